@@ -0,0 +1,39 @@
+package common
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// CIDRSubnetsForZones splits the CIDR block into subnets with the prefix extended by newbits
+// and assigns them to the zones in order, the same way cidrsubnet(cidr, newbits, i) does for
+// the i-th zone.
+func CIDRSubnetsForZones(cidr string, newbits int, zones []string) (map[string]string, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR block %q: %w", cidr, err)
+	}
+
+	prefix, bits := network.Mask.Size()
+	if newbits < 0 || prefix+newbits > bits {
+		return nil, fmt.Errorf("cannot extend prefix /%d of %q by %d bits", prefix, cidr, newbits)
+	}
+	if newbits < 63 && int64(len(zones)) > int64(1)<<newbits {
+		return nil, fmt.Errorf("%q extended by %d bits has only %d subnets for %d zones", cidr, newbits, 1<<newbits, len(zones))
+	}
+
+	base := new(big.Int).SetBytes(network.IP)
+	subnets := make(map[string]string, len(zones))
+	for i, zone := range zones {
+		if _, ok := subnets[zone]; ok {
+			return nil, fmt.Errorf("duplicate zone %q", zone)
+		}
+
+		offset := new(big.Int).Lsh(big.NewInt(int64(i)), uint(bits-prefix-newbits))
+		ip := new(big.Int).Add(base, offset).FillBytes(make([]byte, len(network.IP)))
+		subnet := net.IPNet{IP: ip, Mask: net.CIDRMask(prefix+newbits, bits)}
+		subnets[zone] = subnet.String()
+	}
+	return subnets, nil
+}
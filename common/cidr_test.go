@@ -0,0 +1,97 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCIDRSubnetsForZones(t *testing.T) {
+	cases := []struct {
+		name        string
+		cidr        string
+		newbits     int
+		zones       []string
+		expected    map[string]string
+		expectedErr string
+	}{
+		{
+			name:    "ipv4",
+			cidr:    "10.0.0.0/16",
+			newbits: 8,
+			zones:   []string{"ru-central1-a", "ru-central1-b", "ru-central1-d"},
+			expected: map[string]string{
+				"ru-central1-a": "10.0.0.0/24",
+				"ru-central1-b": "10.0.1.0/24",
+				"ru-central1-d": "10.0.2.0/24",
+			},
+		},
+		{
+			name:    "host bits of the block are ignored",
+			cidr:    "192.168.10.17/24",
+			newbits: 2,
+			zones:   []string{"ru-central1-a", "ru-central1-b"},
+			expected: map[string]string{
+				"ru-central1-a": "192.168.10.0/26",
+				"ru-central1-b": "192.168.10.64/26",
+			},
+		},
+		{
+			name:    "ipv6",
+			cidr:    "fd00::/48",
+			newbits: 16,
+			zones:   []string{"ru-central1-a", "ru-central1-b"},
+			expected: map[string]string{
+				"ru-central1-a": "fd00::/64",
+				"ru-central1-b": "fd00:0:0:1::/64",
+			},
+		},
+		{
+			name:     "no zones",
+			cidr:     "10.0.0.0/16",
+			newbits:  8,
+			expected: map[string]string{},
+		},
+		{
+			name:        "not enough subnets",
+			cidr:        "10.0.0.0/16",
+			newbits:     1,
+			zones:       []string{"ru-central1-a", "ru-central1-b", "ru-central1-d"},
+			expectedErr: `"10.0.0.0/16" extended by 1 bits has only 2 subnets for 3 zones`,
+		},
+		{
+			name:        "prefix too long",
+			cidr:        "10.0.0.0/30",
+			newbits:     3,
+			zones:       []string{"ru-central1-a"},
+			expectedErr: `cannot extend prefix /30 of "10.0.0.0/30" by 3 bits`,
+		},
+		{
+			name:        "duplicate zone",
+			cidr:        "10.0.0.0/16",
+			newbits:     8,
+			zones:       []string{"ru-central1-a", "ru-central1-a"},
+			expectedErr: `duplicate zone "ru-central1-a"`,
+		},
+		{
+			name:        "invalid block",
+			cidr:        "10.0.0.0",
+			newbits:     8,
+			zones:       []string{"ru-central1-a"},
+			expectedErr: `invalid CIDR block "10.0.0.0": invalid CIDR address: 10.0.0.0`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			subnets, err := CIDRSubnetsForZones(tc.cidr, tc.newbits, tc.zones)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, subnets)
+		})
+	}
+}
@@ -0,0 +1,370 @@
+// Package client builds the Yandex Cloud API client shared by the SDK and the framework provider.
+//
+// It owns credential resolution, the SDK with its chain of interceptors, the default Object Storage
+// session and the defaults of the provider settings, so that both providers authenticate and call
+// the API the same way. A muxed provider process builds one client for both of them, see Shared.
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"github.com/yandex-cloud/go-sdk/pkg/requestid"
+	"github.com/yandex-cloud/go-sdk/pkg/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/common/mutexkv"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/apitrace"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/logging"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ratelimit"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tracing"
+)
+
+const (
+	defaultExponentialBackoffBase = 50 * time.Millisecond
+	defaultExponentialBackoffCap  = 1 * time.Minute
+)
+
+// Client is a fully initialized Yandex Cloud API client.
+type Client struct {
+	// Settings are the settings the client is built with, completed with the shared credentials
+	// profile and the default endpoint.
+	Settings Settings
+
+	SDK       *ycsdk.SDK
+	UserAgent string
+
+	// ClientTraceID is sent with every API call of the client.
+	ClientTraceID string
+
+	// S3Session is the Object Storage session with the storage access keys of the settings,
+	// nil if there are no such keys.
+	S3Session *session.Session
+
+	// lockBackend and lockOptions are set by ConfigureLocks to the MutexKVs of the providers.
+	lockBackend mutexkv.Backend
+	lockOptions mutexkv.Options
+
+	// dialOptions are the options the SDK connections are dialed with, see Conn.
+	dialOptions []grpc.DialOption
+
+	connsMu sync.Mutex
+	conns   map[string]*grpc.ClientConn
+	token   *iamTokenCredentials
+
+	// impersonationSDK mints the IAM tokens of the impersonated service account, nil if there is none.
+	impersonationSDK *ycsdk.SDK
+
+	// cancel cancels the context the SDK is built on, see Close.
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+}
+
+var (
+	sharedMu sync.Mutex
+	// sharedClients are keyed by the hash of the settings, so that the credentials are not kept in the keys.
+	sharedClients = make(map[string]*Client)
+)
+
+// Shared returns the client built with the same settings before, or builds a new one.
+// The SDK and the framework provider of a muxed provider process are configured with
+// the same provider block, so they share one client, its connections and its rate limits.
+//
+// The client is closed and forgotten once stopCtx, the stop context of a provider using it, is
+// done. The providers of a process are stopped together, so the other users are stopping as well.
+func Shared(stopCtx context.Context, settings Settings, terraformVersion string, sweeper bool) (*Client, error) {
+	key, err := sharedKey(settings, terraformVersion, sweeper)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	c, ok := sharedClients[key]
+	if !ok {
+		c, err = New(stopCtx, settings, terraformVersion, sweeper)
+		if err != nil {
+			return nil, err
+		}
+		sharedClients[key] = c
+	}
+	context.AfterFunc(stopCtx, func() {
+		sharedMu.Lock()
+		if sharedClients[key] == c {
+			delete(sharedClients, key)
+		}
+		sharedMu.Unlock()
+		c.Close()
+	})
+	return c, nil
+}
+
+func sharedKey(settings Settings, terraformVersion string, sweeper bool) (string, error) {
+	key, err := json.Marshal(struct {
+		Settings         Settings
+		TerraformVersion string
+		Sweeper          bool
+	}{settings, terraformVersion, sweeper})
+	if err != nil {
+		return "", err
+	}
+
+	// The SDK provider leaves the lists of absent blocks nil and the framework provider makes them
+	// empty, so both are dropped from the key.
+	var value interface{}
+	if err := json.Unmarshal(key, &value); err != nil {
+		return "", err
+	}
+	key, err = json.Marshal(withoutEmpty(value))
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(key)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// withoutEmpty returns the decoded JSON value without the nulls, empty arrays and empty objects.
+func withoutEmpty(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if field = withoutEmpty(field); field == nil {
+				delete(v, name)
+			} else {
+				v[name] = field
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		for i := range v {
+			v[i] = withoutEmpty(v[i])
+		}
+	}
+	return value
+}
+
+// New builds a new client. Its SDK is built on a context derived from stopCtx, the stop context
+// of the provider, which is canceled by Close.
+func New(stopCtx context.Context, settings Settings, terraformVersion string, sweeper bool) (*Client, error) {
+	if err := settings.ApplySharedCredentials(); err != nil {
+		return nil, err
+	}
+	if settings.Endpoint == "" {
+		settings.Endpoint = common.DefaultEndpoint
+	}
+
+	c := &Client{
+		Settings:      settings,
+		UserAgent:     userAgent(terraformVersion, sweeper),
+		ClientTraceID: uuid.New().String(),
+	}
+	ctx, cancel := context.WithCancel(c.ContextWithClientTraceID(stopCtx))
+	c.cancel = cancel
+
+	interceptors, err := settings.interceptors()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	headerMD := metadata.Pairs("user-agent", c.UserAgent)
+
+	c.dialOptions = []grpc.DialOption{
+		grpc.WithUserAgent(c.UserAgent),
+		grpc.WithDefaultCallOptions(grpc.Header(&headerMD)),
+		grpc.WithUnaryInterceptor(grpc_middleware.ChainUnaryClient(interceptors...)),
+	}
+
+	// The tokens of an impersonated service account are minted through the same interceptors
+	// as the other API calls, so they are retried, rate limited and traced as well.
+	credentials, impersonationSDK, err := settings.Credentials(ctx, c.dialOptions...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	c.impersonationSDK = impersonationSDK
+
+	c.SDK, err = ycsdk.Build(ctx, settings.sdkConfig(credentials), c.dialOptions...)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	c.S3Session, err = settings.defaultS3Session()
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	if settings.LockBackend != nil {
+		c.lockBackend, c.lockOptions, err = mutexkv.NewBackend(*settings.LockBackend, c.S3Session)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("invalid lock_backend: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// Close cancels the context of the SDK and closes its connections, the ones returned by Conn and
+// the ones minting the IAM tokens of the impersonated service account.
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		c.cancel()
+
+		c.connsMu.Lock()
+		for address, conn := range c.conns {
+			if err := conn.Close(); err != nil {
+				log.Printf("[DEBUG] failed to close connection to %s: %s", address, err)
+			}
+		}
+		c.conns = nil
+		c.connsMu.Unlock()
+
+		if c.SDK != nil {
+			if err := c.SDK.Shutdown(context.Background()); err != nil {
+				log.Printf("[DEBUG] failed to shut down SDK: %s", err)
+			}
+		}
+		if c.impersonationSDK != nil {
+			if err := c.impersonationSDK.Shutdown(context.Background()); err != nil {
+				log.Printf("[DEBUG] failed to shut down impersonation SDK: %s", err)
+			}
+		}
+	})
+}
+
+// ConfigureLocks makes the MutexKV hold its locks in the lock backend of the settings. The SDK and
+// the framework provider share the backend of the client, so their locks exclude each other as well.
+func (c *Client) ConfigureLocks(m *mutexkv.MutexKV) {
+	if c.Settings.LockBackend != nil {
+		m.SetBackend(c.lockBackend, c.lockOptions)
+	}
+}
+
+// ContextWithClientTraceID returns the context with the client trace id in its metadata.
+func (c *Client) ContextWithClientTraceID(ctx context.Context) context.Context {
+	return requestid.ContextWithClientTraceID(ctx, c.ClientTraceID)
+}
+
+func (s *Settings) interceptors() ([]grpc.UnaryClientInterceptor, error) {
+	retryInterceptor := retry.Interceptor(
+		retry.WithMax(s.MaxRetries),
+		retry.WithCodes(codes.Unavailable),
+		retry.WithAttemptHeader(true),
+		retry.WithBackoff(backoffExponentialWithJitter(defaultExponentialBackoffBase, defaultExponentialBackoffCap)))
+	if s.RetryPolicy != nil {
+		retryInterceptor = retrypolicy.Interceptor(s.RetryPolicy)
+	}
+
+	// Tracing interceptor is on top of the chain, so that call spans include retries.
+	var interceptors = []grpc.UnaryClientInterceptor{
+		tracing.UnaryClientInterceptor(),
+		retryInterceptor,
+	}
+
+	// Rate limits are applied below the retries, so that every retry attempt is limited as well.
+	if len(s.RateLimits) > 0 {
+		limiter, err := ratelimit.New(s.RateLimits)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate_limit: %w", err)
+		}
+		interceptors = append(interceptors, limiter.Interceptor())
+	}
+
+	// Make sure retry interceptor is above id interceptor.
+	// Now we will have new request id for every retry attempt.
+	interceptors = append(interceptors, requestid.Interceptor())
+
+	// Support deep API logging in case user has requested it.
+	if os.Getenv("TF_ENABLE_API_LOGGING") != "" {
+		log.Print("[INFO] API logging has been requested, turning on")
+		interceptors = append(interceptors, logging.NewAPILoggingUnaryInterceptor())
+	}
+
+	// Write a structured trace of API calls in case user has requested it.
+	if path := os.Getenv(apitrace.EnvTraceFile); path != "" {
+		log.Printf("[INFO] API trace has been requested, writing it to %s", path)
+		traceInterceptor, err := logging.NewAPITraceFileUnaryInterceptor(path)
+		if err != nil {
+			return nil, err
+		}
+		interceptors = append(interceptors, traceInterceptor)
+	}
+
+	return interceptors, nil
+}
+
+func userAgent(terraformVersion string, sweeper bool) string {
+	if sweeper {
+		return "Terraform Sweeper"
+	}
+	if terraformVersion == "" {
+		// Terraform 0.12 introduced this field to the protocol
+		// We can therefore assume that if it's missing it's 0.10 or 0.11
+		terraformVersion = "0.11+compatible"
+	}
+	return fmt.Sprintf("Terraform/%s (%s) %s", terraformVersion, "https://www.terraform.io", getProviderNameAndVersion())
+}
+
+func backoffExponentialWithJitter(base time.Duration, cap time.Duration) retry.BackoffFunc {
+	return func(attempt int) time.Duration {
+		// First call of BackoffFunc would be with attempt arq equal 0
+		log.Printf("[DEBUG] API call retry attempt %d", attempt+1)
+
+		to := getExponentialTimeout(attempt, base)
+		// Using float types here, because exponential time can be really big, and converting it to time.Duration may
+		// result in undefined behaviour. Its safe conversion, when we have compared it to our 'cap' value.
+		if to > float64(cap) {
+			to = float64(cap)
+		}
+
+		return time.Duration(to * rand.Float64())
+	}
+}
+
+func getExponentialTimeout(attempt int, base time.Duration) float64 {
+	mult := math.Pow(2, float64(attempt))
+	return float64(base) * mult
+}
+
+func getProviderNameAndVersion() string {
+	// version is part of binary name
+	// https://www.terraform.io/docs/configuration/providers.html#plugin-names-and-versions
+	fullBinaryPath := os.Args[0]
+	binaryName := filepath.Base(fullBinaryPath)
+	parts := strings.Split(binaryName, "_")
+
+	if len(parts) < 2 {
+		return "unknown/unknown"
+	}
+
+	parts[1] = strings.TrimPrefix(parts[1], "v")
+
+	return strings.Join(parts[:2], "/")
+}
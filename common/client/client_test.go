@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/resourcemanager/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/apitrace"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/fakecloud"
+)
+
+func TestShared(t *testing.T) {
+	settings := Settings{
+		Endpoint:   "endpoint.secure.me",
+		FolderID:   "folder-id",
+		Token:      "some_special_secured_token",
+		MaxRetries: common.DefaultMaxRetries,
+	}
+
+	stopCtx, stop := context.WithCancel(context.Background())
+	first, err := Shared(stopCtx, settings, "test-terraform", false)
+	require.NoError(t, err)
+	second, err := Shared(context.Background(), settings, "test-terraform", false)
+	require.NoError(t, err)
+	assert.Same(t, first, second, "clients with the same settings must be shared")
+	assert.Contains(t, first.UserAgent, "Terraform/test-terraform")
+
+	settings.FolderID = "other-folder-id"
+	other, err := Shared(context.Background(), settings, "test-terraform", false)
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+	assert.Equal(t, "other-folder-id", other.Settings.FolderID)
+
+	sweeper, err := Shared(context.Background(), settings, "", true)
+	require.NoError(t, err)
+	assert.Equal(t, "Terraform Sweeper", sweeper.UserAgent)
+
+	sharedMu.Lock()
+	for key := range sharedClients {
+		assert.NotContains(t, key, settings.Token, "credentials must not be kept in the cache keys")
+	}
+	sharedMu.Unlock()
+
+	settings.FolderID = "folder-id"
+	stop()
+	require.Eventually(t, func() bool {
+		key, err := sharedKey(settings, "test-terraform", false)
+		require.NoError(t, err)
+		sharedMu.Lock()
+		defer sharedMu.Unlock()
+		_, ok := sharedClients[key]
+		return !ok
+	}, time.Second, time.Millisecond, "client must be released when the provider stops")
+
+	third, err := Shared(context.Background(), settings, "test-terraform", false)
+	require.NoError(t, err)
+	assert.NotSame(t, first, third)
+}
+
+func TestNewDefaultS3Session(t *testing.T) {
+	settings := Settings{
+		Endpoint:         "endpoint.secure.me",
+		Token:            "some_special_secured_token",
+		StorageEndpoint:  common.DefaultStorageEndpoint,
+		StorageAccessKey: "access-key",
+	}
+	_, err := New(context.Background(), settings, "test-terraform", false)
+	assert.Error(t, err, "both storage keys must be set")
+
+	settings.StorageSecretKey = "secret-key"
+	c, err := New(context.Background(), settings, "test-terraform", false)
+	require.NoError(t, err)
+	require.NotNil(t, c.S3Session)
+	credentials, err := c.S3Session.Config.Credentials.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "access-key", credentials.AccessKeyID)
+}
+
+func TestNewImpersonateServiceAccount(t *testing.T) {
+	server, err := fakecloud.NewServer()
+	require.NoError(t, err)
+	t.Cleanup(server.Stop)
+	server.AddCloud("fake-cloud-id", "fake-cloud")
+	server.AddFolder("fake-cloud-id", "fake-folder-id", "fake-folder")
+
+	settings := Settings{
+		Endpoint:   server.Addr(),
+		Token:      fakecloud.Token,
+		Plaintext:  true,
+		MaxRetries: common.DefaultMaxRetries,
+	}
+	c, err := New(context.Background(), settings, "test-terraform", false)
+	require.NoError(t, err)
+	op, err := c.SDK.WrapOperation(c.SDK.IAM().ServiceAccount().Create(context.Background(), &iam.CreateServiceAccountRequest{
+		FolderId: "fake-folder-id",
+		Name:     "terraform",
+	}))
+	require.NoError(t, err)
+	md, err := op.Metadata()
+	require.NoError(t, err)
+	c.Close()
+
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	t.Setenv(apitrace.EnvTraceFile, path)
+	settings.ImpersonateServiceAccountID = md.(*iam.CreateServiceAccountMetadata).ServiceAccountId
+	c, err = New(context.Background(), settings, "test-terraform", false)
+	require.NoError(t, err)
+	require.NotNil(t, c.impersonationSDK)
+
+	_, err = c.SDK.ResourceManager().Folder().Get(context.Background(), &resourcemanager.GetFolderRequest{FolderId: "fake-folder-id"})
+	require.NoError(t, err)
+
+	records, err := apitrace.ReadFile(path)
+	require.NoError(t, err)
+	var methods []string
+	for _, r := range records {
+		methods = append(methods, r.Method)
+	}
+	assert.Contains(t, methods, "/yandex.cloud.iam.v1.IamTokenService/CreateForServiceAccount",
+		"the tokens of the impersonated service account must be minted through the interceptors of the client")
+
+	c.Close()
+	_, err = c.impersonationSDK.IAM().IamToken().CreateForServiceAccount(context.Background(), &iam.CreateIamTokenForServiceAccountRequest{
+		ServiceAccountId: settings.ImpersonateServiceAccountID,
+	})
+	assert.Error(t, err, "the impersonation SDK must be shut down with the client")
+}
+
+func TestInterceptorsTraceRetryAttempts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	t.Setenv(apitrace.EnvTraceFile, path)
+
+	settings := Settings{MaxRetries: 1}
+	interceptors, err := settings.interceptors()
+	require.NoError(t, err)
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if calls == 1 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return interceptor(ctx, method, req, reply, cc, next, opts...)
+		}
+	}
+	require.NoError(t, invoker(context.Background(), "/test.Service/Get", nil, nil, nil))
+
+	records, err := apitrace.ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, 0, records[0].Attempt)
+	assert.Equal(t, "UNAVAILABLE", records[0].StatusCode)
+	assert.Equal(t, 1, records[1].Attempt, "attempt set by the default retry interceptor must be traced")
+}
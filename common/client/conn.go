@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/endpoint"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// iamTokenRefreshMargin is how long before its expiration an IAM token is refreshed.
+const iamTokenRefreshMargin = 5 * time.Minute
+
+// Conn returns a connection to an API endpoint for the services the SDK has no clients for.
+// The endpoint is either an ID listed by the ApiEndpointService, e.g. "vpc", or a host:port address.
+// Calls made through the connection are authenticated with the IAM tokens of the client and pass
+// through the same interceptors as the calls of the SDK.
+func (c *Client) Conn(ctx context.Context, endpointID string) (*grpc.ClientConn, error) {
+	address := endpointID
+	if !strings.Contains(endpointID, ":") {
+		ep, err := c.SDK.ApiEndpoint().ApiEndpoint().Get(ctx, &endpoint.GetApiEndpointRequest{ApiEndpointId: endpointID})
+		if err != nil {
+			return nil, fmt.Errorf("error while resolving API endpoint %q: %w", endpointID, err)
+		}
+		address = ep.GetAddress()
+	}
+
+	c.connsMu.Lock()
+	defer c.connsMu.Unlock()
+
+	if conn, ok := c.conns[address]; ok {
+		return conn, nil
+	}
+	if c.token == nil {
+		c.token = &iamTokenCredentials{sdk: c.SDK, requireTLS: !c.Settings.Plaintext}
+	}
+
+	transport := credentials.NewTLS(&tls.Config{InsecureSkipVerify: c.Settings.Insecure})
+	if c.Settings.Plaintext {
+		transport = insecure.NewCredentials()
+	}
+	opts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(transport),
+		grpc.WithPerRPCCredentials(c.token),
+	}, c.dialOptions...)
+
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error while connecting to API endpoint %q: %w", endpointID, err)
+	}
+	if c.conns == nil {
+		c.conns = make(map[string]*grpc.ClientConn)
+	}
+	c.conns[address] = conn
+	return conn, nil
+}
+
+// iamTokenCredentials authenticate the calls with an IAM token of the SDK, cached until it
+// is close to expiration.
+type iamTokenCredentials struct {
+	sdk        *ycsdk.SDK
+	requireTLS bool
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func (t *iamTokenCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == "" || time.Now().Add(iamTokenRefreshMargin).After(t.expiresAt) {
+		resp, err := t.sdk.CreateIAMToken(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error while creating IAM token: %w", err)
+		}
+		t.token = resp.GetIamToken()
+		t.expiresAt = resp.GetExpiresAt().AsTime()
+	}
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t *iamTokenCredentials) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
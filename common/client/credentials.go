@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"github.com/yandex-cloud/go-sdk/iamkey"
+	"google.golang.org/grpc"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/impersonation"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/workloadidentity"
+)
+
+// Credentials returns the credentials selected by the settings. If a service account
+// to impersonate is set, they are backed by its IAM tokens minted with the selected ones
+// through an SDK dialed with opts. That SDK is returned as well, so that the caller shuts it
+// down once the credentials are no longer used; it is nil otherwise.
+func (s *Settings) Credentials(ctx context.Context, opts ...grpc.DialOption) (ycsdk.Credentials, *ycsdk.SDK, error) {
+	credentials, err := s.baseCredentials(ctx)
+	if err != nil || s.ImpersonateServiceAccountID == "" {
+		return credentials, nil, err
+	}
+
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithUserAgent(getProviderNameAndVersion())}
+	}
+	sdk, err := ycsdk.Build(ctx, s.sdkConfig(credentials), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return impersonation.NewCredentials(s.ImpersonateServiceAccountID, sdk.IAM().IamToken()), sdk, nil
+}
+
+func (s *Settings) baseCredentials(ctx context.Context) (ycsdk.Credentials, error) {
+	if s.WorkloadIdentity != nil {
+		if s.Token != "" || s.ServiceAccountKeyFileOrContent != "" {
+			return nil, fmt.Errorf("'workload_identity' conflicts with 'token' and 'service_account_key_file'")
+		}
+		return workloadidentity.NewCredentials(*s.WorkloadIdentity)
+	}
+
+	if s.ServiceAccountKeyFileOrContent != "" {
+		contents, _, err := pathOrContents(s.ServiceAccountKeyFileOrContent)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading credentials: %s", err)
+		}
+
+		key, err := iamKeyFromJSONContent(contents)
+		if err != nil {
+			return nil, err
+		}
+		return ycsdk.ServiceAccountKey(key)
+	}
+
+	if s.Token != "" {
+		if strings.HasPrefix(s.Token, "t1.") && strings.Count(s.Token, ".") == 2 {
+			return ycsdk.NewIAMTokenCredentials(s.Token), nil
+		}
+		return ycsdk.OAuthToken(s.Token), nil
+	}
+
+	if sa := ycsdk.InstanceServiceAccount(); checkServiceAccountAvailable(ctx, sa) {
+		return sa, nil
+	}
+
+	return nil, fmt.Errorf("one of 'token' or 'service_account_key_file' should be specified; if you are inside compute instance, you can attach service account to it in order to authenticate via instance service account")
+}
+
+func (s *Settings) sdkConfig(credentials ycsdk.Credentials) ycsdk.Config {
+	return ycsdk.Config{
+		Credentials: credentials,
+		Endpoint:    s.Endpoint,
+		Plaintext:   s.Plaintext,
+		TLSConfig: &tls.Config{
+			InsecureSkipVerify: s.Insecure,
+		},
+	}
+}
+
+func iamKeyFromJSONContent(content string) (*iamkey.Key, error) {
+	key := &iamkey.Key{}
+	err := json.Unmarshal([]byte(content), key)
+	if err != nil {
+		return nil, fmt.Errorf("key unmarshal fail: %s", err)
+	}
+	return key, nil
+}
+
+func checkServiceAccountAvailable(ctx context.Context, sa ycsdk.NonExchangeableCredentials) bool {
+	dialer := net.Dialer{Timeout: 50 * time.Millisecond}
+	conn, err := dialer.Dial("tcp", net.JoinHostPort(ycsdk.InstanceMetadataAddr, "80"))
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	_, err = sa.IAMToken(ctx)
+	return err == nil
+}
+
+// copy of github.com/hashicorp/terraform-plugin-sdk/helper/pathorcontents.Read()
+func pathOrContents(poc string) (string, bool, error) {
+	if len(poc) == 0 {
+		return poc, false, nil
+	}
+
+	path := poc
+	if path[0] == '~' {
+		var err error
+		path, err = homedir.Expand(path)
+		if err != nil {
+			return path, true, err
+		}
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		contents, err := ioutil.ReadFile(path)
+		return string(contents), true, err
+	}
+
+	return poc, false, nil
+}
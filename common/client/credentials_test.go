@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/impersonation"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/workloadidentity"
+)
+
+const fakeSAKeyFile = "test-fixtures/fake_service_account_key.json"
+
+func Test_iamKeyFromJSONContent(t *testing.T) {
+	content, err := os.ReadFile(fakeSAKeyFile)
+	require.NoError(t, err, "fail on file read %s", fakeSAKeyFile)
+	_, err = iamKeyFromJSONContent(string(content))
+	require.NoError(t, err)
+}
+
+func TestCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	credentials, impersonationSDK, err := (&Settings{Token: "t1.iam.token"}).Credentials(ctx)
+	require.NoError(t, err)
+	assert.Implements(t, (*ycsdk.NonExchangeableCredentials)(nil), credentials)
+	assert.Nil(t, impersonationSDK)
+
+	credentials, _, err = (&Settings{Token: "oauth-token"}).Credentials(ctx)
+	require.NoError(t, err)
+	assert.Implements(t, (*ycsdk.ExchangeableCredentials)(nil), credentials)
+
+	credentials, _, err = (&Settings{ServiceAccountKeyFileOrContent: fakeSAKeyFile}).Credentials(ctx)
+	require.NoError(t, err)
+	assert.Implements(t, (*ycsdk.ExchangeableCredentials)(nil), credentials)
+
+	workloadIdentity := &workloadidentity.Config{ServiceAccountID: "sa-id", TokenEnv: "CI_JOB_JWT"}
+	credentials, _, err = (&Settings{WorkloadIdentity: workloadIdentity}).Credentials(ctx)
+	require.NoError(t, err)
+	assert.IsType(t, &workloadidentity.Credentials{}, credentials)
+
+	_, _, err = (&Settings{Token: "oauth-token", WorkloadIdentity: workloadIdentity}).Credentials(ctx)
+	assert.Error(t, err)
+
+	credentials, impersonationSDK, err = (&Settings{
+		Endpoint:                    "endpoint.secure.me",
+		Token:                       "oauth-token",
+		ImpersonateServiceAccountID: "target-sa",
+	}).Credentials(ctx)
+	require.NoError(t, err)
+	assert.IsType(t, &impersonation.Credentials{}, credentials)
+	require.NotNil(t, impersonationSDK, "the SDK minting the tokens must be returned to be shut down")
+	assert.NoError(t, impersonationSDK.Shutdown(ctx))
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/grpcjson"
+)
+
+// OperationResult is a completed operation as exposed by yandex_operation.
+type OperationResult struct {
+	Done bool
+	// Error is the error the operation failed with, empty if it succeeded.
+	Error string
+	// MetadataJSON and ResponseJSON are the metadata and the response of the operation
+	// in protobuf JSON format, empty if the operation has none.
+	MetadataJSON string
+	ResponseJSON string
+}
+
+// WaitOperation waits for the operation to complete and returns its result. A failed operation
+// is not an error, it is reported in the Error of the result.
+func WaitOperation(ctx context.Context, sdk *ycsdk.SDK, operationID string) (*OperationResult, error) {
+	op, err := sdk.WrapOperation(sdk.Operation().Get(ctx, &operation.GetOperationRequest{OperationId: operationID}))
+	if err != nil {
+		return nil, fmt.Errorf("error while getting operation %q: %w", operationID, err)
+	}
+	if err := op.Wait(ctx); err != nil && !op.Done() {
+		return nil, fmt.Errorf("error while waiting for operation %q to complete: %w", operationID, err)
+	}
+
+	p := op.Proto()
+	result := &OperationResult{Done: p.GetDone()}
+	if e := p.GetError(); e != nil {
+		result.Error = fmt.Sprintf("%s: %s", codes.Code(e.GetCode()), e.GetMessage())
+	}
+	if result.MetadataJSON, err = anyToJSON(p.GetMetadata()); err != nil {
+		return nil, fmt.Errorf("error while decoding metadata of operation %q: %w", operationID, err)
+	}
+	if result.ResponseJSON, err = anyToJSON(p.GetResponse()); err != nil {
+		return nil, fmt.Errorf("error while decoding response of operation %q: %w", operationID, err)
+	}
+	return result, nil
+}
+
+func anyToJSON(a *anypb.Any) (string, error) {
+	if a == nil {
+		return "", nil
+	}
+	m, err := a.UnmarshalNew()
+	if err != nil {
+		return "", err
+	}
+	return grpcjson.Marshal(m)
+}
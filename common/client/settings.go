@@ -0,0 +1,149 @@
+package client
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/common/mutexkv"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ratelimit"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/workloadidentity"
+)
+
+// Settings are the provider settings the client is built from.
+//
+// Both the SDK and the framework provider fill them from their provider block,
+// settings not set there are taken from the environment variables by SetDefaults
+// and from the shared credentials profile by ApplySharedCredentials.
+type Settings struct {
+	Endpoint                       string
+	FolderID                       string
+	CloudID                        string
+	OrganizationID                 string
+	Zone                           string
+	Region                         string
+	Token                          string
+	ServiceAccountKeyFileOrContent string
+	Plaintext                      bool
+	Insecure                       bool
+	MaxRetries                     int
+	StorageEndpoint                string
+	YMQEndpoint                    string
+
+	// These storage access keys are optional and only used when
+	// storage data/resource doesn't have own access keys explicitly specified.
+	StorageAccessKey string
+	StorageSecretKey string
+
+	// These YMQ access keys are optional and only used when
+	// Message Queue resource doesn't have own access keys explicitly specified.
+	YMQAccessKey string
+	YMQSecretKey string
+
+	SharedCredentialsFile string
+	Profile               string
+
+	// RetryPolicy replaces the default retries of calls failed with codes.Unavailable if set.
+	RetryPolicy *retrypolicy.Policy
+
+	// RateLimits limit the rate and concurrency of API requests per service.
+	RateLimits []ratelimit.Rule
+
+	// WorkloadIdentity exchanges an external OIDC token for an IAM token of a service account.
+	WorkloadIdentity *workloadidentity.Config
+
+	// ImpersonateServiceAccountID is the service account whose IAM tokens, minted with
+	// the configured credentials, authenticate the API calls.
+	ImpersonateServiceAccountID string
+
+	// LockBackend holds the locks of IAM and cloud-wide changes outside of the provider process if set.
+	LockBackend *mutexkv.Config
+}
+
+// SetDefaults fills the settings not set in the provider block from the environment variables,
+// and the rest of them with the default values.
+func (s *Settings) SetDefaults() {
+	s.Endpoint = stringFromEnv(s.Endpoint, "YC_ENDPOINT", "")
+	s.FolderID = stringFromEnv(s.FolderID, "YC_FOLDER_ID", "")
+	s.CloudID = stringFromEnv(s.CloudID, "YC_CLOUD_ID", "")
+	s.OrganizationID = stringFromEnv(s.OrganizationID, "YC_ORGANIZATION_ID", "")
+	s.Region = stringFromEnv(s.Region, "YC_REGION", common.DefaultRegion)
+	s.Zone = stringFromEnv(s.Zone, "YC_ZONE", "")
+	s.Token = stringFromEnv(s.Token, "YC_TOKEN", "")
+	s.ServiceAccountKeyFileOrContent = stringFromEnv(s.ServiceAccountKeyFileOrContent, "YC_SERVICE_ACCOUNT_KEY_FILE", "")
+	s.StorageEndpoint = stringFromEnv(s.StorageEndpoint, "YC_STORAGE_ENDPOINT_URL", common.DefaultStorageEndpoint)
+	s.StorageAccessKey = stringFromEnv(s.StorageAccessKey, "YC_STORAGE_ACCESS_KEY", "")
+	s.StorageSecretKey = stringFromEnv(s.StorageSecretKey, "YC_STORAGE_SECRET_KEY", "")
+	s.YMQEndpoint = stringFromEnv(s.YMQEndpoint, "YC_MESSAGE_QUEUE_ENDPOINT", common.DefaultYMQEndpoint)
+	s.YMQAccessKey = stringFromEnv(s.YMQAccessKey, "YC_MESSAGE_QUEUE_ACCESS_KEY", "")
+	s.YMQSecretKey = stringFromEnv(s.YMQSecretKey, "YC_MESSAGE_QUEUE_SECRET_KEY", "")
+	s.SharedCredentialsFile = stringFromEnv(s.SharedCredentialsFile, "YC_SHARED_CREDENTIALS_FILE", "")
+	s.Profile = stringFromEnv(s.Profile, "YC_PROFILE", "")
+	s.ImpersonateServiceAccountID = stringFromEnv(s.ImpersonateServiceAccountID, "YC_IMPERSONATE_SERVICE_ACCOUNT_ID", "")
+
+	s.Plaintext = s.Plaintext || boolFromEnv("YC_PLAINTEXT")
+	s.Insecure = s.Insecure || boolFromEnv("YC_INSECURE")
+
+	if s.MaxRetries == 0 {
+		s.MaxRetries = common.DefaultMaxRetries
+	}
+}
+
+func stringFromEnv(value string, envName string, defaultValue string) string {
+	return valueOrDefault(valueOrDefault(value, os.Getenv(envName)), defaultValue)
+}
+
+func valueOrDefault(value string, defaultValue string) string {
+	if value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func boolFromEnv(envName string) bool {
+	v, err := strconv.ParseBool(os.Getenv(envName))
+	return err == nil && v
+}
+
+// HasCredentials reports whether any of the mutually exclusive credentials is configured.
+func (s *Settings) HasCredentials() bool {
+	return s.Token != "" || s.ServiceAccountKeyFileOrContent != "" || s.WorkloadIdentity != nil
+}
+
+// ApplySharedCredentials reads the selected profile of the shared credentials file and uses it
+// for the settings not set by the provider attributes or environment variables. The yc CLI config
+// is used if the profile is selected without the file, and its current profile if no credentials
+// are configured at all. Without the yc CLI config nothing is read, so that the instance metadata
+// credentials are used.
+func (s *Settings) ApplySharedCredentials() error {
+	if s.SharedCredentialsFile == "" && (s.Profile != "" || !s.HasCredentials()) {
+		s.SharedCredentialsFile = DefaultSharedCredentialsFile()
+	}
+	if s.SharedCredentialsFile == "" {
+		return nil
+	}
+
+	sharedCredentialsProvider := SharedCredentialsProvider{s.SharedCredentialsFile, s.Profile}
+	sharedCredentials, err := sharedCredentialsProvider.Retrieve()
+	if err != nil {
+		return err
+	}
+
+	// Token and service account key are mutually exclusive, so take them from the profile
+	// only when no credentials are configured at all.
+	if !s.HasCredentials() {
+		s.Token = sharedCredentials.Token
+		s.ServiceAccountKeyFileOrContent = sharedCredentials.ServiceAccountKeyFile
+	}
+	// Storage access keys are taken from the profile only as a pair.
+	if (s.StorageAccessKey == "" || s.StorageSecretKey == "") && sharedCredentials.HasStorageAccessKeys() {
+		s.StorageAccessKey = sharedCredentials.StorageAccessKey
+		s.StorageSecretKey = sharedCredentials.StorageSecretKey
+	}
+	s.CloudID = valueOrDefault(s.CloudID, sharedCredentials.CloudID)
+	s.FolderID = valueOrDefault(s.FolderID, sharedCredentials.FolderID)
+	s.Endpoint = valueOrDefault(s.Endpoint, sharedCredentials.Endpoint)
+	s.Zone = valueOrDefault(s.Zone, sharedCredentials.Zone)
+	return nil
+}
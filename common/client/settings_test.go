@@ -0,0 +1,97 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+func TestSetDefaults(t *testing.T) {
+	t.Setenv("YC_FOLDER_ID", "env-folder")
+	t.Setenv("YC_ZONE", "env-zone")
+	t.Setenv("YC_PLAINTEXT", "true")
+	t.Setenv("YC_INSECURE", "not-a-bool")
+	t.Setenv("YC_REGION", "")
+
+	settings := Settings{Zone: "provider-zone"}
+	settings.SetDefaults()
+
+	assert.Equal(t, "env-folder", settings.FolderID)
+	assert.Equal(t, "provider-zone", settings.Zone)
+	assert.Equal(t, common.DefaultRegion, settings.Region)
+	assert.Equal(t, common.DefaultStorageEndpoint, settings.StorageEndpoint)
+	assert.Equal(t, common.DefaultMaxRetries, settings.MaxRetries)
+	assert.True(t, settings.Plaintext)
+	assert.False(t, settings.Insecure)
+}
+
+func TestApplySharedCredentials(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(filename, []byte(`[default]
+token=profile-token
+folder_id=profile-folder
+cloud_id=profile-cloud
+storage_access_key=profile-access-key
+storage_secret_key=profile-secret-key
+`), 0600))
+
+	settings := Settings{SharedCredentialsFile: filename, CloudID: "provider-cloud", StorageAccessKey: "access-key"}
+	require.NoError(t, settings.ApplySharedCredentials())
+	assert.Equal(t, "profile-token", settings.Token)
+	assert.Equal(t, "profile-folder", settings.FolderID)
+	assert.Equal(t, "provider-cloud", settings.CloudID)
+	assert.Equal(t, "profile-access-key", settings.StorageAccessKey)
+	assert.Equal(t, "profile-secret-key", settings.StorageSecretKey)
+
+	settings = Settings{SharedCredentialsFile: filename, ServiceAccountKeyFileOrContent: "key.json"}
+	require.NoError(t, settings.ApplySharedCredentials())
+	assert.Empty(t, settings.Token, "profile token must not be used along with the service account key")
+}
+
+func TestApplySharedCredentialsYCConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	homedir.Reset()
+	t.Cleanup(homedir.Reset)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".config", "yandex-cloud"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".config", "yandex-cloud", "config.yaml"), []byte(`current: default
+profiles:
+  default:
+    token: default-token
+  testing:
+    token: testing-token
+    folder-id: testing-folder
+`), 0600))
+
+	settings := Settings{}
+	require.NoError(t, settings.ApplySharedCredentials())
+	assert.Equal(t, filepath.Join(home, ".config", "yandex-cloud", "config.yaml"), settings.SharedCredentialsFile)
+	assert.Equal(t, "default-token", settings.Token, "current profile must be used without credentials")
+
+	settings = Settings{Token: "provider-token"}
+	require.NoError(t, settings.ApplySharedCredentials())
+	assert.Empty(t, settings.SharedCredentialsFile, "yc config must not be read along with the credentials")
+	assert.Equal(t, "provider-token", settings.Token)
+
+	settings = Settings{Profile: "testing"}
+	require.NoError(t, settings.ApplySharedCredentials())
+	assert.Equal(t, "testing-token", settings.Token)
+	assert.Equal(t, "testing-folder", settings.FolderID)
+
+	t.Setenv("HOME", t.TempDir())
+	homedir.Reset()
+	settings = Settings{Profile: "testing"}
+	require.NoError(t, settings.ApplySharedCredentials(), "missing yc config must not fail")
+	assert.Empty(t, settings.Token)
+
+	settings = Settings{}
+	require.NoError(t, settings.ApplySharedCredentials(), "missing yc config must not fail")
+	assert.Empty(t, settings.SharedCredentialsFile)
+	assert.Empty(t, settings.Token, "no credentials must fall through to the instance metadata")
+}
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v3"
+)
+
+// Path to the config file written by the yc CLI.
+const ycConfigFile = "~/.config/yandex-cloud/config.yaml"
+
+// Profile used when no profile is selected and the file does not define the current one.
+const defaultProfile = "default"
+
+type Profile = string
+type RawCredentials = map[string]string
+
+// A SharedCredentialsProvider retrieves credentials for the selected profile from the given file.
+type SharedCredentialsProvider struct {
+	// Path to the shared credentials file.
+	Filename string
+
+	// YC Profile to extract credentials from the shared credentials file.
+	// If empty, the current profile of the yc CLI config or the `default` profile is used.
+	Profile string
+}
+
+type SharedCredentials struct {
+	StorageAccessKey string
+	StorageSecretKey string
+
+	// These values are only used when neither the provider attribute nor the environment variable is set.
+	Token                 string
+	ServiceAccountKeyFile string
+	CloudID               string
+	FolderID              string
+	Endpoint              string
+	Zone                  string
+}
+
+// Retrieve reads and extracts the credentials for the selected profile from the given file.
+// Both the `[profile]` key/value format and the YAML config of the yc CLI are supported.
+func (p *SharedCredentialsProvider) Retrieve() (*SharedCredentials, error) {
+	if isYCConfigFile(p.Filename) {
+		return p.retrieveFromYCConfig()
+	}
+
+	rawCredentialsByProfiles, err := parse(p.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse shared credentials file, error: \"%w\"", err)
+	}
+
+	profile := p.Profile
+	if profile == "" {
+		profile = defaultProfile
+	}
+
+	rawCredentials, ok := rawCredentialsByProfiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("not found shared credentials for `%v` profile", profile)
+	}
+
+	return &SharedCredentials{
+		StorageAccessKey:      rawCredentials["storage_access_key"],
+		StorageSecretKey:      rawCredentials["storage_secret_key"],
+		Token:                 rawCredentials["token"],
+		ServiceAccountKeyFile: rawCredentials["service_account_key_file"],
+		CloudID:               rawCredentials["cloud_id"],
+		FolderID:              rawCredentials["folder_id"],
+		Endpoint:              rawCredentials["endpoint"],
+		Zone:                  rawCredentials["zone"],
+	}, nil
+}
+
+// ycConfig is the layout of the config file written by the yc CLI.
+type ycConfig struct {
+	Current  string                      `yaml:"current"`
+	Profiles map[Profile]ycConfigProfile `yaml:"profiles"`
+}
+
+type ycConfigProfile struct {
+	Token              string                 `yaml:"token"`
+	ServiceAccountKey  map[string]interface{} `yaml:"service-account-key"`
+	CloudID            string                 `yaml:"cloud-id"`
+	FolderID           string                 `yaml:"folder-id"`
+	Endpoint           string                 `yaml:"endpoint"`
+	ComputeDefaultZone string                 `yaml:"compute-default-zone"`
+}
+
+func (p *SharedCredentialsProvider) retrieveFromYCConfig() (*SharedCredentials, error) {
+	content, err := os.ReadFile(p.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read yc config file, error: \"%w\"", err)
+	}
+
+	config := ycConfig{}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse yc config file, error: \"%w\"", err)
+	}
+
+	profile := p.Profile
+	if profile == "" {
+		profile = config.Current
+	}
+	if profile == "" {
+		profile = defaultProfile
+	}
+
+	ycProfile, ok := config.Profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("not found `%v` profile in yc config file", profile)
+	}
+
+	credentials := SharedCredentials{
+		Token:    ycProfile.Token,
+		CloudID:  ycProfile.CloudID,
+		FolderID: ycProfile.FolderID,
+		Endpoint: ycProfile.Endpoint,
+		Zone:     ycProfile.ComputeDefaultZone,
+	}
+
+	// yc CLI keeps the authorized key inline, pass it on as the JSON key file contents.
+	if len(ycProfile.ServiceAccountKey) != 0 {
+		key, err := json.Marshal(ycProfile.ServiceAccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account key of `%v` profile, error: \"%w\"", profile, err)
+		}
+		credentials.ServiceAccountKeyFile = string(key)
+	}
+
+	return &credentials, nil
+}
+
+func isYCConfigFile(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// DefaultSharedCredentialsFile returns the path to the yc CLI config if there is one,
+// so that a local yc profile can be used without setting the file.
+func DefaultSharedCredentialsFile() string {
+	path, err := homedir.Expand(ycConfigFile)
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func (p *SharedCredentials) HasStorageAccessKeys() bool {
+	return p.StorageAccessKey != "" && p.StorageSecretKey != ""
+}
+
+func parse(filename string) (map[Profile]RawCredentials, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	credentialsByProfiles, err := parseLines(scanner)
+	if err != nil {
+		return nil, err
+	}
+	if scanner.Err() != nil {
+		return nil, scanner.Err()
+	}
+
+	return credentialsByProfiles, nil
+}
+
+func parseLines(scanner *bufio.Scanner) (map[Profile]RawCredentials, error) {
+	credentialsByProfiles := make(map[Profile]RawCredentials)
+
+	profileRegex := regexp.MustCompile(`^\[(.+)\]$`)
+	keyValuePairRegex := regexp.MustCompile(`^([ \w_]+)=(.+)$`)
+
+	var currentProfile string
+	var currentProfileCredentials RawCredentials
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if profileRegex.MatchString(line) {
+			if currentProfile != "" {
+				credentialsByProfiles[currentProfile] = currentProfileCredentials
+			}
+
+			currentProfile = profileRegex.FindStringSubmatch(line)[1]
+			currentProfileCredentials = make(map[string]string)
+		} else if keyValuePairRegex.MatchString(line) {
+			keyValue := keyValuePairRegex.FindStringSubmatch(line)
+			key := strings.TrimSpace(keyValue[1])
+			value := strings.TrimSpace(keyValue[2])
+			if currentProfile == "" {
+				return nil, fmt.Errorf("key `%v` does not have a profile", key)
+			}
+			if _, exists := currentProfileCredentials[key]; exists {
+				return nil, fmt.Errorf("key `%v` has multiple values", key)
+			}
+			currentProfileCredentials[key] = value
+		} else {
+			return nil, fmt.Errorf("unsupported shared credentials file format, line `%v` does not match "+
+				"either `[{profile}]` or `{key}={value}` patterns", line)
+		}
+	}
+
+	if currentProfile != "" {
+		credentialsByProfiles[currentProfile] = currentProfileCredentials
+	}
+
+	return credentialsByProfiles, nil
+}
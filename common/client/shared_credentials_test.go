@@ -1,7 +1,8 @@
-package yandex
+package client
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"os"
 	"strings"
 	"testing"
@@ -116,3 +117,120 @@ func writeFile(data string) (string, error) {
 
 	return tmpFile.Name(), err
 }
+
+func TestRetrieveProviderSettings(t *testing.T) {
+	fileContent := "[prod-profile]\n" +
+		"token=prod-token\n" +
+		"service_account_key_file=/path/to/key.json\n" +
+		"cloud_id=prod-cloud\n" +
+		"folder_id=prod-folder\n" +
+		"endpoint=api.example.com:443\n" +
+		"zone=ru-central1-b\n" +
+		"\n" +
+		"[default]\n" +
+		"folder_id=default-folder\n"
+
+	filename, err := writeFile(fileContent)
+	require.NoError(t, err)
+	defer os.Remove(filename)
+
+	sharedCredentialsFileProvider := SharedCredentialsProvider{filename, "prod-profile"}
+	result, err := sharedCredentialsFileProvider.Retrieve()
+	require.NoError(t, err)
+	assert.Equal(t, &SharedCredentials{
+		Token:                 "prod-token",
+		ServiceAccountKeyFile: "/path/to/key.json",
+		CloudID:               "prod-cloud",
+		FolderID:              "prod-folder",
+		Endpoint:              "api.example.com:443",
+		Zone:                  "ru-central1-b",
+	}, result)
+
+	sharedCredentialsFileProvider = SharedCredentialsProvider{filename, ""}
+	result, err = sharedCredentialsFileProvider.Retrieve()
+	require.NoError(t, err)
+	assert.Equal(t, &SharedCredentials{FolderID: "default-folder"}, result)
+}
+
+func TestRetrieveFromYCConfig(t *testing.T) {
+	fileContent := `current: dev
+profiles:
+  dev:
+    token: dev-oauth-token
+    cloud-id: dev-cloud
+    folder-id: dev-folder
+    compute-default-zone: ru-central1-a
+  prod:
+    service-account-key:
+      id: key-id
+      service_account_id: sa-id
+      key_algorithm: RSA_2048
+      private_key: private-key
+    cloud-id: prod-cloud
+    folder-id: prod-folder
+    endpoint: api.example.com:443
+`
+
+	cases := []struct {
+		name                string
+		profile             string
+		expectedCredentials *SharedCredentials
+		expectedError       string
+	}{
+		{
+			name:    "current profile",
+			profile: "",
+			expectedCredentials: &SharedCredentials{
+				Token:    "dev-oauth-token",
+				CloudID:  "dev-cloud",
+				FolderID: "dev-folder",
+				Zone:     "ru-central1-a",
+			},
+		},
+		{
+			name:    "selected profile with service account key",
+			profile: "prod",
+			expectedCredentials: &SharedCredentials{
+				ServiceAccountKeyFile: `{"id":"key-id","key_algorithm":"RSA_2048","private_key":"private-key","service_account_id":"sa-id"}`,
+				CloudID:               "prod-cloud",
+				FolderID:              "prod-folder",
+				Endpoint:              "api.example.com:443",
+			},
+		},
+		{
+			name:          "no given profile",
+			profile:       "testing",
+			expectedError: "not found `testing` profile in yc config file",
+		},
+	}
+
+	filename, err := writeYCConfigFile(fileContent)
+	require.NoError(t, err)
+	defer os.Remove(filename)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sharedCredentialsFileProvider := SharedCredentialsProvider{filename, tc.profile}
+
+			result, err := sharedCredentialsFileProvider.Retrieve()
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCredentials, result)
+		})
+	}
+}
+
+func writeYCConfigFile(data string) (string, error) {
+	tmpFile, err := os.CreateTemp("", "yc-config-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	defer tmpFile.Close()
+
+	_, err = tmpFile.WriteString(data)
+	return tmpFile.Name(), err
+}
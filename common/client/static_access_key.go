@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common/mutexkv"
+)
+
+// TemporaryStaticAccessKey is a static access key of a temporary service account
+// that has a role in a folder.
+type TemporaryStaticAccessKey struct {
+	FolderID         string
+	ServiceAccountID string
+
+	// KeyID is the ID of the access key resource, AccessKey is the key ID used in S3 requests.
+	KeyID     string
+	AccessKey string
+	SecretKey string
+}
+
+// CreateTemporaryStaticAccessKey creates a service account with the role in the folder and
+// a static access key of it. Both are deleted by Delete of the returned key. The mutexKV is the
+// one the folder IAM resources lock the folder access bindings with.
+func CreateTemporaryStaticAccessKey(ctx context.Context, sdk *ycsdk.SDK, folderID, roleID string, mutexKV *mutexkv.MutexKV) (*TemporaryStaticAccessKey, error) {
+	op, err := sdk.WrapOperation(sdk.IAM().ServiceAccount().Create(ctx, &iam.CreateServiceAccountRequest{
+		FolderId: folderID,
+		Name:     acctest.RandomWithPrefix("tmp-sa-"),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	protoMetadata, err := op.Metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	md, ok := protoMetadata.(*iam.CreateServiceAccountMetadata)
+	if !ok {
+		return nil, fmt.Errorf("could not get temporary service account ID from create operation metadata")
+	}
+
+	key := &TemporaryStaticAccessKey{
+		FolderID:         folderID,
+		ServiceAccountID: md.ServiceAccountId,
+	}
+
+	if err := op.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	sak, err := key.create(ctx, sdk, roleID)
+	if err != nil {
+		if deleteErr := key.deleteServiceAccount(ctx, sdk, mutexKV); deleteErr != nil {
+			err = errors.Join(err, fmt.Errorf("error deleting temporary service account: %w", deleteErr))
+		}
+		return nil, err
+	}
+
+	key.KeyID = sak.AccessKey.Id
+	key.AccessKey = sak.AccessKey.KeyId
+	key.SecretKey = sak.Secret
+	return key, nil
+}
+
+func (k *TemporaryStaticAccessKey) create(ctx context.Context, sdk *ycsdk.SDK, roleID string) (*awscompatibility.CreateAccessKeyResponse, error) {
+	op, err := sdk.WrapOperation(sdk.ResourceManager().Folder().UpdateAccessBindings(ctx, &access.UpdateAccessBindingsRequest{
+		ResourceId: k.FolderID,
+		AccessBindingDeltas: []*access.AccessBindingDelta{
+			{
+				Action: access.AccessBindingAction_ADD,
+				AccessBinding: &access.AccessBinding{
+					RoleId: roleID,
+					Subject: &access.Subject{
+						Id:   k.ServiceAccountID,
+						Type: "serviceAccount",
+					},
+				},
+			},
+		},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := op.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return sdk.IAM().AWSCompatibility().AccessKey().Create(ctx, &awscompatibility.CreateAccessKeyRequest{
+		ServiceAccountId: k.ServiceAccountID,
+	})
+}
+
+// Delete deletes the access key and its temporary service account. The service account is deleted
+// even if the access key could not be deleted.
+func (k *TemporaryStaticAccessKey) Delete(ctx context.Context, sdk *ycsdk.SDK, mutexKV *mutexkv.MutexKV) error {
+	var errs []error
+	if k.KeyID != "" {
+		_, err := sdk.IAM().AWSCompatibility().AccessKey().Delete(ctx, &awscompatibility.DeleteAccessKeyRequest{
+			AccessKeyId: k.KeyID,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error deleting temporary access key: %w", err))
+		}
+	}
+
+	if err := k.deleteServiceAccount(ctx, sdk, mutexKV); err != nil {
+		errs = append(errs, fmt.Errorf("error deleting temporary service account: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func (k *TemporaryStaticAccessKey) deleteServiceAccount(ctx context.Context, sdk *ycsdk.SDK, mutexKV *mutexkv.MutexKV) error {
+	// Lock the folder in a similar way iam resources do to prevent modifying folder access binding while
+	// service account is being deleted.
+	mutexKey := fmt.Sprintf("iam-folder-%s", k.FolderID)
+	if err := mutexKV.LockContext(ctx, mutexKey); err != nil {
+		return err
+	}
+	defer mutexKV.Unlock(mutexKey)
+
+	op, err := sdk.WrapOperation(sdk.IAM().ServiceAccount().Delete(ctx, &iam.DeleteServiceAccountRequest{
+		ServiceAccountId: k.ServiceAccountID,
+	}))
+	if err != nil {
+		return err
+	}
+	return op.Wait(ctx)
+}
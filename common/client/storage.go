@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultS3Region = "ru-central1"
+
+// NewS3Session returns an Object Storage session authenticated with the static access key.
+func NewS3Session(url, accessKey, secretKey string) (*session.Session, error) {
+	if url == "" {
+		return nil, fmt.Errorf("failed to create storage client, endpoint url is not specified")
+	}
+
+	s3Config := &aws.Config{
+		Credentials: credentials.NewStaticCredentials(accessKey, secretKey, ""),
+		Endpoint:    aws.String(url),
+		Region:      aws.String(defaultS3Region),
+	}
+
+	newSession, err := session.NewSession(s3Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage client: %w", err)
+	}
+
+	return newSession, nil
+}
+
+// NewS3Client returns an Object Storage client authenticated with the static access key. If the key
+// is not set, the client of the default session with the provider storage access keys is returned.
+func NewS3Client(ctx context.Context, url, accessKey, secretKey string, defaultSession *session.Session) (*s3.S3, error) {
+	if accessKey == "" || secretKey == "" {
+		if defaultSession == nil {
+			return nil, fmt.Errorf("failed to get default storage client")
+		}
+
+		return newS3Client(ctx, defaultSession), nil
+	}
+
+	newSession, err := NewS3Session(url, accessKey, secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newS3Client(ctx, newSession), nil
+}
+
+func newS3Client(ctx context.Context, session *session.Session) *s3.S3 {
+	additionalS3Config := &aws.Config{
+		LogLevel: aws.LogLevel(aws.LogDebug),
+		Logger: aws.LoggerFunc(func(args ...any) {
+			tflog.Debug(ctx, fmt.Sprint(args...))
+		}),
+	}
+
+	return s3.New(session, additionalS3Config)
+}
+
+// NewYMQConfig returns the Message Queue client config authenticated with the static access key.
+func NewYMQConfig(endpoint, region, accessKey, secretKey string) *aws.Config {
+	return &aws.Config{
+		Credentials: credentials.NewStaticCredentials(accessKey, secretKey, ""),
+		Endpoint:    aws.String(endpoint),
+		Region:      aws.String(region),
+	}
+}
+
+func (s *Settings) defaultS3Session() (*session.Session, error) {
+	if s.StorageEndpoint == "" || (s.StorageAccessKey == "" && s.StorageSecretKey == "") {
+		return nil, nil
+	}
+
+	if s.StorageAccessKey == "" || s.StorageSecretKey == "" {
+		return nil, fmt.Errorf("both storage access key and storage secret key should be specified or not specified")
+	}
+
+	return NewS3Session(s.StorageEndpoint, s.StorageAccessKey, s.StorageSecretKey)
+}
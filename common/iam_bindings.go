@@ -0,0 +1,82 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+)
+
+// CheckIAMBindingsUnchanged returns an error with the difference of the bindings, if the current
+// ones of the described resource differ from the expected ones, i.e. those read to plan the change.
+func CheckIAMBindingsUnchanged(resource string, expected, current []*access.AccessBinding) error {
+	diff := IAMBindingsDiff(expected, current)
+	if len(diff) == 0 {
+		return nil
+	}
+	return fmt.Errorf("access bindings of %s changed since they were read to plan the change, "+
+		"refusing to overwrite them:\n%s\n\nReview the change and apply it again, it will be planned against "+
+		"the current access bindings.", resource, strings.Join(diff, "\n"))
+}
+
+// IAMBindingsDiff returns the lines of the bindings added ("+") and removed ("-") in current compared
+// to expected, sorted by role and member, no lines if they are the same.
+func IAMBindingsDiff(expected, current []*access.AccessBinding) []string {
+	type change struct{ sign, role, member string }
+
+	expectedMembers := iamRolesToMembers(expected)
+	currentMembers := iamRolesToMembers(current)
+
+	var changes []change
+	for role, members := range currentMembers {
+		for member := range members {
+			if !expectedMembers[role][member] {
+				changes = append(changes, change{"+", role, member})
+			}
+		}
+	}
+	for role, members := range expectedMembers {
+		for member := range members {
+			if !currentMembers[role][member] {
+				changes = append(changes, change{"-", role, member})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].role != changes[j].role {
+			return changes[i].role < changes[j].role
+		}
+		return changes[i].member < changes[j].member
+	})
+
+	diff := make([]string, 0, len(changes))
+	for _, c := range changes {
+		diff = append(diff, fmt.Sprintf("  %s %s: %s", c.sign, c.role, c.member))
+	}
+	return diff
+}
+
+// IAMBindingsOfRole returns the bindings of the role.
+func IAMBindingsOfRole(role string, bindings []*access.AccessBinding) []*access.AccessBinding {
+	var result []*access.AccessBinding
+	for _, b := range bindings {
+		if b.RoleId == role {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// iamRolesToMembers returns the set of the members in TYPE:ID format of each role of the bindings.
+func iamRolesToMembers(bindings []*access.AccessBinding) map[string]map[string]bool {
+	members := make(map[string]map[string]bool)
+	for _, b := range bindings {
+		if members[b.RoleId] == nil {
+			members[b.RoleId] = make(map[string]bool)
+		}
+		members[b.RoleId][b.GetSubject().GetType()+":"+b.GetSubject().GetId()] = true
+	}
+	return members
+}
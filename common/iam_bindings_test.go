@@ -0,0 +1,70 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+)
+
+func testBindings(roleMembers ...string) []*access.AccessBinding {
+	var bindings []*access.AccessBinding
+	for i := 0; i < len(roleMembers); i += 2 {
+		subjectType, id, _ := strings.Cut(roleMembers[i+1], ":")
+		bindings = append(bindings, &access.AccessBinding{
+			RoleId:  roleMembers[i],
+			Subject: &access.Subject{Type: subjectType, Id: id},
+		})
+	}
+	return bindings
+}
+
+func TestIAMBindingsDiff(t *testing.T) {
+	expected := testBindings(
+		"editor", "userAccount:alice",
+		"viewer", "userAccount:bob",
+		"viewer", "serviceAccount:ci",
+	)
+
+	assert.Empty(t, IAMBindingsDiff(expected, testBindings(
+		"viewer", "serviceAccount:ci",
+		"editor", "userAccount:alice",
+		"viewer", "userAccount:bob",
+		"viewer", "userAccount:bob",
+	)), "order and duplicates of the bindings must not matter")
+
+	assert.Equal(t, []string{
+		"  + admin: userAccount:mallory",
+		"  - editor: userAccount:alice",
+		"  + editor: userAccount:carol",
+		"  - viewer: serviceAccount:ci",
+	}, IAMBindingsDiff(expected, testBindings(
+		"admin", "userAccount:mallory",
+		"editor", "userAccount:carol",
+		"viewer", "userAccount:bob",
+	)))
+}
+
+func TestCheckIAMBindingsUnchanged(t *testing.T) {
+	expected := testBindings("viewer", "userAccount:bob")
+
+	assert.NoError(t, CheckIAMBindingsUnchanged("fake resource", expected, testBindings("viewer", "userAccount:bob")))
+
+	err := CheckIAMBindingsUnchanged("fake resource", expected, testBindings(
+		"viewer", "userAccount:bob",
+		"viewer", "userAccount:out-of-band",
+	))
+	assert.ErrorContains(t, err, "access bindings of fake resource changed")
+	assert.ErrorContains(t, err, "+ viewer: userAccount:out-of-band")
+}
+
+func TestIAMBindingsOfRole(t *testing.T) {
+	bindings := testBindings(
+		"viewer", "userAccount:bob",
+		"editor", "userAccount:alice",
+		"viewer", "serviceAccount:ci",
+	)
+	assert.Equal(t, []*access.AccessBinding{bindings[0], bindings[2]}, IAMBindingsOfRole("viewer", bindings))
+	assert.Empty(t, IAMBindingsOfRole("admin", bindings))
+}
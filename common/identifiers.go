@@ -0,0 +1,45 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StorageWebsiteDomain is the domain of the Object Storage bucket websites.
+const StorageWebsiteDomain = "website.yandexcloud.net"
+
+// ParseVirtualHostID splits the ID of an ALB virtual host, which is "<http_router_id>/<name>".
+func ParseVirtualHostID(id string) (httpRouterID, name string, err error) {
+	attrs := strings.Split(id, "/")
+	if len(attrs) < 2 {
+		return "", "", fmt.Errorf("error reading virtual_host, wrong id: %q", id)
+	}
+	return attrs[0], attrs[1], nil
+}
+
+// IAMMember returns the IAM member of the subject in TYPE:ID format, e.g. "serviceAccount:<id>".
+func IAMMember(subjectType, id string) (string, error) {
+	if id == "" {
+		return "", fmt.Errorf("IAM member ID should not be empty")
+	}
+	member := subjectType + ":" + id
+	if err := ValidateIAMMember(member); err != nil {
+		return "", err
+	}
+	return member, nil
+}
+
+// ValidateIAMMember returns an error if the IAM member is not in TYPE:ID format with a non-empty
+// type and ID. The type is not checked against the known ones, so that new subject types are accepted.
+func ValidateIAMMember(member string) error {
+	subjectType, id, ok := strings.Cut(member, ":")
+	if !ok || subjectType == "" || id == "" {
+		return fmt.Errorf("expect 'member' value should be in TYPE:ID format, got '%s'", member)
+	}
+	return nil
+}
+
+// StorageWebsiteEndpoint returns the website endpoint of the Object Storage bucket.
+func StorageWebsiteEndpoint(bucket string) string {
+	return fmt.Sprintf("%s.%s", bucket, StorageWebsiteDomain)
+}
@@ -0,0 +1,90 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVirtualHostID(t *testing.T) {
+	httpRouterID, name, err := ParseVirtualHostID("router-id/host-name")
+	require.NoError(t, err)
+	assert.Equal(t, "router-id", httpRouterID)
+	assert.Equal(t, "host-name", name)
+
+	_, _, err = ParseVirtualHostID("router-id")
+	assert.EqualError(t, err, `error reading virtual_host, wrong id: "router-id"`)
+}
+
+func TestIAMMember(t *testing.T) {
+	cases := []struct {
+		name        string
+		subjectType string
+		id          string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:        "service account",
+			subjectType: "serviceAccount",
+			id:          "aje123",
+			expected:    "serviceAccount:aje123",
+		},
+		{
+			name:        "group",
+			subjectType: "group",
+			id:          "ajg456",
+			expected:    "group:ajg456",
+		},
+		{
+			name:        "system",
+			subjectType: "system",
+			id:          "allAuthenticatedUsers",
+			expected:    "system:allAuthenticatedUsers",
+		},
+		{
+			name:        "other type",
+			subjectType: "robot",
+			id:          "aje123",
+			expected:    "robot:aje123",
+		},
+		{
+			name:        "empty type",
+			id:          "aje123",
+			expectedErr: "expect 'member' value should be in TYPE:ID format, got ':aje123'",
+		},
+		{
+			name:        "empty id",
+			subjectType: "userAccount",
+			expectedErr: "IAM member ID should not be empty",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			member, err := IAMMember(tc.subjectType, tc.id)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, member)
+		})
+	}
+}
+
+func TestValidateIAMMember(t *testing.T) {
+	assert.NoError(t, ValidateIAMMember("userAccount:aje123"))
+	assert.NoError(t, ValidateIAMMember("system:group:organization:org123:users"), "the ID may contain colons")
+	assert.NoError(t, ValidateIAMMember("newSubjectType:aje123"), "unknown types must be accepted")
+
+	for _, member := range []string{"", "aje123", ":aje123", "userAccount:"} {
+		assert.EqualError(t, ValidateIAMMember(member),
+			"expect 'member' value should be in TYPE:ID format, got '"+member+"'")
+	}
+}
+
+func TestStorageWebsiteEndpoint(t *testing.T) {
+	assert.Equal(t, "my-bucket.website.yandexcloud.net", StorageWebsiteEndpoint("my-bucket"))
+}
@@ -0,0 +1,38 @@
+package common
+
+// MergeLabels returns the provider default labels overlaid with the resource own labels.
+// Resource-level values win over the defaults.
+func MergeLabels(defaults, own map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(own))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range own {
+		merged[k] = v
+	}
+	return merged
+}
+
+// StripDefaultLabels removes the provider default labels from the labels returned by the API,
+// so that only the labels set on the resource itself end up in its "labels" attribute.
+// previousAll are the labels of the resource including the defaults as of the last apply or read,
+// its labels missing from own were derived from the defaults then.
+// A label is kept if the resource sets it explicitly. Otherwise it is stripped if it was derived
+// from the defaults, even if the defaults changed since then, or if it has the default value.
+// The rest of the labels, e.g. set out of band, are kept.
+func StripDefaultLabels(all, own, previousAll, defaults map[string]string) map[string]string {
+	stripped := make(map[string]string, len(all))
+	for k, v := range all {
+		if _, ok := own[k]; ok {
+			stripped[k] = v
+			continue
+		}
+		if _, ok := previousAll[k]; ok {
+			continue
+		}
+		if dv, ok := defaults[k]; !ok || dv != v {
+			stripped[k] = v
+		}
+	}
+	return stripped
+}
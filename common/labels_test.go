@@ -0,0 +1,102 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLabels(t *testing.T) {
+	cases := []struct {
+		name     string
+		defaults map[string]string
+		own      map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "no labels",
+			expected: map[string]string{},
+		},
+		{
+			name:     "defaults only",
+			defaults: map[string]string{"env": "dev"},
+			expected: map[string]string{"env": "dev"},
+		},
+		{
+			name:     "own labels win",
+			defaults: map[string]string{"env": "dev", "owner": "tf-team"},
+			own:      map[string]string{"env": "prod", "app": "web"},
+			expected: map[string]string{"env": "prod", "owner": "tf-team", "app": "web"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MergeLabels(tc.defaults, tc.own))
+		})
+	}
+}
+
+func TestStripDefaultLabels(t *testing.T) {
+	cases := []struct {
+		name     string
+		all      map[string]string
+		own      map[string]string
+		previous map[string]string
+		defaults map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "no defaults",
+			all:      map[string]string{"app": "web"},
+			own:      map[string]string{"app": "web"},
+			expected: map[string]string{"app": "web"},
+		},
+		{
+			name:     "defaults are stripped",
+			all:      map[string]string{"app": "web", "env": "dev"},
+			own:      map[string]string{"app": "web"},
+			defaults: map[string]string{"env": "dev"},
+			expected: map[string]string{"app": "web"},
+		},
+		{
+			name:     "explicitly set default is kept",
+			all:      map[string]string{"env": "dev"},
+			own:      map[string]string{"env": "dev"},
+			defaults: map[string]string{"env": "dev"},
+			expected: map[string]string{"env": "dev"},
+		},
+		{
+			name:     "default changed out of band is kept",
+			all:      map[string]string{"env": "prod"},
+			defaults: map[string]string{"env": "dev"},
+			expected: map[string]string{"env": "prod"},
+		},
+		{
+			name:     "label added out of band is kept",
+			all:      map[string]string{"app": "web"},
+			defaults: map[string]string{"env": "dev"},
+			expected: map[string]string{"app": "web"},
+		},
+		{
+			name:     "previously derived from changed defaults is stripped",
+			all:      map[string]string{"app": "web", "env": "dev"},
+			own:      map[string]string{"app": "web"},
+			previous: map[string]string{"app": "web", "env": "dev"},
+			defaults: map[string]string{"env": "prod"},
+			expected: map[string]string{"app": "web"},
+		},
+		{
+			name:     "previously derived from removed defaults is stripped",
+			all:      map[string]string{"env": "dev"},
+			previous: map[string]string{"env": "dev"},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, StripDefaultLabels(tc.all, tc.own, tc.previous, tc.defaults))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package mutexkv
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+)
+
+// Backend holds the locks of MutexKV outside of the provider process.
+type Backend interface {
+	// Acquire takes the lease of the key, or extends it if the key is already held with
+	// the same lease ID. It returns a *LockedError if the key is held by another lease.
+	Acquire(ctx context.Context, key string, lease Lease) error
+	// Release gives up the lease of the key, unless the key is no longer held with it.
+	Release(ctx context.Context, key string, lease Lease) error
+}
+
+// Lease is the record of a held lock.
+type Lease struct {
+	// ID identifies a single acquisition of the lock.
+	ID string `json:"id"`
+	// Holder describes the process holding the lock.
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// LockedError is returned when a key is held by another process.
+type LockedError struct {
+	Key string
+	// Lease is the lease the key is held with, nil if it is unknown.
+	Lease *Lease
+}
+
+func (e *LockedError) Error() string {
+	if e.Lease == nil {
+		return fmt.Sprintf("lock %q held by another process", e.Key)
+	}
+	return fmt.Sprintf("lock %q held by %s since %s, its lease expires at %s", e.Key, e.Lease.Holder,
+		e.Lease.AcquiredAt.Format(time.RFC3339), e.Lease.ExpiresAt.Format(time.RFC3339))
+}
+
+func newLeaseID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating lease ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func defaultHolder() string {
+	holder, err := os.Hostname()
+	if err != nil {
+		holder = "unknown host"
+	}
+	if u, err := user.Current(); err == nil {
+		holder = u.Username + "@" + holder
+	}
+	return fmt.Sprintf("%s, pid %d", holder, os.Getpid())
+}
@@ -0,0 +1,109 @@
+package mutexkv
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// The types of the lock backends.
+const (
+	BackendMemory  = "memory"
+	BackendFile    = "file"
+	BackendStorage = "storage"
+	BackendYDB     = "ydb"
+)
+
+// minLeaseDuration leaves the time for a few renewals of a lease before it expires.
+const minLeaseDuration = 10 * time.Second
+
+// DefaultStoragePrefix is the prefix of the lease objects in an Object Storage bucket.
+const DefaultStoragePrefix = "terraform-provider-yandex/locks"
+
+// Config describes the lock backend of the provider.
+type Config struct {
+	// Type is one of BackendMemory, BackendFile, BackendStorage and BackendYDB.
+	Type string
+	// Path is the directory of the lock files of the file backend.
+	Path string
+	// Bucket and Prefix locate the lease objects of the storage backend.
+	Bucket string
+	Prefix string
+	// Endpoint and Table locate the lease items of the ydb backend, the endpoint
+	// is the Document API endpoint of the database.
+	Endpoint string
+	Table    string
+
+	// Timeout and LeaseDuration are the durations of Options, parsed with time.ParseDuration.
+	Timeout       string
+	LeaseDuration string
+	Holder        string
+}
+
+// NewBackend returns the backend of the config and the options to set it with. The storage and
+// ydb backends are authenticated with the session, e.g. the one with the provider storage access keys.
+func NewBackend(config Config, sess *session.Session) (Backend, Options, error) {
+	options := Options{Holder: config.Holder}
+	var err error
+	if options.Timeout, err = parseDuration("timeout", config.Timeout); err != nil {
+		return nil, options, err
+	}
+	if options.LeaseDuration, err = parseDuration("lease_duration", config.LeaseDuration); err != nil {
+		return nil, options, err
+	}
+	if options.LeaseDuration != 0 && options.LeaseDuration < minLeaseDuration {
+		return nil, options, fmt.Errorf("invalid lease_duration: must be at least %s", minLeaseDuration)
+	}
+
+	switch config.Type {
+	case "", BackendMemory:
+		return nil, options, nil
+	case BackendFile:
+		if config.Path == "" {
+			return nil, options, fmt.Errorf("path of the file lock backend must be specified")
+		}
+		backend, err := NewFileBackend(config.Path)
+		return backend, options, err
+	case BackendStorage:
+		if config.Bucket == "" {
+			return nil, options, fmt.Errorf("bucket of the storage lock backend must be specified")
+		}
+		if sess == nil {
+			return nil, options, fmt.Errorf("storage lock backend requires storage_access_key and storage_secret_key")
+		}
+		prefix := config.Prefix
+		if prefix == "" {
+			prefix = DefaultStoragePrefix
+		}
+		return NewLeaseBackend(NewStorageLeaseStore(s3.New(sess), config.Bucket, prefix)), options, nil
+	case BackendYDB:
+		if config.Endpoint == "" || config.Table == "" {
+			return nil, options, fmt.Errorf("endpoint and table of the ydb lock backend must be specified")
+		}
+		if sess == nil {
+			return nil, options, fmt.Errorf("ydb lock backend requires storage_access_key and storage_secret_key")
+		}
+		client := dynamodb.New(sess, aws.NewConfig().WithEndpoint(config.Endpoint))
+		return NewLeaseBackend(NewDocAPILeaseStore(client, config.Table)), options, nil
+	default:
+		return nil, options, fmt.Errorf("unknown lock backend type %q", config.Type)
+	}
+}
+
+func parseDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid %s: must not be negative", name)
+	}
+	return d, nil
+}
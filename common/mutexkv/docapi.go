@@ -0,0 +1,115 @@
+package mutexkv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// The attributes of the lease items of a YDB table.
+const (
+	docAPIKeyAttribute     = "lock_key"
+	docAPILeaseAttribute   = "lease"
+	docAPIVersionAttribute = "version"
+)
+
+// DocAPILeaseStore keeps the leases as items of a YDB table accessed through its Document API.
+// The table must have the lock_key string partition key. Every write of an item sets a new
+// version attribute, which the conditional writes of the other processes are checked against.
+type DocAPILeaseStore struct {
+	client *dynamodb.DynamoDB
+	table  string
+}
+
+// NewDocAPILeaseStore returns the store keeping the lease items in the table.
+func NewDocAPILeaseStore(client *dynamodb.DynamoDB, table string) *DocAPILeaseStore {
+	return &DocAPILeaseStore{
+		client: client,
+		table:  table,
+	}
+}
+
+func (s *DocAPILeaseStore) Get(ctx context.Context, key string) (*Lease, string, error) {
+	out, err := s.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(s.table),
+		Key:            s.itemKey(key),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if out.Item == nil {
+		return nil, "", nil
+	}
+
+	lease := &Lease{}
+	if err := json.Unmarshal([]byte(aws.StringValue(out.Item[docAPILeaseAttribute].S)), lease); err != nil {
+		return nil, "", err
+	}
+	return lease, aws.StringValue(out.Item[docAPIVersionAttribute].S), nil
+}
+
+func (s *DocAPILeaseStore) Create(ctx context.Context, key string, lease Lease) error {
+	return s.put(ctx, key, lease, &dynamodb.PutItemInput{
+		ConditionExpression: aws.String("attribute_not_exists(" + docAPIKeyAttribute + ")"),
+	})
+}
+
+func (s *DocAPILeaseStore) Replace(ctx context.Context, key string, lease Lease, version string) error {
+	return s.put(ctx, key, lease, &dynamodb.PutItemInput{
+		ConditionExpression:       aws.String(docAPIVersionAttribute + " = :version"),
+		ExpressionAttributeValues: versionValues(version),
+	})
+}
+
+func (s *DocAPILeaseStore) Delete(ctx context.Context, key string, version string) error {
+	_, err := s.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName:                 aws.String(s.table),
+		Key:                       s.itemKey(key),
+		ConditionExpression:       aws.String(docAPIVersionAttribute + " = :version"),
+		ExpressionAttributeValues: versionValues(version),
+	})
+	return docAPIError(err)
+}
+
+func (s *DocAPILeaseStore) put(ctx context.Context, key string, lease Lease, input *dynamodb.PutItemInput) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	version, err := newLeaseID()
+	if err != nil {
+		return err
+	}
+
+	input.TableName = aws.String(s.table)
+	input.Item = s.itemKey(key)
+	input.Item[docAPILeaseAttribute] = &dynamodb.AttributeValue{S: aws.String(string(data))}
+	input.Item[docAPIVersionAttribute] = &dynamodb.AttributeValue{S: aws.String(version)}
+	_, err = s.client.PutItemWithContext(ctx, input)
+	return docAPIError(err)
+}
+
+func (s *DocAPILeaseStore) itemKey(key string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		docAPIKeyAttribute: {S: aws.String(key)},
+	}
+}
+
+func versionValues(version string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		":version": {S: aws.String(version)},
+	}
+}
+
+func docAPIError(err error) error {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		return ErrLeaseConflict
+	}
+	return err
+}
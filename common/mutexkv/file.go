@@ -0,0 +1,135 @@
+package mutexkv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// errFileLocked is returned by tryLockFile when the file is locked by another open file.
+var errFileLocked = errors.New("file is locked")
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// FileBackend holds the locks as advisory locks of files in a directory, which serializes
+// the provider processes on the same host, or the hosts sharing the directory over a file
+// system with working locks. The locks are released by the OS if the process dies.
+//
+// The holder of a lock is written next to its file, so that the waiting processes can tell who it is.
+type FileBackend struct {
+	dir string
+
+	mu    sync.Mutex
+	files map[string]*lockFile
+}
+
+type lockFile struct {
+	file  *os.File
+	lease Lease
+}
+
+// NewFileBackend returns the backend keeping the lock files in the directory, creating it if needed.
+func NewFileBackend(dir string) (*FileBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating lock directory: %w", err)
+	}
+	return &FileBackend{
+		dir:   dir,
+		files: make(map[string]*lockFile),
+	}, nil
+}
+
+func (b *FileBackend) Acquire(_ context.Context, key string, lease Lease) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	name := b.path(key)
+	if held, ok := b.files[key]; ok && held.lease.ID != lease.ID {
+		// The file is locked by another MutexKV of this process sharing the backend.
+		current := held.lease
+		return &LockedError{Key: key, Lease: &current}
+	}
+	_, renew := b.files[key]
+	if !renew {
+		f, err := os.OpenFile(name+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+		if err != nil {
+			return err
+		}
+		if err := tryLockFile(f); err != nil {
+			f.Close()
+			if errors.Is(err, errFileLocked) {
+				return &LockedError{Key: key, Lease: readHolderFile(name + ".holder")}
+			}
+			return fmt.Errorf("error locking %s: %w", f.Name(), err)
+		}
+		b.files[key] = &lockFile{file: f}
+	}
+	b.files[key].lease = lease
+	if err := writeHolderFile(name+".holder", lease); err != nil {
+		if !renew {
+			// Nobody holds the lock if its holder can't be told.
+			held := b.files[key]
+			delete(b.files, key)
+			return errors.Join(err, unlockFile(held.file), held.file.Close())
+		}
+		return err
+	}
+	return nil
+}
+
+func (b *FileBackend) Release(_ context.Context, key string, lease Lease) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	held, ok := b.files[key]
+	if !ok || held.lease.ID != lease.ID {
+		return nil
+	}
+	delete(b.files, key)
+
+	err := os.Remove(b.path(key) + ".holder")
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+	}
+	return errors.Join(err, unlockFile(held.file), held.file.Close())
+}
+
+func (b *FileBackend) path(key string) string {
+	return filepath.Join(b.dir, unsafeFileNameChars.ReplaceAllString(key, "_"))
+}
+
+// readHolderFile returns the lease written by the holder of the lock, nil if it can't be read.
+func readHolderFile(name string) *Lease {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil
+	}
+	lease := &Lease{}
+	if err := json.Unmarshal(data, lease); err != nil {
+		return nil
+	}
+	return lease
+}
+
+// writeHolderFile replaces the holder file at once, so that it is never read half-written.
+func writeHolderFile(name string, lease Lease) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.%s", name, lease.ID)
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
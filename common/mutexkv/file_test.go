@@ -0,0 +1,80 @@
+package mutexkv
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileBackend(t *testing.T) {
+	dir := t.TempDir()
+	first, err := NewFileBackend(dir)
+	require.NoError(t, err)
+	second, err := NewFileBackend(dir)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	lease := Lease{ID: "first", Holder: "pipeline #1", AcquiredAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute)}
+	require.NoError(t, first.Acquire(ctx, "iam-folder-b1g", lease))
+	require.NoError(t, first.Acquire(ctx, "iam-folder-b1g", lease), "the lease must be extended by its holder")
+
+	err = second.Acquire(ctx, "iam-folder-b1g", Lease{ID: "second"})
+	var locked *LockedError
+	require.ErrorAs(t, err, &locked)
+	require.NotNil(t, locked.Lease)
+	assert.Equal(t, "pipeline #1", locked.Lease.Holder)
+
+	err = first.Acquire(ctx, "iam-folder-b1g", Lease{ID: "other"})
+	assert.ErrorAs(t, err, &locked, "another lease of the same backend must not take the lock")
+
+	require.NoError(t, second.Acquire(ctx, "iam-cloud-b1g", Lease{ID: "second"}), "different keys must not block")
+
+	require.NoError(t, first.Release(ctx, "iam-folder-b1g", lease))
+	assert.NoError(t, second.Acquire(ctx, "iam-folder-b1g", Lease{ID: "second"}))
+}
+
+func TestFileBackendHolderWriteFailure(t *testing.T) {
+	dir := t.TempDir()
+	first, err := NewFileBackend(dir)
+	require.NoError(t, err)
+	second, err := NewFileBackend(dir)
+	require.NoError(t, err)
+
+	// The holder file can't replace a directory.
+	holder := filepath.Join(dir, "iam-folder-b1g.holder")
+	require.NoError(t, os.MkdirAll(filepath.Join(holder, "dir"), 0o755))
+
+	ctx := context.Background()
+	require.Error(t, first.Acquire(ctx, "iam-folder-b1g", Lease{ID: "first"}))
+	assert.Empty(t, first.files, "the lock must not be held if its holder can't be written")
+	leftovers, err := filepath.Glob(holder + ".*")
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+
+	require.NoError(t, os.RemoveAll(holder))
+	assert.NoError(t, second.Acquire(ctx, "iam-folder-b1g", Lease{ID: "second"}), "the lock file must be unlocked")
+}
+
+func TestFileBackendMutexKV(t *testing.T) {
+	backend, err := NewFileBackend(t.TempDir())
+	require.NoError(t, err)
+
+	// Two MutexKVs of the process sharing the backend, like the ones of the SDK and the framework provider.
+	first := NewMutexKV()
+	first.SetBackend(backend, Options{Holder: "first"})
+	second := NewMutexKV()
+	second.SetBackend(backend, Options{Holder: "second", Timeout: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond})
+
+	require.NoError(t, first.LockContext(context.Background(), "foo"))
+	err = second.LockContext(context.Background(), "foo")
+	assert.ErrorContains(t, err, "first")
+
+	first.Unlock("foo")
+	require.NoError(t, second.LockContext(context.Background(), "foo"))
+	second.Unlock("foo")
+}
@@ -0,0 +1,79 @@
+package mutexkv
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// ErrLeaseConflict is returned by a LeaseStore when the lease object was changed concurrently.
+var ErrLeaseConflict = errors.New("lease was changed concurrently")
+
+// LeaseStore keeps a lease object per key with compare-and-swap semantics.
+// The versions are opaque to the callers, e.g. ETags of Object Storage objects.
+type LeaseStore interface {
+	// Get returns the lease of the key and its version, a nil lease if there is none.
+	Get(ctx context.Context, key string) (*Lease, string, error)
+	// Create stores the lease of the key, failing with ErrLeaseConflict if there is one.
+	Create(ctx context.Context, key string, lease Lease) error
+	// Replace stores the lease of the key, failing with ErrLeaseConflict if the stored
+	// lease is not of the version any more.
+	Replace(ctx context.Context, key string, lease Lease, version string) error
+	// Delete deletes the lease of the key, failing with ErrLeaseConflict if the stored
+	// lease is not of the version any more.
+	Delete(ctx context.Context, key string, version string) error
+}
+
+type leaseBackend struct {
+	store LeaseStore
+	now   func() time.Time
+}
+
+// NewLeaseBackend returns the backend holding the locks as lease objects of the store.
+// A lease not renewed before it expires, e.g. the one of a killed process, is taken over.
+func NewLeaseBackend(store LeaseStore) Backend {
+	return &leaseBackend{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+func (b *leaseBackend) Acquire(ctx context.Context, key string, lease Lease) error {
+	current, version, err := b.store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case current == nil:
+		err = b.store.Create(ctx, key, lease)
+	case current.ID == lease.ID:
+		lease.AcquiredAt = current.AcquiredAt
+		err = b.store.Replace(ctx, key, lease, version)
+	case current.ExpiresAt.Before(b.now()):
+		log.Printf("[WARN] Taking over the expired %s", (&LockedError{Key: key, Lease: current}).Error())
+		err = b.store.Replace(ctx, key, lease, version)
+	default:
+		return &LockedError{Key: key, Lease: current}
+	}
+
+	if errors.Is(err, ErrLeaseConflict) {
+		// Another process has just taken the lease, it will be seen by the next attempt.
+		return &LockedError{Key: key, Lease: current}
+	}
+	return err
+}
+
+func (b *leaseBackend) Release(ctx context.Context, key string, lease Lease) error {
+	current, version, err := b.store.Get(ctx, key)
+	if err != nil || current == nil || current.ID != lease.ID {
+		return err
+	}
+
+	err = b.store.Delete(ctx, key, version)
+	if errors.Is(err, ErrLeaseConflict) {
+		return nil
+	}
+	return err
+}
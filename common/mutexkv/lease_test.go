@@ -0,0 +1,187 @@
+package mutexkv
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryLeaseStore is a local stand-in for the Object Storage and YDB lease stores.
+type memoryLeaseStore struct {
+	mu      sync.Mutex
+	leases  map[string]Lease
+	version map[string]int
+}
+
+func newMemoryLeaseStore() *memoryLeaseStore {
+	return &memoryLeaseStore{
+		leases:  make(map[string]Lease),
+		version: make(map[string]int),
+	}
+}
+
+func (s *memoryLeaseStore) Get(_ context.Context, key string) (*Lease, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lease, ok := s.leases[key]
+	if !ok {
+		return nil, "", nil
+	}
+	return &lease, strconv.Itoa(s.version[key]), nil
+}
+
+func (s *memoryLeaseStore) Create(_ context.Context, key string, lease Lease) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.leases[key]; ok {
+		return ErrLeaseConflict
+	}
+	s.leases[key] = lease
+	s.version[key]++
+	return nil
+}
+
+func (s *memoryLeaseStore) Replace(_ context.Context, key string, lease Lease, version string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.leases[key]; !ok || strconv.Itoa(s.version[key]) != version {
+		return ErrLeaseConflict
+	}
+	s.leases[key] = lease
+	s.version[key]++
+	return nil
+}
+
+func (s *memoryLeaseStore) Delete(_ context.Context, key string, version string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.leases[key]; !ok || strconv.Itoa(s.version[key]) != version {
+		return ErrLeaseConflict
+	}
+	delete(s.leases, key)
+	return nil
+}
+
+func (s *memoryLeaseStore) lease(key string) (Lease, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lease, ok := s.leases[key]
+	return lease, ok
+}
+
+// newProcess returns a MutexKV standing for a provider process sharing the store with the other ones.
+func newProcess(store LeaseStore, holder string, options Options) *MutexKV {
+	mkv := NewMutexKV()
+	options.Holder = holder
+	if options.PollInterval == 0 {
+		options.PollInterval = 10 * time.Millisecond
+	}
+	mkv.SetBackend(NewLeaseBackend(store), options)
+	return mkv
+}
+
+func TestLeaseBackendExcludesProcesses(t *testing.T) {
+	store := newMemoryLeaseStore()
+	first := newProcess(store, "first", Options{})
+	second := newProcess(store, "second", Options{})
+
+	require.NoError(t, first.LockContext(context.Background(), "foo"))
+
+	doneCh := make(chan struct{})
+	go func() {
+		assert.NoError(t, second.LockContext(context.Background(), "foo"))
+		close(doneCh)
+	}()
+
+	select {
+	case <-doneCh:
+		t.Fatal("Second process was able to take the lock. This shouldn't happen.")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	first.Unlock("foo")
+	select {
+	case <-doneCh:
+	case <-time.After(time.Second):
+		t.Fatal("Second process blocked after unlock. This shouldn't happen.")
+	}
+
+	lease, ok := store.lease("foo")
+	require.True(t, ok)
+	assert.Equal(t, "second", lease.Holder)
+
+	second.Unlock("foo")
+	_, ok = store.lease("foo")
+	assert.False(t, ok, "lease must be deleted on unlock")
+}
+
+func TestLeaseBackendTimeoutNamesHolder(t *testing.T) {
+	store := newMemoryLeaseStore()
+	first := newProcess(store, "pipeline #1", Options{})
+	second := newProcess(store, "pipeline #2", Options{Timeout: 50 * time.Millisecond})
+
+	require.NoError(t, first.LockContext(context.Background(), "foo"))
+	defer first.Unlock("foo")
+
+	err := second.LockContext(context.Background(), "foo")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "pipeline #1")
+
+	var locked *LockedError
+	require.ErrorAs(t, err, &locked)
+	assert.Equal(t, "pipeline #1", locked.Lease.Holder)
+
+	// The in-process mutex must be released on a failure.
+	done := make(chan struct{})
+	go func() {
+		second.Lock("foo")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Failed LockContext kept the key locked. This shouldn't happen.")
+	}
+}
+
+func TestLeaseBackendTakesOverExpiredLease(t *testing.T) {
+	store := newMemoryLeaseStore()
+	require.NoError(t, store.Create(context.Background(), "foo", Lease{
+		ID:         "dead",
+		Holder:     "killed process",
+		AcquiredAt: time.Now().Add(-time.Hour),
+		ExpiresAt:  time.Now().Add(-time.Minute),
+	}))
+
+	mkv := newProcess(store, "alive", Options{Timeout: time.Second})
+	require.NoError(t, mkv.LockContext(context.Background(), "foo"))
+	defer mkv.Unlock("foo")
+
+	lease, _ := store.lease("foo")
+	assert.Equal(t, "alive", lease.Holder)
+}
+
+func TestLeaseBackendRenewsLease(t *testing.T) {
+	store := newMemoryLeaseStore()
+	mkv := newProcess(store, "first", Options{LeaseDuration: 60 * time.Millisecond})
+
+	require.NoError(t, mkv.LockContext(context.Background(), "foo"))
+	defer mkv.Unlock("foo")
+	acquired, _ := store.lease("foo")
+
+	time.Sleep(150 * time.Millisecond)
+	renewed, ok := store.lease("foo")
+	require.True(t, ok)
+	assert.Equal(t, acquired.ID, renewed.ID)
+	assert.Equal(t, acquired.AcquiredAt, renewed.AcquiredAt)
+	assert.True(t, renewed.ExpiresAt.After(time.Now()), "lease must be renewed while the key is locked")
+
+	second := newProcess(store, "second", Options{Timeout: 50 * time.Millisecond})
+	assert.Error(t, second.LockContext(context.Background(), "foo"))
+}
@@ -1,40 +1,150 @@
 package mutexkv
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"sync"
+	"time"
+)
+
+const (
+	// DefaultLeaseDuration is how long a lease of a lock backend is valid unless renewed.
+	DefaultLeaseDuration = time.Minute
+
+	// defaultPollInterval is how often a lock held by another process is tried again.
+	defaultPollInterval = time.Second
 )
 
 // MutexKV is a simple key/value store for arbitrary mutexes. It can be used to
 // serialize changes across arbitrary collaborators that share knowledge of the
 // keys they must serialize on.
+//
+// Lock and Unlock serialize the collaborators within the provider process. LockContext
+// also holds the key in the backend set by SetBackend, if any, which serializes them
+// across provider processes, e.g. ones run by different pipelines or workspaces.
 type MutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]chan struct{}
+
+	backend Backend
+	options Options
+	leases  map[string]*heldLease
+}
+
+// Options tune the locks held in a backend.
+type Options struct {
+	// Timeout limits how long LockContext waits for a key, no limit if zero.
+	Timeout time.Duration
+	// LeaseDuration is how long a lease is valid unless renewed, DefaultLeaseDuration if zero.
+	// The leases are renewed every third of it while the key is locked.
+	LeaseDuration time.Duration
+	// Holder describes this process in the leases, so that the processes waiting for
+	// a lock can tell who holds it. The host name and the process id if empty.
+	Holder string
+	// PollInterval is how often a key held by another process is tried again.
+	PollInterval time.Duration
+}
+
+type heldLease struct {
+	backend Backend
+	lease   Lease
+	stop    chan struct{}
+	done    chan struct{}
 }
 
 // Locks the mutex for the given key. Caller is responsible for calling Unlock
 // for the same key
 func (m *MutexKV) Lock(key string) {
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
+	m.get(key) <- struct{}{}
 	log.Printf("[DEBUG] Locked %q", key)
 }
 
+// LockContext locks the mutex for the given key and holds the key in the backend.
+// It gives up when the context is done or the timeout of the options expires, the
+// returned error then names the holder of the lock if it is known. Caller is
+// responsible for calling Unlock for the same key if no error is returned.
+func (m *MutexKV) LockContext(ctx context.Context, key string) error {
+	backend, options := m.getBackend()
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	log.Printf("[DEBUG] Locking %q", key)
+	select {
+	case m.get(key) <- struct{}{}:
+	case <-ctx.Done():
+		return fmt.Errorf("error locking %q: %w, it is held by this provider process", key, ctx.Err())
+	}
+
+	if backend != nil {
+		held, err := acquire(ctx, backend, options, key)
+		if err != nil {
+			<-m.get(key)
+			return err
+		}
+		m.lock.Lock()
+		m.leases[key] = held
+		m.lock.Unlock()
+	}
+	log.Printf("[DEBUG] Locked %q", key)
+	return nil
+}
+
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
 func (m *MutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
+	m.lock.Lock()
+	held, ok := m.leases[key]
+	delete(m.leases, key)
+	m.lock.Unlock()
+
+	if ok {
+		held.release(key)
+	}
+	select {
+	case <-m.get(key):
+	default:
+		panic(fmt.Sprintf("mutexkv: unlock of unlocked key %q", key))
+	}
 	log.Printf("[DEBUG] Unlocked %q", key)
 }
 
+// SetBackend makes LockContext hold the keys in the backend with the options.
+// A nil backend keeps the locks within the provider process.
+func (m *MutexKV) SetBackend(backend Backend, options Options) {
+	if options.LeaseDuration == 0 {
+		options.LeaseDuration = DefaultLeaseDuration
+	}
+	if options.PollInterval == 0 {
+		options.PollInterval = defaultPollInterval
+	}
+	if options.Holder == "" {
+		options.Holder = defaultHolder()
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.backend = backend
+	m.options = options
+}
+
+func (m *MutexKV) getBackend() (Backend, Options) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.backend, m.options
+}
+
 // Returns a mutex for the given key, no guarantee of its lock status
-func (m *MutexKV) get(key string) *sync.Mutex {
+func (m *MutexKV) get(key string) chan struct{} {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	mutex, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
+		mutex = make(chan struct{}, 1)
 		m.store[key] = mutex
 	}
 	return mutex
@@ -43,6 +153,94 @@ func (m *MutexKV) get(key string) *sync.Mutex {
 // Returns a properly initalized MutexKV
 func NewMutexKV() *MutexKV {
 	return &MutexKV{
-		store: make(map[string]*sync.Mutex),
+		store:  make(map[string]chan struct{}),
+		leases: make(map[string]*heldLease),
+	}
+}
+
+// acquire tries to take the lease of the key until it succeeds or the context is done,
+// and starts renewing the lease.
+func acquire(ctx context.Context, backend Backend, options Options, key string) (*heldLease, error) {
+	id, err := newLeaseID()
+	if err != nil {
+		return nil, err
+	}
+
+	var holder *Lease
+	for {
+		now := time.Now()
+		lease := Lease{
+			ID:         id,
+			Holder:     options.Holder,
+			AcquiredAt: now,
+			ExpiresAt:  now.Add(options.LeaseDuration),
+		}
+		err := backend.Acquire(ctx, key, lease)
+		if err == nil {
+			held := &heldLease{
+				backend: backend,
+				lease:   lease,
+				stop:    make(chan struct{}),
+				done:    make(chan struct{}),
+			}
+			go held.renew(key, options.LeaseDuration)
+			return held, nil
+		}
+
+		locked, ok := err.(*LockedError)
+		if !ok {
+			return nil, fmt.Errorf("error locking %q: %w", key, err)
+		}
+		if holder == nil || locked.Lease == nil || holder.ID != locked.Lease.ID {
+			log.Printf("[INFO] Waiting for %s", locked.Error())
+		}
+		holder = locked.Lease
+
+		timer := time.NewTimer(options.PollInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("error locking %q: %w: %w", key, ctx.Err(), locked)
+		}
+	}
+}
+
+// renew extends the lease every third of its duration until it is released.
+func (h *heldLease) renew(key string, duration time.Duration) {
+	defer close(h.done)
+
+	ticker := time.NewTicker(duration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-ticker.C:
+		}
+
+		lease := h.lease
+		lease.ExpiresAt = time.Now().Add(duration)
+		ctx, cancel := context.WithTimeout(context.Background(), duration/3)
+		err := h.backend.Acquire(ctx, key, lease)
+		cancel()
+		if err != nil {
+			log.Printf("[WARN] Error renewing the lease of %q, it expires at %s: %s", key, h.lease.ExpiresAt.Format(time.RFC3339), err)
+			continue
+		}
+		h.lease = lease
+	}
+}
+
+// release stops renewing the lease and releases it. An error is only logged,
+// as the lease expires anyway.
+func (h *heldLease) release(key string) {
+	close(h.stop)
+	<-h.done
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := h.backend.Release(ctx, key, h.lease); err != nil {
+		log.Printf("[WARN] Error releasing the lease of %q, it expires at %s: %s", key, h.lease.ExpiresAt.Format(time.RFC3339), err)
 	}
 }
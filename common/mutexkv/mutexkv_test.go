@@ -1,6 +1,7 @@
 package mutexkv
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -65,3 +66,19 @@ func TestMutexKVDifferentKeys(t *testing.T) {
 		t.Fatal("Second lock on a different key blocked. This shouldn't happen.")
 	}
 }
+
+func TestMutexKVLockContextTimeout(t *testing.T) {
+	mkv := NewMutexKV()
+	mkv.SetBackend(nil, Options{Timeout: 50 * time.Millisecond})
+
+	mkv.Lock("foo")
+
+	if err := mkv.LockContext(context.Background(), "foo"); err == nil {
+		t.Fatal("Second lock was able to be taken. This shouldn't happen.")
+	}
+
+	mkv.Unlock("foo")
+	if err := mkv.LockContext(context.Background(), "foo"); err != nil {
+		t.Fatalf("Lock blocked after unlock: %s", err)
+	}
+}
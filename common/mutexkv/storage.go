@@ -0,0 +1,102 @@
+package mutexkv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// StorageLeaseStore keeps the leases as JSON objects of an Object Storage bucket,
+// swapping them with the conditional writes of the objects.
+type StorageLeaseStore struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// NewStorageLeaseStore returns the store keeping the lease objects in the bucket under the prefix.
+func NewStorageLeaseStore(client *s3.S3, bucket, prefix string) *StorageLeaseStore {
+	return &StorageLeaseStore{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+	}
+}
+
+func (s *StorageLeaseStore) Get(ctx context.Context, key string) (*Lease, string, error) {
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if isStorageStatus(err, http.StatusNotFound) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	defer out.Body.Close()
+
+	lease := &Lease{}
+	if err := json.NewDecoder(out.Body).Decode(lease); err != nil {
+		return nil, "", err
+	}
+	return lease, aws.StringValue(out.ETag), nil
+}
+
+func (s *StorageLeaseStore) Create(ctx context.Context, key string, lease Lease) error {
+	return s.put(ctx, key, lease, "If-None-Match", "*")
+}
+
+func (s *StorageLeaseStore) Replace(ctx context.Context, key string, lease Lease, version string) error {
+	return s.put(ctx, key, lease, "If-Match", version)
+}
+
+func (s *StorageLeaseStore) Delete(ctx context.Context, key string, version string) error {
+	req, _ := s.client.DeleteObjectRequest(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	return s.send(ctx, req, "If-Match", version)
+}
+
+func (s *StorageLeaseStore) put(ctx context.Context, key string, lease Lease, header, value string) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+	req, _ := s.client.PutObjectRequest(&s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s.objectKey(key)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	return s.send(ctx, req, header, value)
+}
+
+// send sends the request with the condition header, which the S3 API of the SDK has no fields for.
+func (s *StorageLeaseStore) send(ctx context.Context, req *request.Request, header, value string) error {
+	req.SetContext(ctx)
+	req.HTTPRequest.Header.Set(header, value)
+	err := req.Send()
+	if isStorageStatus(err, http.StatusPreconditionFailed) || isStorageStatus(err, http.StatusConflict) {
+		return ErrLeaseConflict
+	}
+	return err
+}
+
+func (s *StorageLeaseStore) objectKey(key string) string {
+	return path.Join(s.prefix, key+".json")
+}
+
+func isStorageStatus(err error, statusCode int) bool {
+	var reqErr awserr.RequestFailure
+	return errors.As(err, &reqErr) && reqErr.StatusCode() == statusCode
+}
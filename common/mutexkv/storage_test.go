@@ -0,0 +1,101 @@
+package mutexkv
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStorage is a local stand-in for Object Storage honoring the conditional headers of the requests.
+type fakeStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	etags   map[string]string
+	writes  int
+}
+
+func (s *fakeStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	etag, exists := s.etags[r.URL.Path]
+	if match := r.Header.Get("If-Match"); match != "" && match != etag {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	if r.Header.Get("If-None-Match") == "*" && exists {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>NoSuchKey</Code></Error>")
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write(s.objects[r.URL.Path])
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		s.writes++
+		s.objects[r.URL.Path] = data
+		s.etags[r.URL.Path] = fmt.Sprintf(`"%d"`, s.writes)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		delete(s.etags, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestStorageLeaseStore(t *testing.T) {
+	server := httptest.NewServer(&fakeStorage{objects: make(map[string][]byte), etags: make(map[string]string)})
+	defer server.Close()
+
+	sess, err := session.NewSession(&aws.Config{
+		Credentials:      credentials.NewStaticCredentials("key", "secret", ""),
+		Endpoint:         aws.String(server.URL),
+		Region:           aws.String("ru-central1"),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	require.NoError(t, err)
+	store := NewStorageLeaseStore(s3.New(sess), "locks", DefaultStoragePrefix)
+	ctx := context.Background()
+
+	lease, _, err := store.Get(ctx, "foo")
+	require.NoError(t, err)
+	assert.Nil(t, lease)
+
+	first := Lease{ID: "first", Holder: "pipeline #1", ExpiresAt: time.Now().Add(time.Minute).UTC().Truncate(time.Second)}
+	require.NoError(t, store.Create(ctx, "foo", first))
+	assert.ErrorIs(t, store.Create(ctx, "foo", Lease{ID: "second"}), ErrLeaseConflict)
+
+	lease, version, err := store.Get(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, first, *lease)
+
+	require.NoError(t, store.Replace(ctx, "foo", Lease{ID: "second"}, version))
+	assert.ErrorIs(t, store.Replace(ctx, "foo", first, version), ErrLeaseConflict)
+	assert.ErrorIs(t, store.Delete(ctx, "foo", version), ErrLeaseConflict)
+
+	_, version, err = store.Get(ctx, "foo")
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(ctx, "foo", version))
+
+	lease, _, err = store.Get(ctx, "foo")
+	require.NoError(t, err)
+	assert.Nil(t, lease)
+}
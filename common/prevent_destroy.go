@@ -0,0 +1,73 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PreventDestroyRules are the parsed entries of the provider prevent_destroy_types setting.
+// A resource matches the rules if its type is listed or if it has one of the listed labels.
+type PreventDestroyRules struct {
+	types  map[string]bool
+	labels map[string]map[string]bool
+}
+
+// ParsePreventDestroyRules parses the entries of prevent_destroy_types. An entry is either
+// a resource type, e.g. "yandex_vpc_network", or a label in the "key=value" form. A label key
+// may be listed with several values, a resource having any of them matches.
+func ParsePreventDestroyRules(entries []string) (PreventDestroyRules, error) {
+	rules := PreventDestroyRules{
+		types:  make(map[string]bool),
+		labels: make(map[string]map[string]bool),
+	}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			return PreventDestroyRules{}, fmt.Errorf("empty entry")
+		}
+
+		key, value, isLabel := strings.Cut(entry, "=")
+		if !isLabel {
+			rules.types[entry] = true
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return PreventDestroyRules{}, fmt.Errorf("label %q has no key", entry)
+		}
+		if rules.labels[key] == nil {
+			rules.labels[key] = make(map[string]bool)
+		}
+		rules.labels[key][strings.TrimSpace(value)] = true
+	}
+	return rules, nil
+}
+
+// IsEmpty reports whether the rules protect nothing.
+func (r PreventDestroyRules) IsEmpty() bool {
+	return len(r.types) == 0 && len(r.labels) == 0
+}
+
+// Match returns the entry of prevent_destroy_types protecting a resource of the given type with
+// the given labels, and false if its deletion is allowed.
+func (r PreventDestroyRules) Match(resourceType string, labels map[string]string) (string, bool) {
+	if r.types[resourceType] {
+		return resourceType, true
+	}
+	for k, v := range labels {
+		if r.labels[k][v] {
+			return k + "=" + v, true
+		}
+	}
+	return "", false
+}
+
+// PreventDestroyError returns the error of a Delete rejected because of the prevent_destroy_types entry.
+func PreventDestroyError(resourceType, id, entry string) error {
+	return fmt.Errorf(
+		"%s %q is protected from deletion by the provider prevent_destroy_types entry %q. "+
+			"Remove the entry from the provider configuration, or the matching label from the resource, to delete it",
+		resourceType, id, entry,
+	)
+}
@@ -0,0 +1,130 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePreventDestroyRules(t *testing.T) {
+	cases := []struct {
+		name    string
+		entries []string
+		wantErr bool
+	}{
+		{
+			name: "no entries",
+		},
+		{
+			name:    "types and labels",
+			entries: []string{"yandex_vpc_network", "protected=true", " env = prod "},
+		},
+		{
+			name:    "label with empty value",
+			entries: []string{"protected="},
+		},
+		{
+			name:    "empty entry",
+			entries: []string{" "},
+			wantErr: true,
+		},
+		{
+			name:    "label without key",
+			entries: []string{"=true"},
+			wantErr: true,
+		},
+		{
+			name:    "label with several values",
+			entries: []string{"env=prod", "env=stage", "env=prod"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParsePreventDestroyRules(tc.entries)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPreventDestroyRulesMatch(t *testing.T) {
+	rules, err := ParsePreventDestroyRules([]string{"yandex_vpc_network", "protected=true", " env = prod ", "env=stage"})
+	require.NoError(t, err)
+	assert.False(t, rules.IsEmpty())
+
+	cases := []struct {
+		name         string
+		resourceType string
+		labels       map[string]string
+		wantEntry    string
+		wantMatch    bool
+	}{
+		{
+			name:         "listed type",
+			resourceType: "yandex_vpc_network",
+			wantEntry:    "yandex_vpc_network",
+			wantMatch:    true,
+		},
+		{
+			name:         "listed label",
+			resourceType: "yandex_compute_disk",
+			labels:       map[string]string{"protected": "true", "app": "web"},
+			wantEntry:    "protected=true",
+			wantMatch:    true,
+		},
+		{
+			name:         "trimmed label",
+			resourceType: "yandex_compute_disk",
+			labels:       map[string]string{"env": "prod"},
+			wantEntry:    "env=prod",
+			wantMatch:    true,
+		},
+		{
+			name:         "label with another listed value",
+			resourceType: "yandex_compute_disk",
+			labels:       map[string]string{"env": "stage"},
+			wantEntry:    "env=stage",
+			wantMatch:    true,
+		},
+		{
+			name:         "label with unlisted value",
+			resourceType: "yandex_compute_disk",
+			labels:       map[string]string{"env": "testing"},
+		},
+		{
+			name:         "label with other value",
+			resourceType: "yandex_compute_disk",
+			labels:       map[string]string{"protected": "false"},
+		},
+		{
+			name:         "other type without labels",
+			resourceType: "yandex_vpc_subnet",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry, ok := rules.Match(tc.resourceType, tc.labels)
+			assert.Equal(t, tc.wantMatch, ok)
+			assert.Equal(t, tc.wantEntry, entry)
+		})
+	}
+}
+
+func TestPreventDestroyRulesEmpty(t *testing.T) {
+	rules, err := ParsePreventDestroyRules(nil)
+	require.NoError(t, err)
+	assert.True(t, rules.IsEmpty())
+
+	_, ok := rules.Match("yandex_vpc_network", map[string]string{"protected": "true"})
+	assert.False(t, ok)
+
+	var zero PreventDestroyRules
+	_, ok = zero.Match("yandex_vpc_network", nil)
+	assert.False(t, ok)
+}
@@ -49,7 +49,102 @@ var Descriptions = map[string]string{
 	"ymq_secret_key": "Yandex.Cloud Message Queue service secret key. \n" +
 		"Used when a message queue resource doesn't have a secret key explicitly specified.",
 
-	"shared_credentials_file": "Path to shared credentials file.",
+	"shared_credentials_file": "Path to shared credentials file. The YAML config of the yc CLI is supported as well.",
 
-	"profile": "Profile to use in the shared credentials file. Default value is `default`.",
+	"profile": "Profile to use in the shared credentials file. Default value is `default` \n" +
+		"(the current profile for the yc CLI config). If the file is not set, the profile is read from the yc CLI config, \n" +
+		"whose current profile is used as well when no credentials are configured.",
+
+	"default_labels": "Labels that will be applied to all resources with a `labels` field. \n" +
+		"Labels set on a resource take precedence over the default ones.",
+
+	"prevent_destroy_types": "Resources that the provider refuses to delete. An entry is either a resource type, \n" +
+		"e.g. `yandex_vpc_network`, or a label in the `key=value` form, e.g. `protected=true`, \n" +
+		"matched against the `labels` of a resource (the `tags` of a storage bucket).",
+
+	"retry_policy": "Retry policy for API calls. If set, it replaces the default retries of the calls \n" +
+		"failed with `UNAVAILABLE` status code.",
+
+	"retry_policy.codes": "gRPC status codes to retry on, e.g. `UNAVAILABLE` or `RESOURCE_EXHAUSTED`. \n" +
+		"Default is `[\"UNAVAILABLE\"]`, method overrides inherit the codes of the policy.",
+
+	"retry_policy.base_backoff": "Base of the exponential backoff between retries, e.g. `100ms`. Default is `50ms`.",
+
+	"retry_policy.max_backoff": "Maximum backoff between retries, e.g. `30s`. Default is `1m`.",
+
+	"retry_policy.max_elapsed_time": "Maximum time spent on an API call including all the retries, e.g. `10m`. \n" +
+		"Not limited by default.",
+
+	"retry_policy.retry_conflicting_operations": "Retry API calls rejected because of a conflicting operation \n" +
+		"running on the same resource.",
+
+	"retry_policy.method_override": "Retry settings for particular API methods. Unset settings are taken from the policy.",
+
+	"retry_policy.method_override.method": "Full gRPC method name, e.g. `/yandex.cloud.compute.v1.InstanceService/Create`, \n" +
+		"or its prefix followed by `*`, e.g. `/yandex.cloud.compute.*`. The longest matching override is used.",
+
+	"retry_policy.method_override.max_retries": "The maximum number of retries of the method. \n" +
+		"Default is the provider `max_retries`, `0` disables the retries of the method.",
+
+	"rate_limit": "Client-side limits of the rate and concurrency of API requests to a service. \n" +
+		"A request is limited by the rule with the longest matching `service` only.",
+
+	"rate_limit.service": "Full gRPC method name or its prefix followed by `*`, e.g. `yandex.cloud.compute.*`. \n" +
+		"`*` matches all the API requests.",
+
+	"rate_limit.requests_per_second": "Maximum average rate of requests per second. Not limited by default.",
+
+	"rate_limit.burst": "Maximum number of requests sent at once above the average rate. \n" +
+		"Default is `requests_per_second` rounded up.",
+
+	"rate_limit.max_in_flight": "Maximum number of concurrent requests. Not limited by default.",
+
+	"workload_identity": "Authenticate with workload identity federation: an external OIDC token, \n" +
+		"e.g. the one of a CI job, is exchanged for an IAM token of a service account and refreshed automatically.",
+
+	"workload_identity.service_account_id": "ID of the service account the federated credentials are bound to.",
+
+	"workload_identity.audience": "Audience of the OIDC token requested from GitHub Actions \n" +
+		"when neither `token_file` nor `token_env` is set.",
+
+	"workload_identity.token_file": "Path to a file with the external OIDC token. The file is read on every token refresh.",
+
+	"workload_identity.token_env": "Environment variable with the external OIDC token.",
+
+	"workload_identity.endpoint": "Token exchange endpoint. Default is `https://auth.yandex.cloud/oauth/token`.",
+
+	"iam_compare_before_set": "Compare the access bindings replaced by the `_iam_binding` and `_iam_policy` resources \n" +
+		"with the ones read to plan the change right before replacing them, and fail with their difference \n" +
+		"if they changed. Can be overridden by `compare_before_set` of a resource. Default is `false`.",
+
+	"lock_backend": "Where the locks serializing changes of IAM access bindings and other cloud-wide changes are held. \n" +
+		"By default they only serialize the resources of one provider process, a shared backend also serializes \n" +
+		"the ones of concurrent runs, e.g. of different pipelines or workspaces.",
+
+	"lock_backend.type": "Type of the backend: `memory`, `file`, `storage` or `ydb`.",
+
+	"lock_backend.path": "Directory of the lock files of the `file` backend.",
+
+	"lock_backend.bucket": "Object Storage bucket of the lease objects of the `storage` backend. \n" +
+		"The provider `storage_access_key` and `storage_secret_key` are used to access it.",
+
+	"lock_backend.prefix": "Prefix of the lease objects of the `storage` backend. \n" +
+		"Default is `terraform-provider-yandex/locks`.",
+
+	"lock_backend.endpoint": "Document API endpoint of the YDB database of the `ydb` backend. \n" +
+		"The provider `storage_access_key` and `storage_secret_key` are used to access it.",
+
+	"lock_backend.table": "Document table of the lease items of the `ydb` backend, with the `lock_key` string partition key.",
+
+	"lock_backend.timeout": "How long to wait for a lock before failing, e.g. `10m`. Not limited by default.",
+
+	"lock_backend.lease_duration": "How long a lease of the `storage` and `ydb` backends is valid unless renewed, \n" +
+		"e.g. `1m`, the default. Leases of killed processes are taken over once expired.",
+
+	"lock_backend.holder": "Description of this run stored with the held locks, e.g. a CI job URL, \n" +
+		"which is reported by the runs waiting for them. Default is the user, host and process ID.",
+
+	"impersonate_service_account_id": "ID of the service account to impersonate. The configured credentials \n" +
+		"are used to mint short-lived IAM tokens of this service account, which authenticate all API calls. \n" +
+		"It can also be sourced from the `YC_IMPERSONATE_SERVICE_ACCOUNT_ID` environment variable.",
 }
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex"
+)
+
+const generateUsage = `Usage: terraform-provider-yandex generate [options]
+
+Lists the resources of a folder and writes an import block and a resource block for each of them.
+The provider is configured from the environment variables and the shared credentials file.
+
+Options:
+`
+
+// runGenerate runs the generate subcommand with the arguments that follow it.
+func runGenerate(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), generateUsage)
+		flags.PrintDefaults()
+	}
+	folderID := flags.String("folder-id", "", "ID of the folder to list the resources of, YC_FOLDER_ID is used if it is empty")
+	types := flags.String("types", "", "comma separated resource types to list, e.g. compute_instance,vpc_subnet; all the supported types are listed if it is empty")
+	out := flags.String("out", "", "file to write the configuration to, the standard output is used if it is empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts := yandex.GenerateOptions{FolderID: *folderID}
+	if *types != "" {
+		opts.ResourceTypes = strings.Split(*types, ",")
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return yandex.Generate(ctx, w, opts)
+}
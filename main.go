@@ -3,12 +3,18 @@ package main
 import (
 	"context"
 	"flag"
+	"log"
+	"os"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tracing"
+	"github.com/yandex-cloud/terraform-provider-yandex/version"
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex"
 	yandex_framework "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework"
 )
@@ -37,10 +43,33 @@ func NewMuxProviderServer(ctx context.Context) (func() tfprotov6.ProviderServer,
 
 func main() {
 	ctx := context.Background()
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := runGenerate(ctx, os.Args[2:]); err != nil {
+			if err != flag.ErrHelp {
+				log.Fatalf("Error: %s", err)
+			}
+			os.Exit(2)
+		}
+		return
+	}
+
 	var debug bool
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	shutdownTracing, err := tracing.Init(ctx, version.ProviderVersion)
+	if err != nil {
+		log.Printf("[WARN] Failed to set up tracing: %s", err)
+	} else {
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := shutdownTracing(shutdownCtx); err != nil {
+				log.Printf("[WARN] Failed to flush traces: %s", err)
+			}
+		}()
+	}
+
 	muxServerFactory, err := NewMuxProviderServer(ctx)
 
 	if err != nil {
@@ -0,0 +1,162 @@
+// Package apitrace reads the API trace written by the provider when TF_YC_API_TRACE_FILE is set
+// and summarises it, e.g. to find the slowest and the most retried calls of an apply.
+//
+// The trace is a file with one JSON encoded Record per line.
+package apitrace
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// EnvTraceFile is the environment variable with the path of the trace file to write.
+const EnvTraceFile = "TF_YC_API_TRACE_FILE"
+
+// Record is a single API call attempt.
+type Record struct {
+	Time          time.Time `json:"time"`
+	Method        string    `json:"method"`
+	RequestID     string    `json:"request_id,omitempty"`
+	ClientTraceID string    `json:"client_trace_id,omitempty"`
+	// Attempt is zero for the first attempt of a call and the retry number for the retries.
+	Attempt    int     `json:"attempt"`
+	DurationMs float64 `json:"duration_ms"`
+	StatusCode string  `json:"status_code"`
+
+	// Request, Response and Error are JSON encoded messages with the sensitive values hidden.
+	Request  json.RawMessage `json:"request,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    json.RawMessage `json:"error,omitempty"`
+}
+
+// Duration returns the duration of the call attempt.
+func (r *Record) Duration() time.Duration {
+	return time.Duration(r.DurationMs * float64(time.Millisecond))
+}
+
+// Read reads all the records of a trace.
+func Read(r io.Reader) ([]Record, error) {
+	var records []Record
+
+	scanner := bufio.NewScanner(r)
+	// Payloads of some calls, e.g. cloud-init metadata, are large.
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("failed to parse trace record at line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ReadFile reads all the records of a trace file.
+func ReadFile(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Slowest returns up to n slowest call attempts, the slowest first.
+func Slowest(records []Record, n int) []Record {
+	sorted := make([]Record, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].DurationMs > sorted[j].DurationMs
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// MethodStats are aggregated statistics of the calls of a method.
+type MethodStats struct {
+	Method string
+	// Calls is the number of the calls, not counting retries.
+	Calls int
+	// Retries is the number of retry attempts of all the calls.
+	Retries int
+	// MaxAttempt is the greatest retry number seen.
+	MaxAttempt int
+	// Errors is the number of attempts failed with a status code other than OK.
+	Errors        int
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+// Summarize aggregates the records per method, sorted by the total duration.
+func Summarize(records []Record) []MethodStats {
+	byMethod := make(map[string]*MethodStats)
+	for i := range records {
+		r := &records[i]
+		stats, ok := byMethod[r.Method]
+		if !ok {
+			stats = &MethodStats{Method: r.Method}
+			byMethod[r.Method] = stats
+		}
+
+		if r.Attempt == 0 {
+			stats.Calls++
+		} else {
+			stats.Retries++
+		}
+		if r.Attempt > stats.MaxAttempt {
+			stats.MaxAttempt = r.Attempt
+		}
+		if r.StatusCode != "" && r.StatusCode != "OK" {
+			stats.Errors++
+		}
+		stats.TotalDuration += r.Duration()
+		if r.Duration() > stats.MaxDuration {
+			stats.MaxDuration = r.Duration()
+		}
+	}
+
+	result := make([]MethodStats, 0, len(byMethod))
+	for _, stats := range byMethod {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalDuration != result[j].TotalDuration {
+			return result[i].TotalDuration > result[j].TotalDuration
+		}
+		return result[i].Method < result[j].Method
+	})
+	return result
+}
+
+// MostRetried returns up to n methods with the greatest number of retries, methods without retries are omitted.
+func MostRetried(records []Record, n int) []MethodStats {
+	var result []MethodStats
+	for _, stats := range Summarize(records) {
+		if stats.Retries > 0 {
+			result = append(result, stats)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Retries > result[j].Retries
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+package apitrace_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/apitrace"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/logging"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+)
+
+func TestTraceRoundTrip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	interceptor := logging.NewAPITraceUnaryInterceptor(buf)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-client-trace-id", "trace-1", "x-client-request-id", "request-1")
+	ok := func(_ context.Context, _ string, _, resp interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		resp.(*wrapperspb.StringValue).Value = "pong"
+		return nil
+	}
+	err := interceptor(ctx, "/yandex.cloud.vpc.v1.NetworkService/Get", wrapperspb.String("ping"), &wrapperspb.StringValue{}, nil, ok)
+	require.NoError(t, err)
+
+	ctx = metadata.AppendToOutgoingContext(ctx, retrypolicy.AttemptHeader, "2")
+	failed := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "try again")
+	}
+	err = interceptor(ctx, "/yandex.cloud.vpc.v1.NetworkService/Get", wrapperspb.String("ping"), &wrapperspb.StringValue{}, nil, failed)
+	require.Error(t, err)
+
+	records, err := apitrace.Read(buf)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "/yandex.cloud.vpc.v1.NetworkService/Get", records[0].Method)
+	assert.Equal(t, "request-1", records[0].RequestID)
+	assert.Equal(t, "trace-1", records[0].ClientTraceID)
+	assert.Equal(t, 0, records[0].Attempt)
+	assert.Equal(t, "OK", records[0].StatusCode)
+	assert.JSONEq(t, `"ping"`, string(records[0].Request))
+	assert.JSONEq(t, `"pong"`, string(records[0].Response))
+	assert.Empty(t, records[0].Error)
+
+	assert.Equal(t, 2, records[1].Attempt)
+	assert.Equal(t, "UNAVAILABLE", records[1].StatusCode)
+	assert.Empty(t, records[1].Response)
+	assert.Contains(t, string(records[1].Error), "try again")
+}
+
+func TestSummaries(t *testing.T) {
+	trace := `
+{"method":"/yandex.cloud.compute.v1.InstanceService/Create","attempt":0,"duration_ms":100,"status_code":"UNAVAILABLE"}
+{"method":"/yandex.cloud.compute.v1.InstanceService/Create","attempt":1,"duration_ms":200,"status_code":"UNAVAILABLE"}
+{"method":"/yandex.cloud.compute.v1.InstanceService/Create","attempt":2,"duration_ms":300,"status_code":"OK"}
+{"method":"/yandex.cloud.vpc.v1.NetworkService/Get","attempt":0,"duration_ms":10,"status_code":"OK"}
+{"method":"/yandex.cloud.vpc.v1.NetworkService/Get","attempt":0,"duration_ms":5000,"status_code":"OK"}
+{"method":"/yandex.cloud.vpc.v1.SubnetService/Get","attempt":0,"duration_ms":20,"status_code":"UNAVAILABLE"}
+{"method":"/yandex.cloud.vpc.v1.SubnetService/Get","attempt":1,"duration_ms":20,"status_code":"OK"}
+`
+	records, err := apitrace.Read(strings.NewReader(trace))
+	require.NoError(t, err)
+	require.Len(t, records, 7)
+
+	slowest := apitrace.Slowest(records, 2)
+	require.Len(t, slowest, 2)
+	assert.Equal(t, 5*time.Second, slowest[0].Duration())
+	assert.Equal(t, "/yandex.cloud.compute.v1.InstanceService/Create", slowest[1].Method)
+
+	summary := apitrace.Summarize(records)
+	require.Len(t, summary, 3)
+	assert.Equal(t, apitrace.MethodStats{
+		Method:        "/yandex.cloud.vpc.v1.NetworkService/Get",
+		Calls:         2,
+		TotalDuration: 5010 * time.Millisecond,
+		MaxDuration:   5 * time.Second,
+	}, summary[0])
+
+	mostRetried := apitrace.MostRetried(records, 10)
+	require.Len(t, mostRetried, 2)
+	assert.Equal(t, apitrace.MethodStats{
+		Method:        "/yandex.cloud.compute.v1.InstanceService/Create",
+		Calls:         1,
+		Retries:       2,
+		MaxAttempt:    2,
+		Errors:        2,
+		TotalDuration: 600 * time.Millisecond,
+		MaxDuration:   300 * time.Millisecond,
+	}, mostRetried[0])
+	assert.Equal(t, "/yandex.cloud.vpc.v1.SubnetService/Get", mostRetried[1].Method)
+
+	_, err = apitrace.Read(strings.NewReader("not json\n"))
+	assert.Error(t, err)
+}
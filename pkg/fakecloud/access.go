@@ -0,0 +1,91 @@
+package fakecloud
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+)
+
+func bindingKey(b *access.AccessBinding) string {
+	return b.GetRoleId() + "/" + b.GetSubject().GetType() + ":" + b.GetSubject().GetId()
+}
+
+func (s *Server) listAccessBindings(req *access.ListAccessBindingsRequest, kind string) (*access.ListAccessBindingsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.object(req.GetResourceId(), kind); err != nil {
+		return nil, err
+	}
+
+	resp := &access.ListAccessBindingsResponse{}
+	for _, b := range s.bindings[req.GetResourceId()] {
+		resp.AccessBindings = append(resp.AccessBindings, proto.Clone(b).(*access.AccessBinding))
+	}
+	return resp, nil
+}
+
+func (s *Server) setAccessBindings(req *access.SetAccessBindingsRequest, kind string) (*operation.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.object(req.GetResourceId(), kind); err != nil {
+		return nil, err
+	}
+
+	bindings := make([]*access.AccessBinding, 0, len(req.GetAccessBindings()))
+	seen := make(map[string]bool)
+	for _, b := range req.GetAccessBindings() {
+		if seen[bindingKey(b)] {
+			continue
+		}
+		seen[bindingKey(b)] = true
+		bindings = append(bindings, proto.Clone(b).(*access.AccessBinding))
+	}
+	s.bindings[req.GetResourceId()] = bindings
+
+	return s.doneOperation("Set access bindings",
+		&access.SetAccessBindingsMetadata{ResourceId: req.GetResourceId()}, &emptypb.Empty{})
+}
+
+func (s *Server) updateAccessBindings(req *access.UpdateAccessBindingsRequest, kind string) (*operation.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.object(req.GetResourceId(), kind); err != nil {
+		return nil, err
+	}
+
+	bindings := s.bindings[req.GetResourceId()]
+	for _, delta := range req.GetAccessBindingDeltas() {
+		key := bindingKey(delta.GetAccessBinding())
+		idx := -1
+		for i, b := range bindings {
+			if bindingKey(b) == key {
+				idx = i
+				break
+			}
+		}
+
+		switch delta.GetAction() {
+		case access.AccessBindingAction_ADD:
+			if idx < 0 {
+				bindings = append(bindings, proto.Clone(delta.GetAccessBinding()).(*access.AccessBinding))
+			}
+		case access.AccessBindingAction_REMOVE:
+			if idx >= 0 {
+				bindings = append(bindings[:idx], bindings[idx+1:]...)
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported access binding action %s", delta.GetAction())
+		}
+	}
+	s.bindings[req.GetResourceId()] = bindings
+
+	return s.doneOperation("Update access bindings",
+		&access.UpdateAccessBindingsMetadata{ResourceId: req.GetResourceId()}, &emptypb.Empty{})
+}
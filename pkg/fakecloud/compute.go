@@ -0,0 +1,153 @@
+package fakecloud
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+)
+
+const (
+	defaultDiskType      = "network-hdd"
+	defaultDiskBlockSize = 4096
+)
+
+type diskService struct {
+	compute.UnimplementedDiskServiceServer
+	s *Server
+}
+
+// disk must be called with s.mu held.
+func (ds *diskService) disk(id string) (*compute.Disk, error) {
+	obj, err := ds.s.object(id, "Disk")
+	if err != nil {
+		return nil, err
+	}
+	disk, ok := obj.(*compute.Disk)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Disk %s not found", id)
+	}
+	return disk, nil
+}
+
+func (ds *diskService) Get(_ context.Context, req *compute.GetDiskRequest) (*compute.Disk, error) {
+	ds.s.mu.Lock()
+	defer ds.s.mu.Unlock()
+
+	disk, err := ds.disk(req.GetDiskId())
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(disk).(*compute.Disk), nil
+}
+
+func (ds *diskService) List(_ context.Context, req *compute.ListDisksRequest) (*compute.ListDisksResponse, error) {
+	ds.s.mu.Lock()
+	defer ds.s.mu.Unlock()
+
+	resp := &compute.ListDisksResponse{}
+	for _, obj := range ds.s.objects {
+		disk, ok := obj.(*compute.Disk)
+		if !ok || disk.FolderId != req.GetFolderId() || !matchesFilter(req.GetFilter(), disk.Name) {
+			continue
+		}
+		resp.Disks = append(resp.Disks, proto.Clone(disk).(*compute.Disk))
+	}
+	return resp, nil
+}
+
+func (ds *diskService) Create(_ context.Context, req *compute.CreateDiskRequest) (*operation.Operation, error) {
+	ds.s.mu.Lock()
+	defer ds.s.mu.Unlock()
+
+	if _, err := ds.s.object(req.GetFolderId(), "Folder"); err != nil {
+		return nil, err
+	}
+	if req.GetZoneId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "zone_id is required")
+	}
+
+	disk := &compute.Disk{
+		Id:                  ds.s.newID("fhm"),
+		FolderId:            req.GetFolderId(),
+		CreatedAt:           timestamppb.Now(),
+		Name:                req.GetName(),
+		Description:         req.GetDescription(),
+		Labels:              req.GetLabels(),
+		TypeId:              req.GetTypeId(),
+		ZoneId:              req.GetZoneId(),
+		Size:                req.GetSize(),
+		BlockSize:           req.GetBlockSize(),
+		Status:              compute.Disk_READY,
+		DiskPlacementPolicy: req.GetDiskPlacementPolicy(),
+	}
+	if disk.TypeId == "" {
+		disk.TypeId = defaultDiskType
+	}
+	if disk.BlockSize == 0 {
+		disk.BlockSize = defaultDiskBlockSize
+	}
+	switch source := req.GetSource().(type) {
+	case *compute.CreateDiskRequest_ImageId:
+		disk.Source = &compute.Disk_SourceImageId{SourceImageId: source.ImageId}
+	case *compute.CreateDiskRequest_SnapshotId:
+		disk.Source = &compute.Disk_SourceSnapshotId{SourceSnapshotId: source.SnapshotId}
+	}
+	ds.s.objects[disk.Id] = disk
+
+	return ds.s.doneOperation("Create disk", &compute.CreateDiskMetadata{DiskId: disk.Id}, disk)
+}
+
+func (ds *diskService) Update(_ context.Context, req *compute.UpdateDiskRequest) (*operation.Operation, error) {
+	ds.s.mu.Lock()
+	defer ds.s.mu.Unlock()
+
+	disk, err := ds.disk(req.GetDiskId())
+	if err != nil {
+		return nil, err
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if hasUpdatePath(paths, "name") {
+		disk.Name = req.GetName()
+	}
+	if hasUpdatePath(paths, "description") {
+		disk.Description = req.GetDescription()
+	}
+	if hasUpdatePath(paths, "labels") {
+		disk.Labels = req.GetLabels()
+	}
+	if hasUpdatePath(paths, "size") && req.GetSize() != 0 {
+		if req.GetSize() < disk.Size {
+			return nil, status.Error(codes.InvalidArgument, "disk size can not be decreased")
+		}
+		disk.Size = req.GetSize()
+	}
+	if hasUpdatePath(paths, "disk_placement_policy") && req.GetDiskPlacementPolicy() != nil {
+		disk.DiskPlacementPolicy = req.GetDiskPlacementPolicy()
+	}
+
+	return ds.s.doneOperation("Update disk", &compute.UpdateDiskMetadata{DiskId: disk.Id}, disk)
+}
+
+func (ds *diskService) Delete(_ context.Context, req *compute.DeleteDiskRequest) (*operation.Operation, error) {
+	ds.s.mu.Lock()
+	defer ds.s.mu.Unlock()
+
+	disk, err := ds.disk(req.GetDiskId())
+	if err != nil {
+		return nil, err
+	}
+	if len(disk.InstanceIds) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "Disk %s is attached to instances", disk.Id)
+	}
+	delete(ds.s.objects, disk.Id)
+
+	return ds.s.doneOperation("Delete disk", &compute.DeleteDiskMetadata{DiskId: disk.Id}, &emptypb.Empty{})
+}
@@ -0,0 +1,163 @@
+package fakecloud
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+)
+
+type iamTokenService struct {
+	iam.UnimplementedIamTokenServiceServer
+	s *Server
+}
+
+func (i *iamTokenService) Create(context.Context, *iam.CreateIamTokenRequest) (*iam.CreateIamTokenResponse, error) {
+	return &iam.CreateIamTokenResponse{
+		IamToken:  Token,
+		ExpiresAt: timestamppb.New(time.Now().Add(12 * time.Hour)),
+	}, nil
+}
+
+// CreateForServiceAccount mints a token of an existing service account. The fake server
+// does not check the caller's permissions to impersonate it.
+func (i *iamTokenService) CreateForServiceAccount(_ context.Context, req *iam.CreateIamTokenForServiceAccountRequest) (*iam.CreateIamTokenResponse, error) {
+	i.s.mu.Lock()
+	defer i.s.mu.Unlock()
+
+	obj, err := i.s.object(req.GetServiceAccountId(), "Service account")
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := obj.(*iam.ServiceAccount); !ok {
+		return nil, status.Errorf(codes.NotFound, "Service account %s not found", req.GetServiceAccountId())
+	}
+	return &iam.CreateIamTokenResponse{
+		IamToken:  Token,
+		ExpiresAt: timestamppb.New(time.Now().Add(12 * time.Hour)),
+	}, nil
+}
+
+type serviceAccountService struct {
+	iam.UnimplementedServiceAccountServiceServer
+	s *Server
+}
+
+// serviceAccount must be called with s.mu held.
+func (sa *serviceAccountService) serviceAccount(id string) (*iam.ServiceAccount, error) {
+	obj, err := sa.s.object(id, "Service account")
+	if err != nil {
+		return nil, err
+	}
+	account, ok := obj.(*iam.ServiceAccount)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Service account %s not found", id)
+	}
+	return account, nil
+}
+
+func (sa *serviceAccountService) Get(_ context.Context, req *iam.GetServiceAccountRequest) (*iam.ServiceAccount, error) {
+	sa.s.mu.Lock()
+	defer sa.s.mu.Unlock()
+
+	account, err := sa.serviceAccount(req.GetServiceAccountId())
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(account).(*iam.ServiceAccount), nil
+}
+
+func (sa *serviceAccountService) List(_ context.Context, req *iam.ListServiceAccountsRequest) (*iam.ListServiceAccountsResponse, error) {
+	sa.s.mu.Lock()
+	defer sa.s.mu.Unlock()
+
+	resp := &iam.ListServiceAccountsResponse{}
+	for _, obj := range sa.s.objects {
+		account, ok := obj.(*iam.ServiceAccount)
+		if !ok || account.FolderId != req.GetFolderId() || !matchesFilter(req.GetFilter(), account.Name) {
+			continue
+		}
+		resp.ServiceAccounts = append(resp.ServiceAccounts, proto.Clone(account).(*iam.ServiceAccount))
+	}
+	return resp, nil
+}
+
+func (sa *serviceAccountService) Create(_ context.Context, req *iam.CreateServiceAccountRequest) (*operation.Operation, error) {
+	sa.s.mu.Lock()
+	defer sa.s.mu.Unlock()
+
+	if _, err := sa.s.object(req.GetFolderId(), "Folder"); err != nil {
+		return nil, err
+	}
+
+	account := &iam.ServiceAccount{
+		Id:          sa.s.newID("aje"),
+		FolderId:    req.GetFolderId(),
+		CreatedAt:   timestamppb.Now(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Labels:      req.GetLabels(),
+	}
+	sa.s.objects[account.Id] = account
+
+	return sa.s.doneOperation("Create service account",
+		&iam.CreateServiceAccountMetadata{ServiceAccountId: account.Id}, account)
+}
+
+func (sa *serviceAccountService) Update(_ context.Context, req *iam.UpdateServiceAccountRequest) (*operation.Operation, error) {
+	sa.s.mu.Lock()
+	defer sa.s.mu.Unlock()
+
+	account, err := sa.serviceAccount(req.GetServiceAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if hasUpdatePath(paths, "name") {
+		account.Name = req.GetName()
+	}
+	if hasUpdatePath(paths, "description") {
+		account.Description = req.GetDescription()
+	}
+	if hasUpdatePath(paths, "labels") {
+		account.Labels = req.GetLabels()
+	}
+
+	return sa.s.doneOperation("Update service account",
+		&iam.UpdateServiceAccountMetadata{ServiceAccountId: account.Id}, account)
+}
+
+func (sa *serviceAccountService) Delete(_ context.Context, req *iam.DeleteServiceAccountRequest) (*operation.Operation, error) {
+	sa.s.mu.Lock()
+	defer sa.s.mu.Unlock()
+
+	if _, err := sa.serviceAccount(req.GetServiceAccountId()); err != nil {
+		return nil, err
+	}
+	delete(sa.s.objects, req.GetServiceAccountId())
+	delete(sa.s.bindings, req.GetServiceAccountId())
+
+	return sa.s.doneOperation("Delete service account",
+		&iam.DeleteServiceAccountMetadata{ServiceAccountId: req.GetServiceAccountId()}, &emptypb.Empty{})
+}
+
+func (sa *serviceAccountService) ListAccessBindings(_ context.Context, req *access.ListAccessBindingsRequest) (*access.ListAccessBindingsResponse, error) {
+	return sa.s.listAccessBindings(req, "Service account")
+}
+
+func (sa *serviceAccountService) SetAccessBindings(_ context.Context, req *access.SetAccessBindingsRequest) (*operation.Operation, error) {
+	return sa.s.setAccessBindings(req, "Service account")
+}
+
+func (sa *serviceAccountService) UpdateAccessBindings(_ context.Context, req *access.UpdateAccessBindingsRequest) (*operation.Operation, error) {
+	return sa.s.updateAccessBindings(req, "Service account")
+}
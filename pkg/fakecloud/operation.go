@@ -0,0 +1,32 @@
+package fakecloud
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+)
+
+type operationService struct {
+	operation.UnimplementedOperationServiceServer
+	s *Server
+}
+
+func (o *operationService) Get(_ context.Context, req *operation.GetOperationRequest) (*operation.Operation, error) {
+	o.s.mu.Lock()
+	defer o.s.mu.Unlock()
+
+	op, ok := o.s.operations[req.GetOperationId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "operation %s not found", req.GetOperationId())
+	}
+	return proto.Clone(op).(*operation.Operation), nil
+}
+
+func (o *operationService) Cancel(ctx context.Context, req *operation.CancelOperationRequest) (*operation.Operation, error) {
+	// All the operations of the fake server are completed right away, there is nothing to cancel.
+	return o.Get(ctx, &operation.GetOperationRequest{OperationId: req.GetOperationId()})
+}
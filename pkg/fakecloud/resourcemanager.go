@@ -0,0 +1,176 @@
+package fakecloud
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/resourcemanager/v1"
+)
+
+type cloudService struct {
+	resourcemanager.UnimplementedCloudServiceServer
+	s *Server
+}
+
+func (c *cloudService) Get(_ context.Context, req *resourcemanager.GetCloudRequest) (*resourcemanager.Cloud, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+
+	obj, err := c.s.object(req.GetCloudId(), "Cloud")
+	if err != nil {
+		return nil, err
+	}
+	cloud, ok := obj.(*resourcemanager.Cloud)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Cloud %s not found", req.GetCloudId())
+	}
+	return proto.Clone(cloud).(*resourcemanager.Cloud), nil
+}
+
+func (c *cloudService) List(_ context.Context, req *resourcemanager.ListCloudsRequest) (*resourcemanager.ListCloudsResponse, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+
+	resp := &resourcemanager.ListCloudsResponse{}
+	for _, obj := range c.s.objects {
+		cloud, ok := obj.(*resourcemanager.Cloud)
+		if !ok || !matchesFilter(req.GetFilter(), cloud.Name) {
+			continue
+		}
+		resp.Clouds = append(resp.Clouds, proto.Clone(cloud).(*resourcemanager.Cloud))
+	}
+	return resp, nil
+}
+
+func (c *cloudService) ListAccessBindings(_ context.Context, req *access.ListAccessBindingsRequest) (*access.ListAccessBindingsResponse, error) {
+	return c.s.listAccessBindings(req, "Cloud")
+}
+
+func (c *cloudService) SetAccessBindings(_ context.Context, req *access.SetAccessBindingsRequest) (*operation.Operation, error) {
+	return c.s.setAccessBindings(req, "Cloud")
+}
+
+func (c *cloudService) UpdateAccessBindings(_ context.Context, req *access.UpdateAccessBindingsRequest) (*operation.Operation, error) {
+	return c.s.updateAccessBindings(req, "Cloud")
+}
+
+type folderService struct {
+	resourcemanager.UnimplementedFolderServiceServer
+	s *Server
+}
+
+// folder must be called with s.mu held.
+func (f *folderService) folder(id string) (*resourcemanager.Folder, error) {
+	obj, err := f.s.object(id, "Folder")
+	if err != nil {
+		return nil, err
+	}
+	folder, ok := obj.(*resourcemanager.Folder)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Folder %s not found", id)
+	}
+	return folder, nil
+}
+
+func (f *folderService) Get(_ context.Context, req *resourcemanager.GetFolderRequest) (*resourcemanager.Folder, error) {
+	f.s.mu.Lock()
+	defer f.s.mu.Unlock()
+
+	folder, err := f.folder(req.GetFolderId())
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(folder).(*resourcemanager.Folder), nil
+}
+
+func (f *folderService) List(_ context.Context, req *resourcemanager.ListFoldersRequest) (*resourcemanager.ListFoldersResponse, error) {
+	f.s.mu.Lock()
+	defer f.s.mu.Unlock()
+
+	resp := &resourcemanager.ListFoldersResponse{}
+	for _, obj := range f.s.objects {
+		folder, ok := obj.(*resourcemanager.Folder)
+		if !ok || folder.CloudId != req.GetCloudId() || !matchesFilter(req.GetFilter(), folder.Name) {
+			continue
+		}
+		resp.Folders = append(resp.Folders, proto.Clone(folder).(*resourcemanager.Folder))
+	}
+	return resp, nil
+}
+
+func (f *folderService) Create(_ context.Context, req *resourcemanager.CreateFolderRequest) (*operation.Operation, error) {
+	f.s.mu.Lock()
+	defer f.s.mu.Unlock()
+
+	if _, err := f.s.object(req.GetCloudId(), "Cloud"); err != nil {
+		return nil, err
+	}
+
+	folder := &resourcemanager.Folder{
+		Id:          f.s.newID("b1g"),
+		CloudId:     req.GetCloudId(),
+		CreatedAt:   timestamppb.Now(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Labels:      req.GetLabels(),
+		Status:      resourcemanager.Folder_ACTIVE,
+	}
+	f.s.objects[folder.Id] = folder
+
+	return f.s.doneOperation("Create folder", &resourcemanager.CreateFolderMetadata{FolderId: folder.Id}, folder)
+}
+
+func (f *folderService) Update(_ context.Context, req *resourcemanager.UpdateFolderRequest) (*operation.Operation, error) {
+	f.s.mu.Lock()
+	defer f.s.mu.Unlock()
+
+	folder, err := f.folder(req.GetFolderId())
+	if err != nil {
+		return nil, err
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if hasUpdatePath(paths, "name") {
+		folder.Name = req.GetName()
+	}
+	if hasUpdatePath(paths, "description") {
+		folder.Description = req.GetDescription()
+	}
+	if hasUpdatePath(paths, "labels") {
+		folder.Labels = req.GetLabels()
+	}
+
+	return f.s.doneOperation("Update folder", &resourcemanager.UpdateFolderMetadata{FolderId: folder.Id}, folder)
+}
+
+func (f *folderService) Delete(_ context.Context, req *resourcemanager.DeleteFolderRequest) (*operation.Operation, error) {
+	f.s.mu.Lock()
+	defer f.s.mu.Unlock()
+
+	if _, err := f.folder(req.GetFolderId()); err != nil {
+		return nil, err
+	}
+	delete(f.s.objects, req.GetFolderId())
+	delete(f.s.bindings, req.GetFolderId())
+
+	return f.s.doneOperation("Delete folder", &resourcemanager.DeleteFolderMetadata{FolderId: req.GetFolderId()}, &emptypb.Empty{})
+}
+
+func (f *folderService) ListAccessBindings(_ context.Context, req *access.ListAccessBindingsRequest) (*access.ListAccessBindingsResponse, error) {
+	return f.s.listAccessBindings(req, "Folder")
+}
+
+func (f *folderService) SetAccessBindings(_ context.Context, req *access.SetAccessBindingsRequest) (*operation.Operation, error) {
+	return f.s.setAccessBindings(req, "Folder")
+}
+
+func (f *folderService) UpdateAccessBindings(_ context.Context, req *access.UpdateAccessBindingsRequest) (*operation.Operation, error) {
+	return f.s.updateAccessBindings(req, "Folder")
+}
@@ -0,0 +1,270 @@
+// Package fakecloud implements an in-process stand-in for the Yandex Cloud API.
+//
+// The server speaks plain gRPC and keeps all state in memory, so the provider
+// can be pointed at it through Config.Endpoint and Config.Plaintext and resource
+// CRUD, import and drift scenarios can be exercised without network access.
+// Only the subset of services and methods the provider unit tests rely on is
+// implemented, everything else returns codes.Unimplemented.
+package fakecloud
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/endpoint"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/resourcemanager/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
+)
+
+// Token is an IAM token accepted by the fake server. It has the "t1.*.*" shape,
+// so the provider uses it as is and does not try to exchange it.
+const Token = "t1.fake-cloud.token"
+
+// Endpoint IDs announced by the fake ApiEndpointService. The SDK resolves every
+// service it talks to through this list.
+var endpointIDs = []string{
+	"endpoint",
+	"operation",
+	"resource-manager",
+	"resourcemanager",
+	"iam",
+	"compute",
+	"vpc",
+}
+
+var nameFilterRegex = regexp.MustCompile(`name\s*=\s*"([^"]*)"`)
+
+// Server is an in-memory Yandex Cloud API.
+type Server struct {
+	mu         sync.Mutex
+	lastID     int
+	objects    map[string]proto.Message
+	bindings   map[string][]*access.AccessBinding
+	operations map[string]*operation.Operation
+
+	grpcServer *grpc.Server
+	listener   net.Listener
+}
+
+// NewServer starts a fake API server listening on a random local port.
+func NewServer() (*Server, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		l, err = net.Listen("tcp6", "[::1]:0")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on any port: %w", err)
+	}
+
+	s := &Server{
+		objects:    make(map[string]proto.Message),
+		bindings:   make(map[string][]*access.AccessBinding),
+		operations: make(map[string]*operation.Operation),
+		grpcServer: grpc.NewServer(),
+		listener:   l,
+	}
+
+	endpoint.RegisterApiEndpointServiceServer(s.grpcServer, &apiEndpointService{s: s})
+	operation.RegisterOperationServiceServer(s.grpcServer, &operationService{s: s})
+	resourcemanager.RegisterCloudServiceServer(s.grpcServer, &cloudService{s: s})
+	resourcemanager.RegisterFolderServiceServer(s.grpcServer, &folderService{s: s})
+	iam.RegisterIamTokenServiceServer(s.grpcServer, &iamTokenService{s: s})
+	iam.RegisterServiceAccountServiceServer(s.grpcServer, &serviceAccountService{s: s})
+	compute.RegisterDiskServiceServer(s.grpcServer, &diskService{s: s})
+	vpc.RegisterNetworkServiceServer(s.grpcServer, &networkService{s: s})
+	vpc.RegisterSubnetServiceServer(s.grpcServer, &subnetService{s: s})
+
+	go func() { _ = s.grpcServer.Serve(l) }()
+
+	return s, nil
+}
+
+// Addr returns the address to be used as provider endpoint.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Stop stops the server and closes its listener.
+func (s *Server) Stop() {
+	s.grpcServer.Stop()
+}
+
+// AddCloud registers a cloud with the given ID and name.
+func (s *Server) AddCloud(id, name string) *resourcemanager.Cloud {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cloud := &resourcemanager.Cloud{
+		Id:        id,
+		Name:      name,
+		CreatedAt: timestamppb.Now(),
+	}
+	s.objects[id] = cloud
+	return cloud
+}
+
+// AddFolder registers an active folder with the given ID and name in the cloud.
+func (s *Server) AddFolder(cloudID, id, name string) *resourcemanager.Folder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	folder := &resourcemanager.Folder{
+		Id:        id,
+		CloudId:   cloudID,
+		Name:      name,
+		CreatedAt: timestamppb.Now(),
+		Status:    resourcemanager.Folder_ACTIVE,
+	}
+	s.objects[id] = folder
+	return folder
+}
+
+// Get returns a copy of the object with the given ID, or nil if there is no such object.
+func (s *Server) Get(id string) proto.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[id]
+	if !ok {
+		return nil
+	}
+	return proto.Clone(obj)
+}
+
+// Mutate applies an out-of-band change to the stored object, the way a change
+// made through the console or CLI would. It returns false if there is no such object.
+func (s *Server) Mutate(id string, mutate func(obj proto.Message)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[id]
+	if !ok {
+		return false
+	}
+	mutate(obj)
+	return true
+}
+
+// Delete removes the object with the given ID out-of-band.
+func (s *Server) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, id)
+	delete(s.bindings, id)
+}
+
+// AccessBindings returns access bindings of the resource with the given ID.
+func (s *Server) AccessBindings(id string) []*access.AccessBinding {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]*access.AccessBinding, 0, len(s.bindings[id]))
+	for _, b := range s.bindings[id] {
+		result = append(result, proto.Clone(b).(*access.AccessBinding))
+	}
+	return result
+}
+
+// newID must be called with s.mu held.
+func (s *Server) newID(prefix string) string {
+	s.lastID++
+	return fmt.Sprintf("%s%017d", prefix, s.lastID)
+}
+
+// object must be called with s.mu held.
+func (s *Server) object(id string, kind string) (proto.Message, error) {
+	obj, ok := s.objects[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "%s %s not found", kind, id)
+	}
+	return obj, nil
+}
+
+// doneOperation must be called with s.mu held.
+func (s *Server) doneOperation(description string, metadata proto.Message, response proto.Message) (*operation.Operation, error) {
+	md, err := anypb.New(metadata)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal operation metadata: %s", err)
+	}
+	resp, err := anypb.New(response)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal operation response: %s", err)
+	}
+
+	now := timestamppb.Now()
+	op := &operation.Operation{
+		Id:          s.newID("op"),
+		Description: description,
+		CreatedAt:   now,
+		ModifiedAt:  now,
+		Done:        true,
+		Metadata:    md,
+		Result:      &operation.Operation_Response{Response: resp},
+	}
+	s.operations[op.Id] = op
+	return op, nil
+}
+
+func nameFromFilter(filter string) (string, bool) {
+	m := nameFilterRegex.FindStringSubmatch(filter)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+func matchesFilter(filter string, name string) bool {
+	if n, ok := nameFromFilter(filter); ok {
+		return n == name
+	}
+	return true
+}
+
+func hasUpdatePath(paths []string, path string) bool {
+	// Requests without update mask replace all the updatable fields.
+	if len(paths) == 0 {
+		return true
+	}
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+type apiEndpointService struct {
+	s *Server
+}
+
+func (a *apiEndpointService) Get(_ context.Context, req *endpoint.GetApiEndpointRequest) (*endpoint.ApiEndpoint, error) {
+	for _, id := range endpointIDs {
+		if id == req.GetApiEndpointId() {
+			return &endpoint.ApiEndpoint{Id: id, Address: a.s.Addr()}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "api endpoint %s not found", req.GetApiEndpointId())
+}
+
+func (a *apiEndpointService) List(context.Context, *endpoint.ListApiEndpointsRequest) (*endpoint.ListApiEndpointsResponse, error) {
+	resp := &endpoint.ListApiEndpointsResponse{}
+	for _, id := range endpointIDs {
+		resp.Endpoints = append(resp.Endpoints, &endpoint.ApiEndpoint{Id: id, Address: a.s.Addr()})
+	}
+	return resp, nil
+}
@@ -0,0 +1,258 @@
+package fakecloud
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
+)
+
+type networkService struct {
+	vpc.UnimplementedNetworkServiceServer
+	s *Server
+}
+
+// network must be called with s.mu held.
+func (ns *networkService) network(id string) (*vpc.Network, error) {
+	obj, err := ns.s.object(id, "Network")
+	if err != nil {
+		return nil, err
+	}
+	network, ok := obj.(*vpc.Network)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Network %s not found", id)
+	}
+	return network, nil
+}
+
+func (ns *networkService) Get(_ context.Context, req *vpc.GetNetworkRequest) (*vpc.Network, error) {
+	ns.s.mu.Lock()
+	defer ns.s.mu.Unlock()
+
+	network, err := ns.network(req.GetNetworkId())
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(network).(*vpc.Network), nil
+}
+
+func (ns *networkService) List(_ context.Context, req *vpc.ListNetworksRequest) (*vpc.ListNetworksResponse, error) {
+	ns.s.mu.Lock()
+	defer ns.s.mu.Unlock()
+
+	resp := &vpc.ListNetworksResponse{}
+	for _, obj := range ns.s.objects {
+		network, ok := obj.(*vpc.Network)
+		if !ok || network.FolderId != req.GetFolderId() || !matchesFilter(req.GetFilter(), network.Name) {
+			continue
+		}
+		resp.Networks = append(resp.Networks, proto.Clone(network).(*vpc.Network))
+	}
+	return resp, nil
+}
+
+func (ns *networkService) ListSubnets(_ context.Context, req *vpc.ListNetworkSubnetsRequest) (*vpc.ListNetworkSubnetsResponse, error) {
+	ns.s.mu.Lock()
+	defer ns.s.mu.Unlock()
+
+	if _, err := ns.network(req.GetNetworkId()); err != nil {
+		return nil, err
+	}
+
+	resp := &vpc.ListNetworkSubnetsResponse{}
+	for _, obj := range ns.s.objects {
+		subnet, ok := obj.(*vpc.Subnet)
+		if !ok || subnet.NetworkId != req.GetNetworkId() {
+			continue
+		}
+		resp.Subnets = append(resp.Subnets, proto.Clone(subnet).(*vpc.Subnet))
+	}
+	return resp, nil
+}
+
+func (ns *networkService) Create(_ context.Context, req *vpc.CreateNetworkRequest) (*operation.Operation, error) {
+	ns.s.mu.Lock()
+	defer ns.s.mu.Unlock()
+
+	if _, err := ns.s.object(req.GetFolderId(), "Folder"); err != nil {
+		return nil, err
+	}
+
+	network := &vpc.Network{
+		Id:                     ns.s.newID("enp"),
+		FolderId:               req.GetFolderId(),
+		CreatedAt:              timestamppb.Now(),
+		Name:                   req.GetName(),
+		Description:            req.GetDescription(),
+		Labels:                 req.GetLabels(),
+		DefaultSecurityGroupId: ns.s.newID("enp"),
+	}
+	ns.s.objects[network.Id] = network
+
+	return ns.s.doneOperation("Create network", &vpc.CreateNetworkMetadata{NetworkId: network.Id}, network)
+}
+
+func (ns *networkService) Update(_ context.Context, req *vpc.UpdateNetworkRequest) (*operation.Operation, error) {
+	ns.s.mu.Lock()
+	defer ns.s.mu.Unlock()
+
+	network, err := ns.network(req.GetNetworkId())
+	if err != nil {
+		return nil, err
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if hasUpdatePath(paths, "name") {
+		network.Name = req.GetName()
+	}
+	if hasUpdatePath(paths, "description") {
+		network.Description = req.GetDescription()
+	}
+	if hasUpdatePath(paths, "labels") {
+		network.Labels = req.GetLabels()
+	}
+
+	return ns.s.doneOperation("Update network", &vpc.UpdateNetworkMetadata{NetworkId: network.Id}, network)
+}
+
+func (ns *networkService) Delete(_ context.Context, req *vpc.DeleteNetworkRequest) (*operation.Operation, error) {
+	ns.s.mu.Lock()
+	defer ns.s.mu.Unlock()
+
+	if _, err := ns.network(req.GetNetworkId()); err != nil {
+		return nil, err
+	}
+	for _, obj := range ns.s.objects {
+		if subnet, ok := obj.(*vpc.Subnet); ok && subnet.NetworkId == req.GetNetworkId() {
+			return nil, status.Errorf(codes.FailedPrecondition, "Network %s is not empty", req.GetNetworkId())
+		}
+	}
+	delete(ns.s.objects, req.GetNetworkId())
+
+	return ns.s.doneOperation("Delete network",
+		&vpc.DeleteNetworkMetadata{NetworkId: req.GetNetworkId()}, &emptypb.Empty{})
+}
+
+type subnetService struct {
+	vpc.UnimplementedSubnetServiceServer
+	s *Server
+}
+
+// subnet must be called with s.mu held.
+func (ss *subnetService) subnet(id string) (*vpc.Subnet, error) {
+	obj, err := ss.s.object(id, "Subnet")
+	if err != nil {
+		return nil, err
+	}
+	subnet, ok := obj.(*vpc.Subnet)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Subnet %s not found", id)
+	}
+	return subnet, nil
+}
+
+func (ss *subnetService) Get(_ context.Context, req *vpc.GetSubnetRequest) (*vpc.Subnet, error) {
+	ss.s.mu.Lock()
+	defer ss.s.mu.Unlock()
+
+	subnet, err := ss.subnet(req.GetSubnetId())
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(subnet).(*vpc.Subnet), nil
+}
+
+func (ss *subnetService) List(_ context.Context, req *vpc.ListSubnetsRequest) (*vpc.ListSubnetsResponse, error) {
+	ss.s.mu.Lock()
+	defer ss.s.mu.Unlock()
+
+	resp := &vpc.ListSubnetsResponse{}
+	for _, obj := range ss.s.objects {
+		subnet, ok := obj.(*vpc.Subnet)
+		if !ok || subnet.FolderId != req.GetFolderId() || !matchesFilter(req.GetFilter(), subnet.Name) {
+			continue
+		}
+		resp.Subnets = append(resp.Subnets, proto.Clone(subnet).(*vpc.Subnet))
+	}
+	return resp, nil
+}
+
+func (ss *subnetService) Create(_ context.Context, req *vpc.CreateSubnetRequest) (*operation.Operation, error) {
+	ss.s.mu.Lock()
+	defer ss.s.mu.Unlock()
+
+	if _, err := ss.s.object(req.GetFolderId(), "Folder"); err != nil {
+		return nil, err
+	}
+	if _, err := ss.s.object(req.GetNetworkId(), "Network"); err != nil {
+		return nil, err
+	}
+	if len(req.GetV4CidrBlocks()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "v4_cidr_blocks is required")
+	}
+
+	subnet := &vpc.Subnet{
+		Id:           ss.s.newID("e9b"),
+		FolderId:     req.GetFolderId(),
+		CreatedAt:    timestamppb.Now(),
+		Name:         req.GetName(),
+		Description:  req.GetDescription(),
+		Labels:       req.GetLabels(),
+		NetworkId:    req.GetNetworkId(),
+		ZoneId:       req.GetZoneId(),
+		V4CidrBlocks: req.GetV4CidrBlocks(),
+		RouteTableId: req.GetRouteTableId(),
+		DhcpOptions:  req.GetDhcpOptions(),
+	}
+	ss.s.objects[subnet.Id] = subnet
+
+	return ss.s.doneOperation("Create subnet", &vpc.CreateSubnetMetadata{SubnetId: subnet.Id}, subnet)
+}
+
+func (ss *subnetService) Update(_ context.Context, req *vpc.UpdateSubnetRequest) (*operation.Operation, error) {
+	ss.s.mu.Lock()
+	defer ss.s.mu.Unlock()
+
+	subnet, err := ss.subnet(req.GetSubnetId())
+	if err != nil {
+		return nil, err
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if hasUpdatePath(paths, "name") {
+		subnet.Name = req.GetName()
+	}
+	if hasUpdatePath(paths, "description") {
+		subnet.Description = req.GetDescription()
+	}
+	if hasUpdatePath(paths, "labels") {
+		subnet.Labels = req.GetLabels()
+	}
+	if hasUpdatePath(paths, "route_table_id") {
+		subnet.RouteTableId = req.GetRouteTableId()
+	}
+	if hasUpdatePath(paths, "dhcp_options") {
+		subnet.DhcpOptions = req.GetDhcpOptions()
+	}
+
+	return ss.s.doneOperation("Update subnet", &vpc.UpdateSubnetMetadata{SubnetId: subnet.Id}, subnet)
+}
+
+func (ss *subnetService) Delete(_ context.Context, req *vpc.DeleteSubnetRequest) (*operation.Operation, error) {
+	ss.s.mu.Lock()
+	defer ss.s.mu.Unlock()
+
+	if _, err := ss.subnet(req.GetSubnetId()); err != nil {
+		return nil, err
+	}
+	delete(ss.s.objects, req.GetSubnetId())
+
+	return ss.s.doneOperation("Delete subnet",
+		&vpc.DeleteSubnetMetadata{SubnetId: req.GetSubnetId()}, &emptypb.Empty{})
+}
@@ -0,0 +1,121 @@
+// Package grpcjson calls unary gRPC methods by their fully qualified names with requests
+// and responses in protobuf JSON format.
+//
+// Methods are looked up in the global protobuf registry, so only the services whose Go
+// packages are linked into the binary can be called.
+package grpcjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// FindMethod returns the descriptor of a unary method named as "package.Service/Method"
+// or "package.Service.Method".
+func FindMethod(name string) (protoreflect.MethodDescriptor, error) {
+	name = strings.TrimPrefix(name, "/")
+	i := strings.LastIndexAny(name, "/.")
+	if i <= 0 || i == len(name)-1 {
+		return nil, fmt.Errorf("invalid method name %q, expected package.Service/Method", name)
+	}
+
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name[:i]))
+	if err != nil {
+		return nil, fmt.Errorf("unknown service of method %q: %w", name, err)
+	}
+	service, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a service", name[:i])
+	}
+	method := service.Methods().ByName(protoreflect.Name(name[i+1:]))
+	if method == nil {
+		return nil, fmt.Errorf("service %q has no method %q", service.FullName(), name[i+1:])
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("method %q is not unary", name)
+	}
+	return method, nil
+}
+
+// Invoke calls the method with the request unmarshalled from requestJSON and returns the response.
+func Invoke(ctx context.Context, cc grpc.ClientConnInterface, method protoreflect.MethodDescriptor, requestJSON string) (proto.Message, error) {
+	req := newMessage(method.Input())
+	if err := protojson.Unmarshal([]byte(requestJSON), req); err != nil {
+		return nil, fmt.Errorf("invalid request of method %q: %w", method.FullName(), err)
+	}
+
+	resp := newMessage(method.Output())
+	fullMethod := fmt.Sprintf("/%s/%s", method.Parent().FullName(), method.Name())
+	if err := cc.Invoke(ctx, fullMethod, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// newMessage prefers the generated type of the message, so that the message can be converted
+// to it, e.g. when the response is an operation.
+func newMessage(d protoreflect.MessageDescriptor) proto.Message {
+	if mt, err := protoregistry.GlobalTypes.FindMessageByName(d.FullName()); err == nil {
+		return mt.New().Interface()
+	}
+	return dynamicpb.NewMessage(d)
+}
+
+// Marshal returns the message in compact protobuf JSON format. The output of protojson is
+// deliberately unstable, so it is compacted not to change between calls.
+func Marshal(m proto.Message) (string, error) {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Lookup returns the value at the dot separated path in the JSON document. Elements of arrays
+// are addressed by their indexes, e.g. "spec.rules.0.name".
+func Lookup(document string, path string) (interface{}, bool, error) {
+	var v interface{}
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, false, fmt.Errorf("invalid JSON document: %w", err)
+	}
+
+	if path == "" {
+		return v, true, nil
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			value, ok := node[key]
+			if !ok {
+				return nil, false, nil
+			}
+			v = value
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false, nil
+			}
+			v = node[i]
+		default:
+			return nil, false, nil
+		}
+	}
+	return v, true, nil
+}
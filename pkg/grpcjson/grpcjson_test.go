@@ -0,0 +1,94 @@
+package grpcjson_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/grpcjson"
+)
+
+func TestFindMethod(t *testing.T) {
+	for _, name := range []string{"grpc.health.v1.Health/Check", "grpc.health.v1.Health.Check", "/grpc.health.v1.Health/Check"} {
+		method, err := grpcjson.FindMethod(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, "grpc.health.v1.Health.Check", string(method.FullName()))
+	}
+
+	for name, message := range map[string]string{
+		"Check":                                   "invalid method name",
+		"grpc.health.v1.Health/":                  "invalid method name",
+		"grpc.health.v1.Unknown/Check":            "unknown service",
+		"grpc.health.v1.HealthCheckRequest/Check": "is not a service",
+		"grpc.health.v1.Health/Unknown":           "has no method",
+		"grpc.health.v1.Health/Watch":             "is not unary",
+	} {
+		_, err := grpcjson.FindMethod(name)
+		assert.ErrorContains(t, err, message, name)
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("compute", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	method, err := grpcjson.FindMethod("grpc.health.v1.Health/Check")
+	require.NoError(t, err)
+
+	resp, err := grpcjson.Invoke(context.Background(), conn, method, `{"service": "compute"}`)
+	require.NoError(t, err)
+	assert.IsType(t, &healthpb.HealthCheckResponse{}, resp)
+	out, err := grpcjson.Marshal(resp)
+	require.NoError(t, err)
+	assert.Equal(t, `{"status":"NOT_SERVING"}`, out)
+
+	_, err = grpcjson.Invoke(context.Background(), conn, method, `{"unknown": 1}`)
+	assert.ErrorContains(t, err, "invalid request")
+
+	_, err = grpcjson.Invoke(context.Background(), conn, method, `{"service": "vpc"}`)
+	assert.ErrorContains(t, err, "unknown service")
+}
+
+func TestLookup(t *testing.T) {
+	document := `{"id": "net1", "spec": {"size": 10, "rules": [{"name": "a"}, {"name": "b"}]}}`
+	for path, expected := range map[string]interface{}{
+		"id":                "net1",
+		"spec.size":         json.Number("10"),
+		"spec.rules.1.name": "b",
+		"spec.rules.0":      map[string]interface{}{"name": "a"},
+	} {
+		v, ok, err := grpcjson.Lookup(document, path)
+		require.NoError(t, err)
+		assert.True(t, ok, path)
+		assert.Equal(t, expected, v, path)
+	}
+
+	for _, path := range []string{"name", "spec.rules.2.name", "spec.rules.x", "id.x"} {
+		_, ok, err := grpcjson.Lookup(document, path)
+		require.NoError(t, err)
+		assert.False(t, ok, path)
+	}
+
+	_, _, err := grpcjson.Lookup("{", "id")
+	assert.ErrorContains(t, err, "invalid JSON document")
+}
@@ -0,0 +1,67 @@
+package impersonation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+)
+
+type fakeMinter struct {
+	now      func() time.Time
+	requests []string
+	err      error
+}
+
+func (f *fakeMinter) CreateForServiceAccount(_ context.Context, in *iam.CreateIamTokenForServiceAccountRequest, _ ...grpc.CallOption) (*iam.CreateIamTokenResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.requests = append(f.requests, in.GetServiceAccountId())
+	return &iam.CreateIamTokenResponse{
+		IamToken:  fmt.Sprintf("t1.%s.%d", in.GetServiceAccountId(), len(f.requests)),
+		ExpiresAt: timestamppb.New(f.now().Add(time.Hour)),
+	}, nil
+}
+
+func TestIAMTokenIsRefreshedBeforeExpiry(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+	minter := &fakeMinter{now: clock}
+
+	creds := NewCredentials("target-sa", minter)
+	creds.now = clock
+
+	token, err := creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t1.target-sa.1", token.GetIamToken())
+
+	now = now.Add(50 * time.Minute)
+	token, err = creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t1.target-sa.1", token.GetIamToken())
+
+	now = now.Add(6 * time.Minute)
+	token, err = creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t1.target-sa.2", token.GetIamToken())
+
+	assert.Equal(t, []string{"target-sa", "target-sa"}, minter.requests)
+}
+
+func TestIAMTokenError(t *testing.T) {
+	minter := &fakeMinter{now: time.Now, err: status.Error(codes.PermissionDenied, "no iam.serviceAccounts.tokenCreator role")}
+
+	_, err := NewCredentials("target-sa", minter).IAMToken(context.Background())
+	assert.ErrorContains(t, err, "target-sa")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,138 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/apitrace"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+)
+
+// Headers set by the go-sdk request id interceptor, and the one the API responds with.
+const (
+	clientRequestIDHeader = "x-client-request-id"
+	clientTraceIDHeader   = "x-client-trace-id"
+	serverRequestIDHeader = "x-request-id"
+)
+
+var (
+	traceWritersMu sync.Mutex
+	// traceWriters are shared by all the SDK clients of the process, so that records
+	// of the muxed providers are not interleaved in the same file.
+	traceWriters = map[string]*traceWriter{}
+)
+
+type traceWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (t *traceWriter) write(record *apitrace.Record) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		log.Print("[DEBUG] Failed to marshal API trace record ", err)
+		return
+	}
+	line = append(line, '\n')
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, err := t.w.Write(line); err != nil {
+		log.Print("[DEBUG] Failed to write API trace record ", err)
+	}
+}
+
+// NewAPITraceFileUnaryInterceptor returns an interceptor appending a JSON record of every call
+// to the file at the given path. The interceptor must be placed below the request id one.
+func NewAPITraceFileUnaryInterceptor(path string) (grpc.UnaryClientInterceptor, error) {
+	traceWritersMu.Lock()
+	defer traceWritersMu.Unlock()
+
+	t, ok := traceWriters[path]
+	if !ok {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open API trace file: %w", err)
+		}
+		t = &traceWriter{w: f}
+		traceWriters[path] = t
+	}
+	return newAPITraceUnaryInterceptor(t), nil
+}
+
+// NewAPITraceUnaryInterceptor returns an interceptor writing a JSON record of every call to w.
+func NewAPITraceUnaryInterceptor(w io.Writer) grpc.UnaryClientInterceptor {
+	return newAPITraceUnaryInterceptor(&traceWriter{w: w})
+}
+
+func newAPITraceUnaryInterceptor(t *traceWriter) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, resp interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var header metadata.MD
+		opts = append(opts, grpc.Header(&header))
+
+		start := time.Now()
+		err := invoker(ctx, method, req, resp, cc, opts...)
+		duration := time.Since(start)
+
+		md, _ := metadata.FromOutgoingContext(ctx)
+		record := &apitrace.Record{
+			Time:          start.UTC(),
+			Method:        method,
+			RequestID:     firstValue(md, clientRequestIDHeader),
+			ClientTraceID: firstValue(md, clientTraceIDHeader),
+			DurationMs:    float64(duration) / float64(time.Millisecond),
+			Request:       traceMessage(req),
+		}
+		if record.RequestID == "" {
+			record.RequestID = firstValue(header, serverRequestIDHeader)
+		}
+		if attempt, convErr := strconv.Atoi(firstValue(md, retrypolicy.AttemptHeader)); convErr == nil {
+			record.Attempt = attempt
+		}
+
+		st, _ := statusFromError(err)
+		record.StatusCode = codeString(st.Code())
+		if err == nil {
+			record.Response = traceMessage(resp)
+		} else {
+			record.Error = traceMessage(st.Proto())
+		}
+
+		t.write(record)
+		return err
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func traceMessage(message interface{}) json.RawMessage {
+	if IsNil(message) {
+		return nil
+	}
+	m, ok := message.(proto.Message)
+	if !ok {
+		return nil
+	}
+	b, err := JSONHidingSensitiveValuesMarshaller(m)
+	if err != nil {
+		log.Print("[DEBUG] Failed to marshal API trace message ", err)
+		return nil
+	}
+	return b
+}
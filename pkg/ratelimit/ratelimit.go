@@ -0,0 +1,201 @@
+// Package ratelimit implements client-side rate limiting of Yandex Cloud API calls.
+//
+// Every Rule applies to the gRPC methods matching its service pattern and combines a token
+// bucket limiting the request rate with a cap on the number of requests in flight. The limits
+// of a rule are shared by all the methods it matches.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Rule describes limits of calls to the matching methods.
+type Rule struct {
+	// Service is a full gRPC method name or a prefix followed by "*", e.g. "yandex.cloud.compute.*".
+	// The "*" pattern matches all the methods.
+	Service string
+	// RequestsPerSecond is the rate of the token bucket, zero means no rate limit.
+	RequestsPerSecond float64
+	// Burst is the size of the token bucket, defaults to the rate rounded up.
+	Burst int
+	// MaxInFlight is the maximum number of concurrent requests, zero means no limit.
+	MaxInFlight int
+}
+
+// Limiter limits calls according to a set of rules. A call is limited by the rule
+// with the longest matching service pattern only.
+type Limiter struct {
+	rules []*rule
+}
+
+type rule struct {
+	Rule
+
+	bucket   *tokenBucket
+	inFlight chan struct{}
+}
+
+// New returns a limiter for the given rules.
+func New(rules []Rule) (*Limiter, error) {
+	l := &Limiter{}
+	for _, r := range rules {
+		if r.Service == "" {
+			return nil, fmt.Errorf("service of a rate limit must not be empty")
+		}
+		if r.RequestsPerSecond < 0 || r.Burst < 0 || r.MaxInFlight < 0 {
+			return nil, fmt.Errorf("limits of %q must not be negative", r.Service)
+		}
+		for _, existing := range l.rules {
+			if existing.Service == r.Service {
+				return nil, fmt.Errorf("duplicate rate limit for %q", r.Service)
+			}
+		}
+
+		lr := &rule{Rule: r}
+		if r.RequestsPerSecond > 0 {
+			burst := r.Burst
+			if burst == 0 {
+				burst = int(math.Ceil(r.RequestsPerSecond))
+			}
+			lr.bucket = newTokenBucket(r.RequestsPerSecond, burst, time.Now)
+		}
+		if r.MaxInFlight > 0 {
+			lr.inFlight = make(chan struct{}, r.MaxInFlight)
+		}
+		l.rules = append(l.rules, lr)
+	}
+	return l, nil
+}
+
+func (l *Limiter) ruleFor(method string) *rule {
+	var match *rule
+	for _, r := range l.rules {
+		if !methodMatches(r.Service, method) {
+			continue
+		}
+		if match == nil || len(r.Service) > len(match.Service) {
+			match = r
+		}
+	}
+	return match
+}
+
+func methodMatches(pattern string, method string) bool {
+	// Allow patterns without the leading slash, e.g. "yandex.cloud.compute.*"
+	method = strings.TrimPrefix(method, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(method, prefix)
+	}
+	return method == pattern
+}
+
+// Wait blocks until the call of the method is allowed by the limits and returns a function
+// to be called once the call is finished.
+func (l *Limiter) Wait(ctx context.Context, method string) (func(), error) {
+	r := l.ruleFor(method)
+	if r == nil {
+		return func() {}, nil
+	}
+
+	if r.bucket != nil {
+		delay := r.bucket.reserve()
+		if delay > 0 {
+			log.Printf("[DEBUG] Rate limit of %q delays %s by %s", r.Service, method, delay)
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				r.bucket.cancel()
+				return nil, status.FromContextError(ctx.Err()).Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	if r.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case r.inFlight <- struct{}{}:
+	default:
+		log.Printf("[DEBUG] %s waits for one of %d requests in flight to %q to finish", method, r.MaxInFlight, r.Service)
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case r.inFlight <- struct{}{}:
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-r.inFlight })
+	}, nil
+}
+
+// Interceptor returns a unary client interceptor limiting calls. It is meant to be placed
+// below the retry interceptor, so that every retry attempt is limited as well.
+func (l *Limiter) Interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		done, err := l.Wait(ctx, method)
+		if err != nil {
+			return err
+		}
+		defer done()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// tokenBucket hands out tokens at a fixed rate up to the burst size. Tokens may be reserved
+// ahead of time, in that case the bucket goes negative and callers wait for their turn.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now func() time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now(),
+		now:    now,
+	}
+}
+
+// reserve takes a token and returns how long to wait until it is actually available.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token which has not been used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewValidation(t *testing.T) {
+	_, err := New([]Rule{{RequestsPerSecond: 1}})
+	assert.Error(t, err)
+
+	_, err = New([]Rule{{Service: "*", MaxInFlight: -1}})
+	assert.Error(t, err)
+
+	_, err = New([]Rule{{Service: "*"}, {Service: "*"}})
+	assert.Error(t, err)
+
+	_, err = New([]Rule{{Service: "*", RequestsPerSecond: 0.5}, {Service: "yandex.cloud.compute.*", MaxInFlight: 1}})
+	assert.NoError(t, err)
+}
+
+func TestRuleFor(t *testing.T) {
+	l, err := New([]Rule{
+		{Service: "*", RequestsPerSecond: 100},
+		{Service: "yandex.cloud.compute.*", RequestsPerSecond: 10},
+		{Service: "/yandex.cloud.compute.v1.InstanceService/Create", MaxInFlight: 1},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "*", l.ruleFor("/yandex.cloud.vpc.v1.NetworkService/Get").Service)
+	assert.Equal(t, "yandex.cloud.compute.*", l.ruleFor("/yandex.cloud.compute.v1.DiskService/Get").Service)
+	assert.Equal(t, "/yandex.cloud.compute.v1.InstanceService/Create", l.ruleFor("/yandex.cloud.compute.v1.InstanceService/Create").Service)
+
+	l, err = New([]Rule{{Service: "yandex.cloud.compute.*", RequestsPerSecond: 10}})
+	require.NoError(t, err)
+	assert.Nil(t, l.ruleFor("/yandex.cloud.vpc.v1.NetworkService/Get"))
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTokenBucket(2, 2, func() time.Time { return now })
+
+	assert.Equal(t, time.Duration(0), b.reserve())
+	assert.Equal(t, time.Duration(0), b.reserve())
+	assert.Equal(t, 500*time.Millisecond, b.reserve())
+	assert.Equal(t, time.Second, b.reserve())
+
+	b.cancel()
+	b.cancel()
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), b.reserve())
+	assert.Equal(t, time.Duration(0), b.reserve())
+
+	// The bucket does not accumulate more tokens than the burst.
+	now = now.Add(time.Hour)
+	assert.Equal(t, time.Duration(0), b.reserve())
+	assert.Equal(t, time.Duration(0), b.reserve())
+	assert.Equal(t, 500*time.Millisecond, b.reserve())
+}
+
+func TestInterceptorMaxInFlight(t *testing.T) {
+	l, err := New([]Rule{{Service: "yandex.cloud.compute.*", MaxInFlight: 2}})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return nil
+	}
+
+	interceptor := l.Interceptor()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, interceptor(context.Background(), "/yandex.cloud.compute.v1.DiskService/Get", nil, nil, nil, invoker))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 2, maxInFlight)
+}
+
+func TestInterceptorContextCanceled(t *testing.T) {
+	l, err := New([]Rule{{Service: "*", RequestsPerSecond: 0.001, Burst: 1}})
+	require.NoError(t, err)
+
+	calls := 0
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		calls++
+		return nil
+	}
+
+	interceptor := l.Interceptor()
+	require.NoError(t, interceptor(context.Background(), "/yandex.cloud.vpc.v1.NetworkService/Get", nil, nil, nil, invoker))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = interceptor(ctx, "/yandex.cloud.vpc.v1.NetworkService/Get", nil, nil, nil, invoker)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,119 @@
+package retrypolicy
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	DefaultBackoffBase = 50 * time.Millisecond
+	DefaultBackoffCap  = 1 * time.Minute
+)
+
+// DefaultRule is the rule used when no retry policy is configured: calls failed with
+// codes.Unavailable are retried with exponential backoff.
+func DefaultRule(maxRetries int) Rule {
+	return Rule{
+		Codes:       []codes.Code{codes.Unavailable},
+		MaxRetries:  maxRetries,
+		BackoffBase: DefaultBackoffBase,
+		BackoffCap:  DefaultBackoffCap,
+	}
+}
+
+// RuleConfig is a rule as written in the provider configuration. Empty strings and nil values are left unset.
+type RuleConfig struct {
+	Method                     string
+	Codes                      []string
+	MaxRetries                 *int
+	BaseBackoff                string
+	MaxBackoff                 string
+	MaxElapsedTime             string
+	RetryConflictingOperations *bool
+}
+
+// NewPolicy builds a policy from the provider configuration. Unset fields of the default
+// rule are taken from DefaultRule(maxRetries), unset fields of the overrides are inherited
+// from the default rule.
+func NewPolicy(maxRetries int, defaultRule RuleConfig, overrides []RuleConfig) (*Policy, error) {
+	def, err := parseRule(defaultRule)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := DefaultRule(maxRetries)
+	if def.Codes == nil {
+		def.Codes = fallback.Codes
+	}
+	if !def.MaxRetriesSet {
+		def.MaxRetries = fallback.MaxRetries
+	}
+	if def.BackoffBase == 0 {
+		def.BackoffBase = fallback.BackoffBase
+	}
+	if def.BackoffCap == 0 {
+		def.BackoffCap = fallback.BackoffCap
+	}
+
+	p := &Policy{Default: def}
+	for _, o := range overrides {
+		if o.Method == "" {
+			return nil, fmt.Errorf("method of a retry policy override must not be empty")
+		}
+		rule, err := parseRule(o)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry policy override for %q: %w", o.Method, err)
+		}
+		p.Overrides = append(p.Overrides, rule)
+	}
+	return p, nil
+}
+
+func parseRule(cfg RuleConfig) (Rule, error) {
+	rule := Rule{Method: cfg.Method}
+	if cfg.MaxRetries != nil {
+		if *cfg.MaxRetries < 0 {
+			return rule, fmt.Errorf("max_retries must not be negative, got %d", *cfg.MaxRetries)
+		}
+		rule.MaxRetries, rule.MaxRetriesSet = *cfg.MaxRetries, true
+	}
+	if cfg.RetryConflictingOperations != nil {
+		rule.RetryConflictingOperations, rule.RetryConflictingOperationsSet = *cfg.RetryConflictingOperations, true
+	}
+
+	if len(cfg.Codes) > 0 {
+		parsed, err := ParseCodes(cfg.Codes)
+		if err != nil {
+			return rule, err
+		}
+		rule.Codes = parsed
+	}
+
+	var err error
+	if rule.BackoffBase, err = parseDuration("base_backoff", cfg.BaseBackoff); err != nil {
+		return rule, err
+	}
+	if rule.BackoffCap, err = parseDuration("max_backoff", cfg.MaxBackoff); err != nil {
+		return rule, err
+	}
+	if rule.MaxElapsedTime, err = parseDuration("max_elapsed_time", cfg.MaxElapsedTime); err != nil {
+		return rule, err
+	}
+	return rule, nil
+}
+
+func parseDuration(name string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got %q", name, value)
+	}
+	return d, nil
+}
@@ -0,0 +1,225 @@
+// Package retrypolicy implements a configurable retry of Yandex Cloud API calls.
+//
+// A Policy consists of the default Rule and per-method overrides. The rule matching the called
+// method decides which status codes are retried, how long to wait between attempts and when to
+// give up. Every retry is logged together with the rule that fired.
+package retrypolicy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AttemptHeader is the metadata key carrying the retry attempt number.
+const AttemptHeader = "x-retry-attempt"
+
+const defaultRuleName = "default"
+
+var conflictingOperationRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`conflicting operation "(.+)" detected`),
+	regexp.MustCompile(`Conflicting operation (.+) detected`),
+}
+
+// Rule describes when and how a call is retried.
+type Rule struct {
+	// Method is a full gRPC method name, e.g. "/yandex.cloud.compute.v1.InstanceService/Create",
+	// or a prefix followed by "*", e.g. "/yandex.cloud.compute.*". It is ignored for the default rule.
+	Method string
+
+	// Codes are the status codes to retry on.
+	Codes []codes.Code
+	// MaxRetries is the maximum number of retries, zero means no retries.
+	MaxRetries int
+	// MaxRetriesSet makes an override use its MaxRetries even if it is zero,
+	// which is otherwise taken from the default rule.
+	MaxRetriesSet bool
+	// BackoffBase and BackoffCap bound the exponential backoff with full jitter between attempts.
+	BackoffBase time.Duration
+	BackoffCap  time.Duration
+	// MaxElapsedTime limits the overall time spent on a call including all the retries, zero means no limit.
+	MaxElapsedTime time.Duration
+	// RetryConflictingOperations makes the calls rejected because of a conflicting operation to be retried.
+	RetryConflictingOperations bool
+	// RetryConflictingOperationsSet makes an override use its RetryConflictingOperations even if it
+	// is false, which is otherwise taken from the default rule.
+	RetryConflictingOperationsSet bool
+}
+
+// Policy is a default rule with per-method overrides.
+type Policy struct {
+	Default   Rule
+	Overrides []Rule
+}
+
+// RuleFor returns the rule for the given method and its name to be used in logs.
+// Override fields left unset are taken from the default rule, a zero MaxRetries and a false
+// RetryConflictingOperations are unset unless marked as set. The longest matching override wins.
+func (p *Policy) RuleFor(method string) (Rule, string) {
+	var match *Rule
+	for i := range p.Overrides {
+		o := &p.Overrides[i]
+		if !methodMatches(o.Method, method) {
+			continue
+		}
+		if match == nil || len(o.Method) > len(match.Method) {
+			match = o
+		}
+	}
+	if match == nil {
+		return p.Default, defaultRuleName
+	}
+
+	rule := *match
+	if rule.Codes == nil {
+		rule.Codes = p.Default.Codes
+	}
+	if rule.MaxRetries == 0 && !rule.MaxRetriesSet {
+		rule.MaxRetries = p.Default.MaxRetries
+	}
+	if rule.BackoffBase == 0 {
+		rule.BackoffBase = p.Default.BackoffBase
+	}
+	if rule.BackoffCap == 0 {
+		rule.BackoffCap = p.Default.BackoffCap
+	}
+	if rule.MaxElapsedTime == 0 {
+		rule.MaxElapsedTime = p.Default.MaxElapsedTime
+	}
+	if !rule.RetryConflictingOperations && !rule.RetryConflictingOperationsSet {
+		rule.RetryConflictingOperations = p.Default.RetryConflictingOperations
+	}
+	return rule, rule.Method
+}
+
+func methodMatches(pattern string, method string) bool {
+	// Allow patterns without the leading slash, e.g. "yandex.cloud.compute.*"
+	method = strings.TrimPrefix(method, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(method, prefix)
+	}
+	return method == pattern
+}
+
+// reason returns why the error is retryable under the rule, or an empty string if it is not.
+func (r *Rule) reason(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, code := range r.Codes {
+		if st.Code() == code {
+			return "code " + CodeName(code)
+		}
+	}
+	if r.RetryConflictingOperations {
+		for _, re := range conflictingOperationRegexes {
+			if re.MatchString(st.Message()) {
+				return "conflicting operation"
+			}
+		}
+	}
+	return ""
+}
+
+// Backoff returns the exponential backoff with full jitter for the given zero-based attempt.
+func (r *Rule) Backoff(attempt int) time.Duration {
+	to := float64(r.BackoffBase) * math.Pow(2, float64(attempt))
+	// Exponential time can be really big, compare it to the cap before converting to time.Duration.
+	if to > float64(r.BackoffCap) {
+		to = float64(r.BackoffCap)
+	}
+	return time.Duration(to * rand.Float64())
+}
+
+// Interceptor returns a unary client interceptor retrying calls according to the policy.
+func Interceptor(p *Policy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		rule, ruleName := p.RuleFor(method)
+		start := time.Now()
+
+		for attempt := 0; ; attempt++ {
+			callCtx := ctx
+			if attempt > 0 {
+				callCtx = metadata.AppendToOutgoingContext(ctx, AttemptHeader, strconv.Itoa(attempt))
+			}
+
+			err := invoker(callCtx, method, req, reply, cc, opts...)
+			if err == nil {
+				return nil
+			}
+
+			reason := rule.reason(err)
+			if reason == "" || attempt >= rule.MaxRetries {
+				return err
+			}
+
+			backoff := rule.Backoff(attempt)
+			if rule.MaxElapsedTime > 0 && time.Since(start)+backoff > rule.MaxElapsedTime {
+				log.Printf("[DEBUG] Not retrying %s: max elapsed time %s of retry rule %q exceeded", method, rule.MaxElapsedTime, ruleName)
+				return err
+			}
+
+			log.Printf("[DEBUG] Retrying %s in %s, attempt %d of %d: retry rule %q fired on %s", method, backoff, attempt+1, rule.MaxRetries, ruleName, reason)
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return status.FromContextError(ctx.Err()).Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// CodeName returns the name of the code in the upper snake case, e.g. "RESOURCE_EXHAUSTED".
+func CodeName(code codes.Code) string {
+	var sb strings.Builder
+	prev := rune(0)
+	for _, r := range code.String() {
+		if unicode.IsUpper(r) && unicode.IsLower(prev) {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(r)
+		prev = r
+	}
+	return strings.ToUpper(sb.String())
+}
+
+// ParseCode parses a status code name, both "RESOURCE_EXHAUSTED" and "ResourceExhausted" forms are accepted.
+func ParseCode(name string) (codes.Code, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if strings.ToLower(c.String()) == normalized {
+			return c, nil
+		}
+	}
+	return codes.Unknown, fmt.Errorf("unknown gRPC status code %q", name)
+}
+
+// ParseCodes parses a list of status code names.
+func ParseCodes(names []string) ([]codes.Code, error) {
+	result := make([]codes.Code, 0, len(names))
+	for _, name := range names {
+		code, err := ParseCode(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, code)
+	}
+	return result, nil
+}
@@ -0,0 +1,321 @@
+package retrypolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func testPolicy() *Policy {
+	return &Policy{
+		Default: Rule{
+			Codes:       []codes.Code{codes.Unavailable},
+			MaxRetries:  3,
+			BackoffBase: time.Millisecond,
+			BackoffCap:  2 * time.Millisecond,
+		},
+		Overrides: []Rule{
+			{
+				Method: "/yandex.cloud.compute.*",
+				Codes:  []codes.Code{codes.ResourceExhausted},
+			},
+			{
+				Method:     "/yandex.cloud.compute.v1.InstanceService/Create",
+				MaxRetries: 1,
+			},
+			{
+				Method:                     "yandex.cloud.vpc.v1.NetworkService/Update",
+				RetryConflictingOperations: true,
+			},
+		},
+	}
+}
+
+func TestRuleFor(t *testing.T) {
+	p := testPolicy()
+
+	rule, name := p.RuleFor("/yandex.cloud.iam.v1.ServiceAccountService/Get")
+	assert.Equal(t, "default", name)
+	assert.Equal(t, p.Default, rule)
+
+	rule, name = p.RuleFor("/yandex.cloud.compute.v1.DiskService/Create")
+	assert.Equal(t, "/yandex.cloud.compute.*", name)
+	assert.Equal(t, []codes.Code{codes.ResourceExhausted}, rule.Codes)
+	assert.Equal(t, 3, rule.MaxRetries)
+	assert.Equal(t, time.Millisecond, rule.BackoffBase)
+
+	rule, name = p.RuleFor("/yandex.cloud.compute.v1.InstanceService/Create")
+	assert.Equal(t, "/yandex.cloud.compute.v1.InstanceService/Create", name)
+	assert.Equal(t, []codes.Code{codes.Unavailable}, rule.Codes)
+	assert.Equal(t, 1, rule.MaxRetries)
+
+	rule, name = p.RuleFor("/yandex.cloud.vpc.v1.NetworkService/Update")
+	assert.Equal(t, "yandex.cloud.vpc.v1.NetworkService/Update", name)
+	assert.True(t, rule.RetryConflictingOperations)
+}
+
+func TestParseCode(t *testing.T) {
+	for name, expected := range map[string]codes.Code{
+		"UNAVAILABLE":        codes.Unavailable,
+		"RESOURCE_EXHAUSTED": codes.ResourceExhausted,
+		"ResourceExhausted":  codes.ResourceExhausted,
+		"deadline_exceeded":  codes.DeadlineExceeded,
+		"OK":                 codes.OK,
+	} {
+		code, err := ParseCode(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, code, name)
+		assert.Equal(t, expected, mustParseCode(t, CodeName(code)))
+	}
+
+	assert.Equal(t, "RESOURCE_EXHAUSTED", CodeName(codes.ResourceExhausted))
+	assert.Equal(t, "OK", CodeName(codes.OK))
+
+	_, err := ParseCode("NOT_A_CODE")
+	assert.Error(t, err)
+}
+
+func mustParseCode(t *testing.T, name string) codes.Code {
+	code, err := ParseCode(name)
+	require.NoError(t, err)
+	return code
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	rule := Rule{BackoffBase: time.Second, BackoffCap: 5 * time.Second}
+	for attempt := 0; attempt < 100; attempt++ {
+		backoff := rule.Backoff(attempt)
+		assert.GreaterOrEqual(t, backoff, time.Duration(0))
+		assert.LessOrEqual(t, backoff, 5*time.Second)
+	}
+}
+
+type fakeInvoker struct {
+	errs     []error
+	calls    int
+	attempts []string
+}
+
+func (f *fakeInvoker) invoke(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	f.attempts = append(f.attempts, md.Get(AttemptHeader)...)
+	f.calls++
+	if f.calls > len(f.errs) {
+		return nil
+	}
+	return f.errs[f.calls-1]
+}
+
+func TestInterceptor(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	exhausted := status.Error(codes.ResourceExhausted, "quota")
+	conflict := status.Error(codes.FailedPrecondition, `conflicting operation "op1" detected`)
+
+	tests := []struct {
+		name          string
+		method        string
+		errs          []error
+		expectedCalls int
+		expectedErr   error
+	}{
+		{
+			name:          "succeeds after retries",
+			method:        "/yandex.cloud.iam.v1.ServiceAccountService/Get",
+			errs:          []error{unavailable, unavailable},
+			expectedCalls: 3,
+		},
+		{
+			name:          "gives up after max retries",
+			method:        "/yandex.cloud.iam.v1.ServiceAccountService/Get",
+			errs:          []error{unavailable, unavailable, unavailable, unavailable, unavailable},
+			expectedCalls: 4,
+			expectedErr:   unavailable,
+		},
+		{
+			name:          "code not retried by rule",
+			method:        "/yandex.cloud.iam.v1.ServiceAccountService/Get",
+			errs:          []error{exhausted},
+			expectedCalls: 1,
+			expectedErr:   exhausted,
+		},
+		{
+			name:          "override codes",
+			method:        "/yandex.cloud.compute.v1.DiskService/Create",
+			errs:          []error{exhausted},
+			expectedCalls: 2,
+		},
+		{
+			name:          "override max retries",
+			method:        "/yandex.cloud.compute.v1.InstanceService/Create",
+			errs:          []error{unavailable, unavailable},
+			expectedCalls: 2,
+			expectedErr:   unavailable,
+		},
+		{
+			name:          "conflicting operation",
+			method:        "/yandex.cloud.vpc.v1.NetworkService/Update",
+			errs:          []error{conflict},
+			expectedCalls: 2,
+		},
+		{
+			name:          "conflicting operation not retried by default",
+			method:        "/yandex.cloud.vpc.v1.NetworkService/Create",
+			errs:          []error{conflict},
+			expectedCalls: 1,
+			expectedErr:   conflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoker := &fakeInvoker{errs: tt.errs}
+			err := Interceptor(testPolicy())(context.Background(), tt.method, nil, nil, nil, invoker.invoke)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedCalls, invoker.calls)
+			assert.Len(t, invoker.attempts, tt.expectedCalls-1)
+		})
+	}
+}
+
+func TestInterceptorMaxElapsedTime(t *testing.T) {
+	p := &Policy{
+		Default: Rule{
+			Codes:          []codes.Code{codes.Unavailable},
+			MaxRetries:     100,
+			BackoffBase:    10 * time.Millisecond,
+			BackoffCap:     10 * time.Millisecond,
+			MaxElapsedTime: 50 * time.Millisecond,
+		},
+	}
+
+	errs := make([]error, 100)
+	for i := range errs {
+		errs[i] = status.Error(codes.Unavailable, "unavailable")
+	}
+	invoker := &fakeInvoker{errs: errs}
+
+	err := Interceptor(p)(context.Background(), "/yandex.cloud.iam.v1.ServiceAccountService/Get", nil, nil, nil, invoker.invoke)
+	assert.Error(t, err)
+	assert.Less(t, invoker.calls, 100)
+}
+
+func TestInterceptorContextCanceled(t *testing.T) {
+	p := &Policy{
+		Default: Rule{
+			Codes:       []codes.Code{codes.Unavailable},
+			MaxRetries:  10,
+			BackoffBase: time.Hour,
+			BackoffCap:  time.Hour,
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	invoker := &fakeInvoker{errs: []error{status.Error(codes.Unavailable, "unavailable")}}
+	err := Interceptor(p)(ctx, "/yandex.cloud.iam.v1.ServiceAccountService/Get", nil, nil, nil, invoker.invoke)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, 1, invoker.calls)
+}
+
+func TestNewPolicy(t *testing.T) {
+	p, err := NewPolicy(5, RuleConfig{
+		Codes:          []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"},
+		MaxElapsedTime: "10m",
+	}, []RuleConfig{
+		{
+			Method:      "/yandex.cloud.compute.*",
+			MaxRetries:  intPtr(10),
+			BaseBackoff: "1s",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, Rule{
+		Codes:          []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+		MaxRetries:     5,
+		BackoffBase:    DefaultBackoffBase,
+		BackoffCap:     DefaultBackoffCap,
+		MaxElapsedTime: 10 * time.Minute,
+	}, p.Default)
+
+	rule, _ := p.RuleFor("/yandex.cloud.compute.v1.InstanceService/Create")
+	assert.Equal(t, Rule{
+		Method:         "/yandex.cloud.compute.*",
+		Codes:          []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+		MaxRetries:     10,
+		MaxRetriesSet:  true,
+		BackoffBase:    time.Second,
+		BackoffCap:     DefaultBackoffCap,
+		MaxElapsedTime: 10 * time.Minute,
+	}, rule)
+
+	_, err = NewPolicy(5, RuleConfig{Codes: []string{"UNKNOWN_CODE"}}, nil)
+	assert.Error(t, err)
+	_, err = NewPolicy(5, RuleConfig{BaseBackoff: "soon"}, nil)
+	assert.Error(t, err)
+	_, err = NewPolicy(5, RuleConfig{}, []RuleConfig{{MaxRetries: intPtr(1)}})
+	assert.Error(t, err)
+}
+
+func TestRuleFor_explicitZeroOverrides(t *testing.T) {
+	p := testPolicy()
+	p.Default.RetryConflictingOperations = true
+	p.Overrides = append(p.Overrides, Rule{
+		Method:                        "/yandex.cloud.compute.v1.InstanceService/Delete",
+		MaxRetriesSet:                 true,
+		RetryConflictingOperationsSet: true,
+	})
+
+	rule, _ := p.RuleFor("/yandex.cloud.compute.v1.InstanceService/Delete")
+	assert.Equal(t, 0, rule.MaxRetries, "an explicit zero must disable the retries")
+	assert.False(t, rule.RetryConflictingOperations, "an explicit false must not be taken from the default rule")
+
+	rule, _ = p.RuleFor("/yandex.cloud.compute.v1.DiskService/Create")
+	assert.Equal(t, 3, rule.MaxRetries, "an unset zero must be taken from the default rule")
+	assert.True(t, rule.RetryConflictingOperations, "an unset false must be taken from the default rule")
+}
+
+func TestNewPolicy_explicitZeroOverrides(t *testing.T) {
+	p, err := NewPolicy(5, RuleConfig{RetryConflictingOperations: boolPtr(true)}, []RuleConfig{
+		{
+			Method:                     "/yandex.cloud.compute.v1.InstanceService/Delete",
+			MaxRetries:                 intPtr(0),
+			RetryConflictingOperations: boolPtr(false),
+		},
+		{
+			Method: "/yandex.cloud.compute.*",
+		},
+	})
+	require.NoError(t, err)
+
+	rule, _ := p.RuleFor("/yandex.cloud.compute.v1.InstanceService/Delete")
+	assert.Equal(t, 0, rule.MaxRetries)
+	assert.False(t, rule.RetryConflictingOperations)
+
+	invoker := &fakeInvoker{errs: []error{status.Error(codes.Unavailable, "unavailable")}}
+	err = Interceptor(p)(context.Background(), "/yandex.cloud.compute.v1.InstanceService/Delete", nil, nil, nil, invoker.invoke)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, invoker.calls, "the method must not be retried")
+
+	rule, _ = p.RuleFor("/yandex.cloud.compute.v1.DiskService/Create")
+	assert.Equal(t, 5, rule.MaxRetries)
+	assert.True(t, rule.RetryConflictingOperations)
+
+	_, err = NewPolicy(5, RuleConfig{}, []RuleConfig{{Method: "/yandex.*", MaxRetries: intPtr(-1)}})
+	assert.Error(t, err)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,168 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+)
+
+// UnaryClientInterceptor returns an interceptor starting a client span for every API call.
+//
+// It also traces the waits of long-running operations: a wait span starts when a call returns
+// an operation which is not done and ends when polling the operation reports it done. This way
+// every op.Wait is traced without instrumenting its callers. The waits of operations which are
+// not polled to completion end when the context of the call returning the operation is done, or
+// when more than maxOperationWaits operations are waited. The interceptor must be placed on top
+// of the chain, so that the call spans include retries.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	waits := &operationWaits{spans: make(map[string]*operationWait)}
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service, name := splitMethod(method)
+		callCtx, span := startSpan(ctx, strings.TrimPrefix(method, "/"), trace.SpanKindClient,
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", name),
+		)
+		if !span.IsRecording() {
+			span.End()
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		folderID := folderIDOf(req)
+		if folderID != "" {
+			span.SetAttributes(AttrFolderID.String(folderID))
+		}
+		if get, ok := req.(*operation.GetOperationRequest); ok {
+			span.SetAttributes(AttrOperationID.String(get.GetOperationId()))
+		}
+
+		err := invoker(callCtx, method, req, reply, cc, opts...)
+
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(status.Code(err))))
+		if op, ok := reply.(*operation.Operation); ok && err == nil && op.GetId() != "" {
+			span.SetAttributes(AttrOperationID.String(op.GetId()))
+			waits.observe(ctx, folderID, op)
+		}
+		EndSpan(span, err)
+		return err
+	}
+}
+
+// maxOperationWaits bounds the number of operation waits traced at once. The oldest wait
+// ends if a new one would exceed it.
+const maxOperationWaits = 1000
+
+type operationWait struct {
+	span trace.Span
+	seq  uint64
+	stop func() bool
+}
+
+type operationWaits struct {
+	mu    sync.Mutex
+	seq   uint64
+	spans map[string]*operationWait
+}
+
+func (w *operationWaits) observe(ctx context.Context, folderID string, op *operation.Operation) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wait, waiting := w.spans[op.GetId()]
+	if !op.GetDone() {
+		if waiting {
+			return
+		}
+		if len(w.spans) >= maxOperationWaits {
+			w.endOldest()
+		}
+		attrs := []attribute.KeyValue{
+			AttrOperationID.String(op.GetId()),
+			attribute.String("yandex.operation.description", op.GetDescription()),
+		}
+		if folderID != "" {
+			attrs = append(attrs, AttrFolderID.String(folderID))
+		}
+		w.seq++
+		wait = &operationWait{seq: w.seq}
+		_, wait.span = StartSpan(ctx, "operation wait", attrs...)
+		w.spans[op.GetId()] = wait
+
+		id := op.GetId()
+		wait.stop = context.AfterFunc(ctx, func() {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			if w.spans[id] == wait {
+				w.end(id, "operation was not waited to completion")
+			}
+		})
+		return
+	}
+
+	if !waiting {
+		return
+	}
+	wait.stop()
+	delete(w.spans, op.GetId())
+	if opErr := op.GetError(); opErr != nil {
+		wait.span.SetStatus(codes.Error, opErr.GetMessage())
+		wait.span.SetAttributes(attribute.Int("yandex.operation.error_code", int(opErr.GetCode())))
+	}
+	wait.span.End()
+}
+
+// endOldest ends the wait of the operation observed first.
+func (w *operationWaits) endOldest() {
+	oldest := ""
+	for id, wait := range w.spans {
+		if oldest == "" || wait.seq < w.spans[oldest].seq {
+			oldest = id
+		}
+	}
+	w.spans[oldest].stop()
+	w.end(oldest, "too many operations are waited")
+}
+
+// end ends the wait of the operation which is not known to be done.
+func (w *operationWaits) end(id, reason string) {
+	wait := w.spans[id]
+	delete(w.spans, id)
+	wait.span.SetAttributes(attribute.String("yandex.operation.wait_abandoned", reason))
+	wait.span.End()
+}
+
+func splitMethod(method string) (string, string) {
+	method = strings.TrimPrefix(method, "/")
+	if i := strings.LastIndexByte(method, '/'); i >= 0 {
+		return method[:i], method[i+1:]
+	}
+	return "", method
+}
+
+// folderIDOf returns the folder_id field of the request, if there is one.
+func folderIDOf(req interface{}) string {
+	m, ok := req.(proto.Message)
+	if !ok || m == nil {
+		return ""
+	}
+	msg := m.ProtoReflect()
+	if !msg.IsValid() {
+		return ""
+	}
+	field := msg.Descriptor().Fields().ByName("folder_id")
+	if field == nil || field.Kind() != protoreflect.StringKind || field.IsList() {
+		return ""
+	}
+	return msg.Get(field).String()
+}
@@ -0,0 +1,137 @@
+// Package tracing instruments the provider with OpenTelemetry spans.
+//
+// Tracing is off by default. It is enabled by the TF_YC_OTEL_TRACES_EXPORTER environment variable:
+//   - "otlp" exports spans to an OTLP gRPC endpoint configured by the standard OTEL_EXPORTER_OTLP_* variables;
+//   - "file" writes spans as JSON to the file set by TF_YC_OTEL_TRACES_FILE.
+//
+// Spans are created for resource and data source actions, API calls and long-running operation waits.
+// They are tagged with the resource type, the operation ID and the folder ID where known.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	EnvTracesExporter = "TF_YC_OTEL_TRACES_EXPORTER"
+	EnvTracesFile     = "TF_YC_OTEL_TRACES_FILE"
+
+	instrumentationName = "github.com/yandex-cloud/terraform-provider-yandex"
+	serviceName         = "terraform-provider-yandex"
+)
+
+// Attributes the provider spans are tagged with.
+const (
+	AttrResourceType = attribute.Key("yandex.resource.type")
+	AttrResourceID   = attribute.Key("yandex.resource.id")
+	AttrOperationID  = attribute.Key("yandex.operation.id")
+	AttrFolderID     = attribute.Key("yandex.folder.id")
+)
+
+type resourceTypeKey struct{}
+
+// Init sets up the global tracer provider according to the environment variables. The returned
+// function flushes the spans not exported yet and must be called before the process exits.
+func Init(ctx context.Context, version string) (func(context.Context) error, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+
+	switch kind := strings.ToLower(os.Getenv(EnvTracesExporter)); kind {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracegrpc.New(ctx)
+	case "file":
+		path := os.Getenv(EnvTracesFile)
+		if path == "" {
+			return nil, fmt.Errorf("%s must be set for the %q traces exporter", EnvTracesFile, kind)
+		}
+		f, openErr := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if openErr != nil {
+			return nil, fmt.Errorf("failed to open traces file: %w", openErr)
+		}
+		closer = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("unknown traces exporter %q in %s, expected \"otlp\" or \"file\"", kind, EnvTracesExporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create traces exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			_ = closer.Close()
+		}
+		return err
+	}, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// ContextWithResourceType returns a context the spans started from are tagged with the resource type.
+func ContextWithResourceType(ctx context.Context, resourceType string) context.Context {
+	return context.WithValue(ctx, resourceTypeKey{}, resourceType)
+}
+
+// ResourceTypeFromContext returns the resource type set by ContextWithResourceType.
+func ResourceTypeFromContext(ctx context.Context) string {
+	resourceType, _ := ctx.Value(resourceTypeKey{}).(string)
+	return resourceType
+}
+
+// StartSpan starts a span tagged with the resource type from the context.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return startSpan(ctx, name, trace.SpanKindInternal, attrs...)
+}
+
+func startSpan(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if resourceType := ResourceTypeFromContext(ctx); resourceType != "" {
+		attrs = append(attrs, AttrResourceType.String(resourceType))
+	}
+	return tracer().Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// StartResourceSpan starts a span of a resource or data source action, e.g. "yandex_vpc_network create".
+// The returned context carries the resource type for the spans started from it.
+func StartResourceSpan(ctx context.Context, resourceType string, action string) (context.Context, trace.Span) {
+	ctx = ContextWithResourceType(ctx, resourceType)
+	return StartSpan(ctx, resourceType+" "+action)
+}
+
+// EndSpan records the error, if any, and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
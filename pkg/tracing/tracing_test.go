@@ -0,0 +1,206 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
+)
+
+func setupTestTracing(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+	return exporter
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	for _, s := range spans {
+		if s.Name == name {
+			return s
+		}
+	}
+	require.Failf(t, "span not found", "no span named %q in %v", name, spans)
+	return tracetest.SpanStub{}
+}
+
+func TestInterceptorTracesOperationWait(t *testing.T) {
+	exporter := setupTestTracing(t)
+
+	ctx, resourceSpan := StartResourceSpan(context.Background(), "yandex_vpc_network", "create")
+
+	interceptor := UnaryClientInterceptor()
+	respond := func(op *operation.Operation) grpc.UnaryInvoker {
+		return func(_ context.Context, _ string, _, reply interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			proto.Merge(reply.(*operation.Operation), op)
+			return nil
+		}
+	}
+
+	err := interceptor(ctx, "/yandex.cloud.vpc.v1.NetworkService/Create",
+		&vpc.CreateNetworkRequest{FolderId: "folder1", Name: "net"}, &operation.Operation{}, nil,
+		respond(&operation.Operation{Id: "op1", Description: "Create network"}))
+	require.NoError(t, err)
+
+	// The operation is polled until it is done.
+	getReq := &operation.GetOperationRequest{OperationId: "op1"}
+	require.NoError(t, interceptor(ctx, "/yandex.cloud.operation.OperationService/Get", getReq, &operation.Operation{}, nil,
+		respond(&operation.Operation{Id: "op1"})))
+	require.NoError(t, interceptor(ctx, "/yandex.cloud.operation.OperationService/Get", getReq, &operation.Operation{}, nil,
+		respond(&operation.Operation{Id: "op1", Done: true})))
+
+	EndSpan(resourceSpan, nil)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 5)
+
+	create := findSpan(t, spans, "yandex.cloud.vpc.v1.NetworkService/Create")
+	attrs := spanAttributes(create)
+	assert.Equal(t, "yandex_vpc_network", attrs[AttrResourceType].AsString())
+	assert.Equal(t, "folder1", attrs[AttrFolderID].AsString())
+	assert.Equal(t, "op1", attrs[AttrOperationID].AsString())
+	assert.Equal(t, "yandex.cloud.vpc.v1.NetworkService", attrs["rpc.service"].AsString())
+	assert.Equal(t, "Create", attrs["rpc.method"].AsString())
+
+	wait := findSpan(t, spans, "operation wait")
+	attrs = spanAttributes(wait)
+	assert.Equal(t, "yandex_vpc_network", attrs[AttrResourceType].AsString())
+	assert.Equal(t, "folder1", attrs[AttrFolderID].AsString())
+	assert.Equal(t, "op1", attrs[AttrOperationID].AsString())
+	assert.Equal(t, codes.Unset, wait.Status.Code)
+
+	resource := findSpan(t, spans, "yandex_vpc_network create")
+	assert.Equal(t, resource.SpanContext.SpanID(), create.Parent.SpanID())
+	assert.Equal(t, resource.SpanContext.SpanID(), wait.Parent.SpanID())
+}
+
+func TestInterceptorRecordsErrors(t *testing.T) {
+	exporter := setupTestTracing(t)
+
+	interceptor := UnaryClientInterceptor()
+	err := interceptor(context.Background(), "/yandex.cloud.vpc.v1.NetworkService/Get", &vpc.GetNetworkRequest{NetworkId: "net1"}, &vpc.Network{}, nil,
+		func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			return errors.New("failed")
+		})
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "failed", spans[0].Status.Description)
+	_, hasFolder := spanAttributes(spans[0])[AttrFolderID]
+	assert.False(t, hasFolder)
+}
+
+func TestInterceptorTracesFailedOperation(t *testing.T) {
+	exporter := setupTestTracing(t)
+
+	interceptor := UnaryClientInterceptor()
+	ops := []*operation.Operation{
+		{Id: "op1"},
+		{Id: "op1", Done: true, Result: &operation.Operation_Error{Error: statusProto("quota exceeded")}},
+	}
+	for _, op := range ops {
+		op := op
+		require.NoError(t, interceptor(context.Background(), "/yandex.cloud.operation.OperationService/Get",
+			&operation.GetOperationRequest{OperationId: "op1"}, &operation.Operation{}, nil,
+			func(_ context.Context, _ string, _, reply interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				proto.Merge(reply.(*operation.Operation), op)
+				return nil
+			}))
+	}
+
+	wait := findSpan(t, exporter.GetSpans(), "operation wait")
+	assert.Equal(t, codes.Error, wait.Status.Code)
+	assert.Equal(t, "quota exceeded", wait.Status.Description)
+}
+
+func TestInterceptorEndsAbandonedOperationWaits(t *testing.T) {
+	exporter := setupTestTracing(t)
+
+	interceptor := UnaryClientInterceptor()
+	create := func(ctx context.Context, id string) {
+		require.NoError(t, interceptor(ctx, "/yandex.cloud.vpc.v1.NetworkService/Create",
+			&vpc.CreateNetworkRequest{FolderId: "folder1"}, &operation.Operation{}, nil,
+			func(_ context.Context, _ string, _, reply interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				proto.Merge(reply.(*operation.Operation), &operation.Operation{Id: id})
+				return nil
+			}))
+	}
+	waitSpans := func() (spans tracetest.SpanStubs) {
+		for _, s := range exporter.GetSpans() {
+			if s.Name == "operation wait" {
+				spans = append(spans, s)
+			}
+		}
+		return spans
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	create(ctx, "op1")
+	assert.Empty(t, waitSpans())
+
+	cancel()
+	require.Eventually(t, func() bool { return len(waitSpans()) == 1 }, time.Second, time.Millisecond,
+		"the wait must end with the context of the call")
+	assert.Equal(t, "operation was not waited to completion",
+		spanAttributes(waitSpans()[0])["yandex.operation.wait_abandoned"].AsString())
+
+	for i := 0; i <= maxOperationWaits; i++ {
+		create(context.Background(), fmt.Sprintf("op-%d", i))
+	}
+	spans := waitSpans()
+	require.Len(t, spans, 2, "the oldest wait must end once there are too many")
+	assert.Equal(t, "op-0", spanAttributes(spans[1])[AttrOperationID].AsString())
+}
+
+func statusProto(message string) *rpcstatus.Status {
+	return &rpcstatus.Status{Code: int32(grpccodes.ResourceExhausted), Message: message}
+}
+
+func TestInitDisabledByDefault(t *testing.T) {
+	t.Setenv(EnvTracesExporter, "")
+
+	shutdown, err := Init(context.Background(), "test")
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	t.Setenv(EnvTracesExporter, "file")
+	t.Setenv(EnvTracesFile, "")
+	_, err = Init(context.Background(), "test")
+	assert.Error(t, err)
+
+	t.Setenv(EnvTracesExporter, "zipkin")
+	_, err = Init(context.Background(), "test")
+	assert.Error(t, err)
+}
@@ -0,0 +1,206 @@
+// Package workloadidentity implements authentication with workload identity federation.
+//
+// An external OIDC token, e.g. the one issued to a GitLab or GitHub CI job, is exchanged
+// for an IAM token of a service account through the token exchange endpoint. The IAM token
+// is cached and exchanged again shortly before it expires, the external token is read anew
+// on every exchange, so rotated token files are picked up.
+package workloadidentity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	DefaultEndpoint = "https://auth.yandex.cloud/oauth/token"
+
+	// GitHub Actions provide a URL to request an OIDC token for the job with these variables.
+	envGitHubTokenRequestURL   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	envGitHubTokenRequestToken = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+
+	// refreshBefore is how long before the expiration the IAM token is exchanged again.
+	refreshBefore = 5 * time.Minute
+)
+
+// Config describes how to obtain the external token and which service account to exchange it for.
+type Config struct {
+	// ServiceAccountID is the service account the federated credentials are bound to.
+	ServiceAccountID string
+	// Audience is the audience of the OIDC token requested from GitHub Actions
+	// when neither TokenFile nor TokenEnv is set.
+	Audience string
+	// TokenFile is a path to a file with the external token.
+	TokenFile string
+	// TokenEnv is an environment variable with the external token.
+	TokenEnv string
+	// Endpoint is the token exchange endpoint, DefaultEndpoint if empty.
+	Endpoint string
+}
+
+// Credentials are ycsdk.NonExchangeableCredentials backed by the token exchange.
+type Credentials struct {
+	config Config
+	client *http.Client
+	now    func() time.Time
+
+	mu    sync.Mutex
+	token *iam.CreateIamTokenResponse
+}
+
+// NewCredentials validates the config and returns the credentials.
+func NewCredentials(config Config) (*Credentials, error) {
+	if config.ServiceAccountID == "" {
+		return nil, fmt.Errorf("workload identity service_account_id must be specified")
+	}
+	if config.TokenFile != "" && config.TokenEnv != "" {
+		return nil, fmt.Errorf("only one of workload identity token_file and token_env may be specified")
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = DefaultEndpoint
+	}
+	return &Credentials{
+		config: config,
+		client: &http.Client{Timeout: time.Minute},
+		now:    time.Now,
+	}, nil
+}
+
+// YandexCloudAPICredentials marks the credentials as ycsdk.Credentials.
+func (c *Credentials) YandexCloudAPICredentials() {}
+
+// IAMToken returns the cached IAM token, or exchanges the external token for a new one
+// if the cached token is about to expire.
+func (c *Credentials) IAMToken(ctx context.Context) (*iam.CreateIamTokenResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != nil && c.now().Add(refreshBefore).Before(c.token.GetExpiresAt().AsTime()) {
+		return c.token, nil
+	}
+
+	subjectToken, err := c.subjectToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := c.exchange(ctx, subjectToken)
+	if err != nil {
+		return nil, err
+	}
+	c.token = token
+	return token, nil
+}
+
+func (c *Credentials) subjectToken(ctx context.Context) (string, error) {
+	switch {
+	case c.config.TokenFile != "":
+		b, err := os.ReadFile(c.config.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read workload identity token file: %w", err)
+		}
+		return nonEmptyToken(string(b), "file "+c.config.TokenFile)
+	case c.config.TokenEnv != "":
+		return nonEmptyToken(os.Getenv(c.config.TokenEnv), "environment variable "+c.config.TokenEnv)
+	case os.Getenv(envGitHubTokenRequestURL) != "":
+		return c.gitHubToken(ctx)
+	}
+	return "", fmt.Errorf("workload identity token_file or token_env must be specified outside of GitHub Actions")
+}
+
+func nonEmptyToken(token string, source string) (string, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("workload identity token in %s is empty", source)
+	}
+	return token, nil
+}
+
+// gitHubToken requests the OIDC token of the GitHub Actions job.
+func (c *Credentials) gitHubToken(ctx context.Context) (string, error) {
+	u, err := url.Parse(os.Getenv(envGitHubTokenRequestURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", envGitHubTokenRequestURL, err)
+	}
+	if c.config.Audience != "" {
+		q := u.Query()
+		q.Set("audience", c.config.Audience)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+os.Getenv(envGitHubTokenRequestToken))
+
+	var resp struct {
+		Value string `json:"value"`
+	}
+	if err := c.do(req, &resp); err != nil {
+		return "", fmt.Errorf("failed to request GitHub Actions OIDC token: %w", err)
+	}
+	return nonEmptyToken(resp.Value, "GitHub Actions response")
+}
+
+// exchange exchanges the external token for an IAM token as described in RFC 8693.
+func (c *Credentials) exchange(ctx context.Context, subjectToken string) (*iam.CreateIamTokenResponse, error) {
+	form := url.Values{
+		"grant_type":           {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"requested_token_type": {"urn:ietf:params:oauth:token-type:access_token"},
+		"audience":             {c.config.ServiceAccountID},
+		"subject_token":        {subjectToken},
+		"subject_token_type":   {"urn:ietf:params:oauth:token-type:id_token"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	requestedAt := c.now()
+	if err := c.do(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to exchange workload identity token for service account %s: %w", c.config.ServiceAccountID, err)
+	}
+	if resp.AccessToken == "" {
+		return nil, fmt.Errorf("token exchange response has no access_token")
+	}
+
+	return &iam.CreateIamTokenResponse{
+		IamToken:  resp.AccessToken,
+		ExpiresAt: timestamppb.New(requestedAt.Add(time.Duration(resp.ExpiresIn) * time.Second)),
+	}, nil
+}
+
+func (c *Credentials) do(req *http.Request, result interface{}) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
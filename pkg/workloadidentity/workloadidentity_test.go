@@ -0,0 +1,160 @@
+package workloadidentity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTokenExchange struct {
+	exchanges     int
+	subjectTokens []string
+	audiences     []string
+}
+
+func (f *fakeTokenExchange) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:token-exchange" {
+		http.Error(w, `{"error":"unsupported_grant_type"}`, http.StatusBadRequest)
+		return
+	}
+	f.exchanges++
+	f.subjectTokens = append(f.subjectTokens, r.Form.Get("subject_token"))
+	f.audiences = append(f.audiences, r.Form.Get("audience"))
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token":      fmt.Sprintf("t1.iam-token-%d", f.exchanges),
+		"issued_token_type": "urn:ietf:params:oauth:token-type:access_token",
+		"token_type":        "Bearer",
+		"expires_in":        3600,
+	})
+}
+
+func TestIAMTokenExchangeAndRefresh(t *testing.T) {
+	exchange := &fakeTokenExchange{}
+	server := httptest.NewServer(exchange)
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("jwt-1\n"), 0600))
+
+	creds, err := NewCredentials(Config{
+		ServiceAccountID: "sa1",
+		TokenFile:        tokenFile,
+		Endpoint:         server.URL,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	creds.now = func() time.Time { return now }
+
+	token, err := creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t1.iam-token-1", token.GetIamToken())
+	assert.Equal(t, now.Add(time.Hour).Unix(), token.GetExpiresAt().AsTime().Unix())
+
+	// The token is cached while it is valid long enough.
+	now = now.Add(50 * time.Minute)
+	token, err = creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t1.iam-token-1", token.GetIamToken())
+
+	// The rotated external token is used for the refresh.
+	require.NoError(t, os.WriteFile(tokenFile, []byte("jwt-2"), 0600))
+	now = now.Add(6 * time.Minute)
+	token, err = creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t1.iam-token-2", token.GetIamToken())
+
+	assert.Equal(t, []string{"jwt-1", "jwt-2"}, exchange.subjectTokens)
+	assert.Equal(t, []string{"sa1", "sa1"}, exchange.audiences)
+}
+
+func TestIAMTokenFromEnv(t *testing.T) {
+	exchange := &fakeTokenExchange{}
+	server := httptest.NewServer(exchange)
+	defer server.Close()
+
+	t.Setenv("CI_JOB_JWT_V2", "jwt-from-env")
+	creds, err := NewCredentials(Config{
+		ServiceAccountID: "sa1",
+		TokenEnv:         "CI_JOB_JWT_V2",
+		Endpoint:         server.URL,
+	})
+	require.NoError(t, err)
+
+	_, err = creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jwt-from-env"}, exchange.subjectTokens)
+
+	t.Setenv("CI_JOB_JWT_V2", "")
+	creds.token = nil
+	_, err = creds.IAMToken(context.Background())
+	assert.ErrorContains(t, err, "is empty")
+}
+
+func TestIAMTokenFromGitHubActions(t *testing.T) {
+	exchange := &fakeTokenExchange{}
+	server := httptest.NewServer(exchange)
+	defer server.Close()
+
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer request-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"value": "jwt-for-" + r.URL.Query().Get("audience")})
+	}))
+	defer github.Close()
+
+	t.Setenv(envGitHubTokenRequestURL, github.URL+"/token?api-version=2.0")
+	t.Setenv(envGitHubTokenRequestToken, "request-token")
+
+	creds, err := NewCredentials(Config{
+		ServiceAccountID: "sa1",
+		Audience:         "yc-federation",
+		Endpoint:         server.URL,
+	})
+	require.NoError(t, err)
+
+	_, err = creds.IAMToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jwt-for-yc-federation"}, exchange.subjectTokens)
+}
+
+func TestIAMTokenErrors(t *testing.T) {
+	_, err := NewCredentials(Config{TokenFile: "token"})
+	assert.Error(t, err)
+
+	_, err = NewCredentials(Config{ServiceAccountID: "sa1", TokenFile: "token", TokenEnv: "TOKEN"})
+	assert.Error(t, err)
+
+	t.Setenv(envGitHubTokenRequestURL, "")
+	creds, err := NewCredentials(Config{ServiceAccountID: "sa1"})
+	require.NoError(t, err)
+	_, err = creds.IAMToken(context.Background())
+	assert.Error(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	t.Setenv("TOKEN", "jwt")
+	creds, err = NewCredentials(Config{ServiceAccountID: "sa1", TokenEnv: "TOKEN", Endpoint: server.URL})
+	require.NoError(t, err)
+	_, err = creds.IAMToken(context.Background())
+	assert.ErrorContains(t, err, "invalid_grant")
+}
@@ -0,0 +1,67 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+// cidrSubnetsForZonesFunction plans one subnet per availability zone out of a single network block.
+type cidrSubnetsForZonesFunction struct{}
+
+func NewCIDRSubnetsForZonesFunction() function.Function {
+	return &cidrSubnetsForZonesFunction{}
+}
+
+func (f *cidrSubnetsForZonesFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_subnets_for_zones"
+}
+
+func (f *cidrSubnetsForZonesFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a CIDR block into subnets of availability zones",
+		Description: "Returns a map of the availability zones to their subnets. The i-th zone gets the subnet " +
+			"`cidrsubnet(cidr, newbits, i)`, so appending zones to the list does not change the subnets of the existing ones.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cidr",
+				Description: "IPv4 or IPv6 network block in CIDR notation.",
+			},
+			function.Int64Parameter{
+				Name:        "newbits",
+				Description: "Number of bits to extend the prefix of the block with.",
+			},
+			function.ListParameter{
+				Name:        "zones",
+				Description: "Availability zones, e.g. `[\"ru-central1-a\", \"ru-central1-b\", \"ru-central1-d\"]`.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *cidrSubnetsForZonesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		cidr    string
+		newbits int64
+		zones   []string
+	)
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidr, &newbits, &zones))
+	if resp.Error != nil {
+		return
+	}
+
+	subnets, err := common.CIDRSubnetsForZones(cidr, int(newbits), zones)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, subnets))
+}
@@ -0,0 +1,83 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func runFunction(f function.Function, result attr.Value, args ...attr.Value) function.RunResponse {
+	resp := function.RunResponse{
+		Result: function.NewResultData(result),
+	}
+	f.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData(args),
+	}, &resp)
+	return resp
+}
+
+func TestParseVirtualHostIDFunction(t *testing.T) {
+	resp := runFunction(NewParseVirtualHostIDFunction(), types.ObjectUnknown(virtualHostIDAttrTypes),
+		types.StringValue("router-id/host-name"))
+
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, function.NewResultData(types.ObjectValueMust(virtualHostIDAttrTypes, map[string]attr.Value{
+		"http_router_id": types.StringValue("router-id"),
+		"name":           types.StringValue("host-name"),
+	})), resp.Result)
+
+	resp = runFunction(NewParseVirtualHostIDFunction(), types.ObjectUnknown(virtualHostIDAttrTypes),
+		types.StringValue("router-id"))
+	assert.Equal(t, function.NewArgumentFuncError(0, `error reading virtual_host, wrong id: "router-id"`), resp.Error)
+}
+
+func TestIAMMemberFunction(t *testing.T) {
+	resp := runFunction(NewIAMMemberFunction(), types.StringUnknown(),
+		types.StringValue("serviceAccount"), types.StringValue("aje123"))
+
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, function.NewResultData(types.StringValue("serviceAccount:aje123")), resp.Result)
+
+	resp = runFunction(NewIAMMemberFunction(), types.StringUnknown(),
+		types.StringValue(""), types.StringValue("aje123"))
+	assert.Equal(t, function.NewArgumentFuncError(0,
+		"expect 'member' value should be in TYPE:ID format, got ':aje123'"), resp.Error)
+
+	resp = runFunction(NewIAMMemberFunction(), types.StringUnknown(),
+		types.StringValue("group"), types.StringValue(""))
+	assert.Equal(t, function.NewArgumentFuncError(1, "IAM member ID should not be empty"), resp.Error)
+}
+
+func TestCIDRSubnetsForZonesFunction(t *testing.T) {
+	zones := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("ru-central1-a"),
+		types.StringValue("ru-central1-b"),
+	})
+
+	resp := runFunction(NewCIDRSubnetsForZonesFunction(), types.MapUnknown(types.StringType),
+		types.StringValue("10.0.0.0/16"), types.Int64Value(8), zones)
+
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, function.NewResultData(types.MapValueMust(types.StringType, map[string]attr.Value{
+		"ru-central1-a": types.StringValue("10.0.0.0/24"),
+		"ru-central1-b": types.StringValue("10.0.1.0/24"),
+	})), resp.Result)
+
+	resp = runFunction(NewCIDRSubnetsForZonesFunction(), types.MapUnknown(types.StringType),
+		types.StringValue("10.0.0.0/31"), types.Int64Value(8), zones)
+	assert.Equal(t, function.NewFuncError(`cannot extend prefix /31 of "10.0.0.0/31" by 8 bits`), resp.Error)
+}
+
+func TestS3WebsiteEndpointFunction(t *testing.T) {
+	resp := runFunction(NewS3WebsiteEndpointFunction(), types.StringUnknown(), types.StringValue("my-bucket"))
+
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, function.NewResultData(types.StringValue("my-bucket.website.yandexcloud.net")), resp.Result)
+
+	resp = runFunction(NewS3WebsiteEndpointFunction(), types.StringUnknown(), types.StringValue(""))
+	assert.Equal(t, function.NewArgumentFuncError(0, "bucket name should not be empty"), resp.Error)
+}
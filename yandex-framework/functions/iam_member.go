@@ -0,0 +1,59 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+// iamMemberFunction builds an IAM member in TYPE:ID format the IAM resources accept in `member` and `members`.
+type iamMemberFunction struct{}
+
+func NewIAMMemberFunction() function.Function {
+	return &iamMemberFunction{}
+}
+
+func (f *iamMemberFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "iam_member"
+}
+
+func (f *iamMemberFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build an IAM member",
+		Description: "Returns the IAM member of a subject in `TYPE:ID` format, e.g. `serviceAccount:<id>`, " +
+			"as the `member` and `members` attributes of the IAM resources expect it.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "type",
+				Description: "Type of the subject, e.g. `userAccount`, `serviceAccount`, `federatedUser`, `group` or `system`.",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "ID of the subject.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *iamMemberFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var subjectType, id string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &subjectType, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	member, err := common.IAMMember(subjectType, id)
+	if err != nil {
+		argument := int64(0)
+		if id == "" {
+			argument = 1
+		}
+		resp.Error = function.NewArgumentFuncError(argument, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, member))
+}
@@ -0,0 +1,68 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+var virtualHostIDAttrTypes = map[string]attr.Type{
+	"http_router_id": types.StringType,
+	"name":           types.StringType,
+}
+
+// parseVirtualHostIDFunction splits the ID of yandex_alb_virtual_host the same way the provider imports it.
+type parseVirtualHostIDFunction struct{}
+
+func NewParseVirtualHostIDFunction() function.Function {
+	return &parseVirtualHostIDFunction{}
+}
+
+func (f *parseVirtualHostIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_virtual_host_id"
+}
+
+func (f *parseVirtualHostIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse the ID of an ALB virtual host",
+		Description: "Splits the ID of `yandex_alb_virtual_host`, which is `<http_router_id>/<name>`, into an object with `http_router_id` and `name` attributes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "ID of the ALB virtual host.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: virtualHostIDAttrTypes,
+		},
+	}
+}
+
+func (f *parseVirtualHostIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	httpRouterID, name, err := common.ParseVirtualHostID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(virtualHostIDAttrTypes, map[string]attr.Value{
+		"http_router_id": types.StringValue(httpRouterID),
+		"name":           types.StringValue(name),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,49 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+// s3WebsiteEndpointFunction returns the website endpoint yandex_storage_bucket exports as `website_endpoint`.
+type s3WebsiteEndpointFunction struct{}
+
+func NewS3WebsiteEndpointFunction() function.Function {
+	return &s3WebsiteEndpointFunction{}
+}
+
+func (f *s3WebsiteEndpointFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "s3_website_endpoint"
+}
+
+func (f *s3WebsiteEndpointFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the website endpoint of a storage bucket",
+		Description: "Returns the website endpoint of an Object Storage bucket, e.g. `<bucket>.website.yandexcloud.net`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "bucket",
+				Description: "Name of the bucket.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *s3WebsiteEndpointFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var bucket string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &bucket))
+	if resp.Error != nil {
+		return
+	}
+
+	if bucket == "" {
+		resp.Error = function.NewArgumentFuncError(0, "bucket name should not be empty")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, common.StorageWebsiteEndpoint(bucket)))
+}
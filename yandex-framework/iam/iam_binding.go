@@ -13,14 +13,21 @@ import (
 	"strings"
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/utils"
 )
 
 type bindingResource struct {
 	ResourceUpdater ResourceIamUpdater
+
+	preventDestroy   common.PreventDestroyRules
+	compareBeforeSet bool
 }
 
 func NewIamBinding(updater ResourceIamUpdater) resource.Resource {
-	return &bindingResource{updater}
+	return &bindingResource{ResourceUpdater: updater}
 }
 
 func (r *bindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -49,6 +56,7 @@ func (r *bindingResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	resp.State.Raw = req.Plan.Raw
 	r.RefreshBindingState(ctx, req.Plan, &resp.State, resp.Diagnostics)
 }
 
@@ -119,8 +127,19 @@ func (r *bindingResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	var stateRole types.String
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("role"), &stateRole)...)
+	compare := compareBeforeSet(ctx, req.Plan, r.compareBeforeSet, &resp.Diagnostics)
+	stateBindings := getResourceIamBindings(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	err := iamPolicyReadModifySet(ctx, r.ResourceUpdater, func(p *Policy) error {
+		if compare {
+			current := common.IAMBindingsOfRole(stateRole.ValueString(), p.Bindings)
+			if err := common.CheckIAMBindingsUnchanged(r.ResourceUpdater.DescribeResource(), stateBindings, current); err != nil {
+				return err
+			}
+		}
 		p.Bindings = removeRoleFromBindings(stateRole.ValueString(), p.Bindings)
 		p.Bindings = append(p.Bindings, bindings...)
 		return nil
@@ -133,10 +152,15 @@ func (r *bindingResource) Update(ctx context.Context, req resource.UpdateRequest
 				"Error: %s", err))
 		return
 	}
+
+	resp.State.Raw = req.Plan.Raw
 	r.RefreshBindingState(ctx, req.Plan, &resp.State, resp.Diagnostics)
 }
 
 func (r *bindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if utils.PreventDestroy(ctx, r.preventDestroy, "yandex_"+r.ResourceUpdater.GetNameSuffix(), req.State, &resp.Diagnostics) {
+		return
+	}
 	r.ResourceUpdater.Initialize(ctx, req.State, &resp.Diagnostics)
 
 	binding := getResourceIamBindings(ctx, req.State, &resp.Diagnostics)
@@ -150,8 +174,14 @@ func (r *bindingResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 	role := binding[0].RoleId
+	compare := compareBeforeSet(ctx, req.State, r.compareBeforeSet, &resp.Diagnostics)
 
 	err := iamPolicyReadModifySet(ctx, r.ResourceUpdater, func(p *Policy) error {
+		if compare {
+			if err := common.CheckIAMBindingsUnchanged(r.ResourceUpdater.DescribeResource(), binding, common.IAMBindingsOfRole(role, p.Bindings)); err != nil {
+				return err
+			}
+		}
 		p.Bindings = removeRoleFromBindings(role, p.Bindings)
 		return nil
 	})
@@ -173,6 +203,10 @@ func (r *bindingResource) Metadata(_ context.Context, req resource.MetadataReque
 
 func (r *bindingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.ResourceUpdater.Configure(ctx, req, resp)
+	if providerConfig, ok := req.ProviderData.(*provider_config.Config); ok {
+		r.preventDestroy = providerConfig.PreventDestroy
+		r.compareBeforeSet = providerConfig.IAMCompareBeforeSet
+	}
 }
 
 func (r *bindingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -180,6 +214,8 @@ func (r *bindingResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 		Attributes: map[string]schema.Attribute{
 			"role":    schema.StringAttribute{Required: true},
 			"members": schema.SetAttribute{Required: true, ElementType: types.StringType},
+
+			"compare_before_set": compareBeforeSetAttribute,
 		},
 	}
 	maps.Copy(resp.Schema.Attributes, r.ResourceUpdater.GetSchemaAttributes())
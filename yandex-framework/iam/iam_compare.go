@@ -0,0 +1,30 @@
+package iam
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// compareBeforeSetAttribute is the attribute of the authoritative IAM resources that overrides
+// the provider iam_compare_before_set.
+var compareBeforeSetAttribute = schema.BoolAttribute{
+	Optional: true,
+	Description: "Re-read the access bindings right before replacing them and fail with their difference " +
+		"if they changed since they were read to plan the change. Default is the provider `iam_compare_before_set`.",
+}
+
+// compareBeforeSet reports whether the access bindings of the resource are compared before they
+// are replaced. The attribute of the plan, or of the state while the resource is deleted, takes
+// precedence over the provider default.
+func compareBeforeSet(ctx context.Context, data Extractable, providerDefault bool, diags *diag.Diagnostics) bool {
+	var compare types.Bool
+	diags.Append(data.GetAttribute(ctx, path.Root("compare_before_set"), &compare)...)
+	if compare.IsNull() || compare.IsUnknown() {
+		return providerDefault
+	}
+	return compare.ValueBool()
+}
@@ -0,0 +1,60 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+)
+
+type fakeIamUpdater struct {
+	policy *Policy
+}
+
+func (u *fakeIamUpdater) GetResourceIamPolicy(context.Context) (*Policy, error) {
+	return &Policy{Bindings: append([]*access.AccessBinding(nil), u.policy.Bindings...)}, nil
+}
+
+func (u *fakeIamUpdater) SetResourceIamPolicy(_ context.Context, policy *Policy) error {
+	u.policy = policy
+	return nil
+}
+
+func (u *fakeIamUpdater) UpdateResourceIamPolicy(context.Context, *PolicyDelta) error { return nil }
+func (u *fakeIamUpdater) GetMutexKey() string                                         { return "iam-fake" }
+func (u *fakeIamUpdater) Initialize(context.Context, Extractable, *diag.Diagnostics)  {}
+func (u *fakeIamUpdater) DescribeResource() string                                    { return "fake resource" }
+func (u *fakeIamUpdater) Configure(context.Context, resource.ConfigureRequest, *resource.ConfigureResponse) {
+}
+func (u *fakeIamUpdater) GetSchemaAttributes() map[string]schema.Attribute { return nil }
+func (u *fakeIamUpdater) GetNameSuffix() string                            { return "fake_iam_binding" }
+func (u *fakeIamUpdater) GetIdAlias() string                               { return "fake_id" }
+func (u *fakeIamUpdater) GetId() string                                    { return "fake" }
+
+func TestPolicyResourceReplacePolicy_compare(t *testing.T) {
+	planned := &Policy{Bindings: []*access.AccessBinding{
+		roleMemberToAccessBinding("viewer", "userAccount:a"),
+	}}
+	updater := &fakeIamUpdater{policy: &Policy{Bindings: []*access.AccessBinding{
+		roleMemberToAccessBinding("viewer", "userAccount:a"),
+		roleMemberToAccessBinding("editor", "userAccount:b"),
+	}}}
+	r := &policyResource{ResourceUpdater: updater}
+
+	err := r.replacePolicy(context.Background(), &Policy{}, planned)
+	require.Error(t, err, "bindings added since the plan must not be overwritten")
+	assert.Contains(t, err.Error(), "+ editor: userAccount:b")
+	assert.Len(t, updater.policy.Bindings, 2)
+
+	require.NoError(t, r.replacePolicy(context.Background(), &Policy{}, updater.policy))
+	assert.Empty(t, updater.policy.Bindings)
+
+	updater.policy = &Policy{Bindings: []*access.AccessBinding{roleMemberToAccessBinding("editor", "userAccount:b")}}
+	require.NoError(t, r.replacePolicy(context.Background(), planned, nil), "nothing is compared without expected bindings")
+	assert.Equal(t, planned.Bindings, updater.policy.Bindings)
+}
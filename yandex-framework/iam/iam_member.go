@@ -0,0 +1,190 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/utils"
+)
+
+type memberResource struct {
+	ResourceUpdater ResourceIamUpdater
+
+	preventDestroy common.PreventDestroyRules
+}
+
+func NewIamMember(updater ResourceIamUpdater) resource.Resource {
+	return &memberResource{ResourceUpdater: updater}
+}
+
+func (r *memberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.ResourceUpdater.Initialize(ctx, req.Plan, &resp.Diagnostics)
+	member := getResourceIamMember(ctx, req.Plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := iamPolicyUpdate(ctx, r.ResourceUpdater, &PolicyDelta{
+		Deltas: []*access.AccessBindingDelta{
+			{
+				Action:        access.AccessBindingAction_ADD,
+				AccessBinding: member,
+			},
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Add Resource Policy Member",
+			fmt.Sprintf("An unexpected error occurred while adding member to resource policies. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"Error: %s", err))
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+	r.refreshMemberState(ctx, &resp.State, &resp.Diagnostics)
+}
+
+func (r *memberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	r.ResourceUpdater.Initialize(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.refreshMemberState(ctx, &resp.State, &resp.Diagnostics)
+}
+
+// refreshMemberState removes the resource from the state if the member no longer has the role.
+func (r *memberResource) refreshMemberState(ctx context.Context, state *tfsdk.State, diags *diag.Diagnostics) {
+	eMember := getResourceIamMember(ctx, state, diags)
+	if diags.HasError() {
+		return
+	}
+
+	policy, err := r.ResourceUpdater.GetResourceIamPolicy(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to Refresh Resource Policies",
+			fmt.Sprintf("An unexpected error occurred while refreshing resource policies. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"Error: %s", err))
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Retrieved access bindings of %s: %+v", r.ResourceUpdater.DescribeResource(), policy))
+
+	for _, b := range policy.Bindings {
+		if b.RoleId == eMember.RoleId && canonicalMember(b) == canonicalMember(eMember) {
+			diags.Append(state.SetAttribute(ctx, path.Root("member"), canonicalMember(b))...)
+			return
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Member %q with role %q does not exist in access bindings of %s, removing from state",
+		canonicalMember(eMember), eMember.RoleId, r.ResourceUpdater.DescribeResource()))
+	state.RemoveResource(ctx)
+}
+
+// Update is never called, as all attributes require replacement.
+func (r *memberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.State.Raw = req.Plan.Raw
+}
+
+func (r *memberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if utils.PreventDestroy(ctx, r.preventDestroy, "yandex_"+iamResourceNameSuffix(r.ResourceUpdater, "member"), req.State, &resp.Diagnostics) {
+		return
+	}
+	r.ResourceUpdater.Initialize(ctx, req.State, &resp.Diagnostics)
+	member := getResourceIamMember(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := iamPolicyUpdate(ctx, r.ResourceUpdater, &PolicyDelta{
+		Deltas: []*access.AccessBindingDelta{
+			{
+				Action:        access.AccessBindingAction_REMOVE,
+				AccessBinding: member,
+			},
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Remove Resource Policy Member",
+			fmt.Sprintf("An unexpected error occurred while removing member from resource policies. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"Error: %s", err))
+	}
+}
+
+func (r *memberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + iamResourceNameSuffix(r.ResourceUpdater, "member")
+}
+
+func (r *memberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.ResourceUpdater.Configure(ctx, req, resp)
+	if providerConfig, ok := req.ProviderData.(*provider_config.Config); ok {
+		r.preventDestroy = providerConfig.PreventDestroy
+	}
+}
+
+func (r *memberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"member": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    []validator.String{memberValidator{}},
+			},
+		},
+	}
+	for name, attribute := range r.ResourceUpdater.GetSchemaAttributes() {
+		resp.Schema.Attributes[name] = requiresReplace(attribute)
+	}
+}
+
+func (r *memberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ",")
+
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || !isValidMember(idParts[2]) {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: {resource_id},{role},{member}. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(r.ResourceUpdater.GetIdAlias()), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member"), idParts[2])...)
+}
+
+func getResourceIamMember(ctx context.Context, state Extractable, diag *diag.Diagnostics) *access.AccessBinding {
+	var role, member types.String
+
+	diag.Append(state.GetAttribute(ctx, path.Root("role"), &role)...)
+	diag.Append(state.GetAttribute(ctx, path.Root("member"), &member)...)
+	if diag.HasError() {
+		return nil
+	}
+	return roleMemberToAccessBinding(role.ValueString(), member.ValueString())
+}
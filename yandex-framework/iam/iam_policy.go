@@ -0,0 +1,219 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/utils"
+)
+
+type policyResource struct {
+	ResourceUpdater ResourceIamUpdater
+
+	preventDestroy   common.PreventDestroyRules
+	compareBeforeSet bool
+}
+
+// NewIamPolicy returns the resource that replaces all access bindings of the resource with policy_data.
+func NewIamPolicy(updater ResourceIamUpdater) resource.Resource {
+	return &policyResource{ResourceUpdater: updater}
+}
+
+func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.ResourceUpdater.Initialize(ctx, req.Plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// There are no access bindings read to plan the creation, so there is nothing to compare with.
+	r.setPolicyData(ctx, req.Plan, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+	r.refreshPolicyState(ctx, &resp.State, &resp.Diagnostics)
+}
+
+func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	r.ResourceUpdater.Initialize(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.refreshPolicyState(ctx, &resp.State, &resp.Diagnostics)
+}
+
+func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.ResourceUpdater.Initialize(ctx, req.Plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var expected *Policy
+	if compareBeforeSet(ctx, req.Plan, r.compareBeforeSet, &resp.Diagnostics) {
+		expected = r.statePolicy(ctx, req.State, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setPolicyData(ctx, req.Plan, expected, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+	r.refreshPolicyState(ctx, &resp.State, &resp.Diagnostics)
+}
+
+func (r *policyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if utils.PreventDestroy(ctx, r.preventDestroy, "yandex_"+iamResourceNameSuffix(r.ResourceUpdater, "policy"), req.State, &resp.Diagnostics) {
+		return
+	}
+	r.ResourceUpdater.Initialize(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var expected *Policy
+	if compareBeforeSet(ctx, req.State, r.compareBeforeSet, &resp.Diagnostics) {
+		expected = r.statePolicy(ctx, req.State, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set an empty policy to delete the attached policy.
+	if err := r.replacePolicy(ctx, &Policy{}, expected); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Resource Policies",
+			fmt.Sprintf("An unexpected error occurred while deleting resource policies. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"Error: %s", err))
+	}
+}
+
+// setPolicyData replaces the access bindings of the resource with the policy_data of the plan.
+// If expected is set, the current access bindings are compared with it right before they are replaced.
+func (r *policyResource) setPolicyData(ctx context.Context, plan tfsdk.Plan, expected *Policy, diags *diag.Diagnostics) {
+	var policyData types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("policy_data"), &policyData)...)
+	if diags.HasError() {
+		return
+	}
+
+	policy, err := unmarshalIamPolicy(policyData.ValueString())
+	if err == nil {
+		err = r.replacePolicy(ctx, policy, expected)
+	}
+	if err != nil {
+		diags.AddError(
+			"Unable to Set Resource Policies",
+			fmt.Sprintf("An unexpected error occurred while setting resource policies. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"Error: %s", err))
+	}
+}
+
+// replacePolicy replaces the access bindings of the resource with the policy. If expected is set, it fails
+// instead if the current access bindings differ from expected.
+func (r *policyResource) replacePolicy(ctx context.Context, policy, expected *Policy) error {
+	if expected == nil {
+		return iamPolicySet(ctx, r.ResourceUpdater, policy)
+	}
+	return iamPolicyReadModifySet(ctx, r.ResourceUpdater, func(p *Policy) error {
+		if err := common.CheckIAMBindingsUnchanged(r.ResourceUpdater.DescribeResource(), expected.Bindings, p.Bindings); err != nil {
+			return err
+		}
+		p.Bindings = policy.Bindings
+		return nil
+	})
+}
+
+// statePolicy returns the policy_data of the state, i.e. the access bindings read to plan the change.
+func (r *policyResource) statePolicy(ctx context.Context, state tfsdk.State, diags *diag.Diagnostics) *Policy {
+	var policyData types.String
+	diags.Append(state.GetAttribute(ctx, path.Root("policy_data"), &policyData)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	policy, err := unmarshalIamPolicy(policyData.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Policy Data in State", err.Error())
+		return nil
+	}
+	return policy
+}
+
+// refreshPolicyState keeps policy_data of the state as is, unless the access bindings of the resource differ from it.
+func (r *policyResource) refreshPolicyState(ctx context.Context, state *tfsdk.State, diags *diag.Diagnostics) {
+	policy, err := r.ResourceUpdater.GetResourceIamPolicy(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to Refresh Resource Policies",
+			fmt.Sprintf("An unexpected error occurred while refreshing resource policies. "+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				"Error: %s", err))
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Retrieved access bindings of %s: %+v", r.ResourceUpdater.DescribeResource(), policy))
+
+	var policyData types.String
+	diags.Append(state.GetAttribute(ctx, path.Root("policy_data"), &policyData)...)
+	if current, err := unmarshalIamPolicy(policyData.ValueString()); err == nil && policiesEqual(current, policy) {
+		return
+	}
+	diags.Append(state.SetAttribute(ctx, path.Root("policy_data"), marshalIamPolicy(policy))...)
+}
+
+func (r *policyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + iamResourceNameSuffix(r.ResourceUpdater, "policy")
+}
+
+func (r *policyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.ResourceUpdater.Configure(ctx, req, resp)
+	if providerConfig, ok := req.ProviderData.(*provider_config.Config); ok {
+		r.preventDestroy = providerConfig.PreventDestroy
+		r.compareBeforeSet = providerConfig.IAMCompareBeforeSet
+	}
+}
+
+func (r *policyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"policy_data": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{policyDataValidator{}},
+			},
+			"compare_before_set": compareBeforeSetAttribute,
+		},
+	}
+	for name, attribute := range r.ResourceUpdater.GetSchemaAttributes() {
+		resp.Schema.Attributes[name] = requiresReplace(attribute)
+	}
+}
+
+func (r *policyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: {resource_id}. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(r.ResourceUpdater.GetIdAlias()), req.ID)...)
+}
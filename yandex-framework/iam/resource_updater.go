@@ -12,7 +12,8 @@ import (
 	"github.com/yandex-cloud/terraform-provider-yandex/common/mutexkv"
 )
 
-var mutexKV = mutexkv.NewMutexKV()
+// MutexKV serializes changes of access bindings of the same resource, see ResourceIamUpdater.GetMutexKey.
+var MutexKV = mutexkv.NewMutexKV()
 
 type Policy struct {
 	Bindings []*access.AccessBinding
@@ -74,8 +75,10 @@ type iamPolicyModifyFunc func(p *Policy) error
 
 func iamPolicyReadModifySet(ctx context.Context, updater ResourceIamUpdater, modify iamPolicyModifyFunc) error {
 	mutexKey := updater.GetMutexKey()
-	mutexKV.Lock(mutexKey)
-	defer mutexKV.Unlock(mutexKey)
+	if err := MutexKV.LockContext(ctx, mutexKey); err != nil {
+		return err
+	}
+	defer MutexKV.Unlock(mutexKey)
 
 	tflog.Debug(ctx, fmt.Sprintf("Retrieving access bindings for %s", updater.DescribeResource()))
 
@@ -102,3 +105,41 @@ func iamPolicyReadModifySet(ctx context.Context, updater ResourceIamUpdater, mod
 
 	return nil
 }
+
+// iamPolicyUpdate applies the policy delta to the access bindings of the resource.
+func iamPolicyUpdate(ctx context.Context, updater ResourceIamUpdater, policyDelta *PolicyDelta) error {
+	mutexKey := updater.GetMutexKey()
+	if err := MutexKV.LockContext(ctx, mutexKey); err != nil {
+		return err
+	}
+	defer MutexKV.Unlock(mutexKey)
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating access bindings of %s with %+v", updater.DescribeResource(), policyDelta))
+
+	err := updater.UpdateResourceIamPolicy(ctx, policyDelta)
+	if err != nil {
+		return fmt.Errorf("Error updating access bindings of %s: %w", updater.DescribeResource(), err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updated access bindings of %s", updater.DescribeResource()))
+
+	return nil
+}
+
+// iamPolicySet replaces the access bindings of the resource with the policy.
+func iamPolicySet(ctx context.Context, updater ResourceIamUpdater, policy *Policy) error {
+	mutexKey := updater.GetMutexKey()
+	if err := MutexKV.LockContext(ctx, mutexKey); err != nil {
+		return err
+	}
+	defer MutexKV.Unlock(mutexKey)
+
+	tflog.Debug(ctx, fmt.Sprintf("Setting access bindings for %s to %+v", updater.DescribeResource(), policy))
+
+	err := updater.SetResourceIamPolicy(ctx, policy)
+	if err != nil {
+		return fmt.Errorf("Error applying access bindings to %s: %w", updater.DescribeResource(), err)
+	}
+
+	return nil
+}
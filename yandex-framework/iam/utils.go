@@ -1,9 +1,18 @@
 package iam
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+	"sort"
 	"strings"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
 )
 
 func roleMemberToAccessBinding(role, member string) *access.AccessBinding {
@@ -95,3 +104,112 @@ func CountBatches(size, batchSize int) int {
 	}
 	return iterations
 }
+
+// iamResourceNameSuffix returns the name suffix of the member or policy resource of the updater,
+// e.g. "datasphere_project_iam_member" for the updater of "datasphere_project_iam_binding".
+func iamResourceNameSuffix(updater ResourceIamUpdater, kind string) string {
+	return strings.TrimSuffix(updater.GetNameSuffix(), "_iam_binding") + "_iam_" + kind
+}
+
+// requiresReplace makes the resource id attribute of the updater schema force a replacement on change.
+func requiresReplace(attribute schema.Attribute) schema.Attribute {
+	if a, ok := attribute.(schema.StringAttribute); ok {
+		a.PlanModifiers = append([]planmodifier.String{stringplanmodifier.RequiresReplace()}, a.PlanModifiers...)
+		return a
+	}
+	return attribute
+}
+
+func isValidMember(member string) bool {
+	return common.ValidateIAMMember(member) == nil
+}
+
+type memberValidator struct{}
+
+func (v memberValidator) Description(_ context.Context) string {
+	return "value must be in TYPE:ID format"
+}
+
+func (v memberValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v memberValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !isValidMember(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Member",
+			fmt.Sprintf("expect 'member' value should be in TYPE:ID format, got '%s'", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+type policyDataValidator struct{}
+
+func (v policyDataValidator) Description(_ context.Context) string {
+	return "value must be a policy in the format of the yandex_iam_policy data source"
+}
+
+func (v policyDataValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v policyDataValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := unmarshalIamPolicy(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Policy Data", err.Error())
+	}
+}
+
+func marshalIamPolicy(policy *Policy) string {
+	pdBytes, _ := json.Marshal(&Policy{
+		Bindings: policy.Bindings,
+	})
+
+	return string(pdBytes)
+}
+
+func unmarshalIamPolicy(policyData string) (*Policy, error) {
+	policy := &Policy{}
+	if err := json.Unmarshal([]byte(policyData), policy); err != nil {
+		return nil, fmt.Errorf("Could not unmarshal policy data %s:\n%w", policyData, err)
+	}
+	for _, b := range policy.Bindings {
+		if b == nil || b.Subject == nil {
+			return nil, fmt.Errorf("Policy data %s has a binding without a subject", policyData)
+		}
+	}
+	return policy, nil
+}
+
+// policiesEqual reports whether the policies have the same members of the same roles.
+func policiesEqual(a, b *Policy) bool {
+	canonical := func(p *Policy) []string {
+		var bindings []string
+		for role, members := range rolesToMembersMap(p.Bindings) {
+			for member := range members {
+				bindings = append(bindings, role+" "+member)
+			}
+		}
+		sort.Strings(bindings)
+		return bindings
+	}
+
+	aBindings, bBindings := canonical(a), canonical(b)
+	if len(aBindings) != len(bBindings) {
+		return false
+	}
+	for i := range aBindings {
+		if aBindings[i] != bBindings[i] {
+			return false
+		}
+	}
+	return true
+}
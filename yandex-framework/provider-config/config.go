@@ -2,37 +2,15 @@ package provider_config
 
 import (
 	"context"
-	"crypto/tls"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"math"
-	"math/rand"
-	"net"
-	"os"
-	"path/filepath"
-	"strings"
-	"time"
-
-	"github.com/google/uuid"
-	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/mitchellh/go-homedir"
 	ycsdk "github.com/yandex-cloud/go-sdk"
-	"github.com/yandex-cloud/go-sdk/iamkey"
-	"github.com/yandex-cloud/go-sdk/pkg/requestid"
-	"github.com/yandex-cloud/go-sdk/pkg/retry"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-
-	"github.com/yandex-cloud/terraform-provider-yandex/pkg/logging"
-)
 
-const (
-	defaultExponentialBackoffBase = 50 * time.Millisecond
-	defaultExponentialBackoffCap  = 1 * time.Minute
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/common/client"
+	"github.com/yandex-cloud/terraform-provider-yandex/common/mutexkv"
 )
 
 type State struct {
@@ -62,189 +40,172 @@ type State struct {
 
 	SharedCredentialsFile types.String `tfsdk:"shared_credentials_file"`
 	Profile               types.String `tfsdk:"profile"`
-	//
-	//sharedCredentials *SharedCredentials
-	//defaultS3Client   *s3.S3
-}
-
-// TODO: remove yandex.Config when it is not used
-type Config struct {
-	ProviderState State
 
-	UserAgent types.String
-	SDK       *ycsdk.SDK
-}
+	// DefaultLabels are merged into the labels of every resource that has them.
+	DefaultLabels types.Map `tfsdk:"default_labels"`
 
-// Client configures and returns a fully initialized Yandex.Cloud SDK
-func (c *Config) InitAndValidate(ctx context.Context, terraformVersion string, sweeper bool) error {
-	ctx = requestid.ContextWithClientTraceID(ctx, uuid.New().String())
+	// PreventDestroyTypes are the resource types and labels of the resources the provider refuses to delete.
+	PreventDestroyTypes types.List `tfsdk:"prevent_destroy_types"`
 
-	credentials, err := c.Credentials(ctx)
-	if err != nil {
-		return err
-	}
+	// IAMCompareBeforeSet makes the authoritative IAM resources compare the access bindings before they are set.
+	IAMCompareBeforeSet types.Bool `tfsdk:"iam_compare_before_set"`
 
-	yandexSDKConfig := &ycsdk.Config{
-		Credentials: credentials,
-		Endpoint:    c.ProviderState.Endpoint.ValueString(),
-		Plaintext:   c.ProviderState.Plaintext.ValueBool(),
-		TLSConfig: &tls.Config{
-			InsecureSkipVerify: c.ProviderState.Insecure.ValueBool(),
-		},
-	}
+	// RetryPolicy replaces the default retries of calls failed with codes.Unavailable if set.
+	RetryPolicy []RetryPolicy `tfsdk:"retry_policy"`
 
-	providerNameAndVersion := getProviderNameAndVersion()
-	terraformURL := "https://www.terraform.io"
+	// RateLimit limits the rate and concurrency of API requests per service.
+	RateLimit []RateLimit `tfsdk:"rate_limit"`
 
-	if sweeper {
-		c.UserAgent = types.StringValue("Terraform Sweeper")
-	} else {
-		c.UserAgent = types.StringValue(fmt.Sprintf("Terraform/%s (%s) %s", terraformVersion, terraformURL, providerNameAndVersion))
-	}
+	// WorkloadIdentity exchanges an external OIDC token for an IAM token of a service account.
+	WorkloadIdentity []WorkloadIdentity `tfsdk:"workload_identity"`
 
-	headerMD := metadata.Pairs("user-agent", c.UserAgent.ValueString())
+	// LockBackend holds the locks of IAM and cloud-wide changes outside of the provider process if set.
+	LockBackend []LockBackend `tfsdk:"lock_backend"`
 
-	requestIDInterceptor := requestid.Interceptor()
+	// ImpersonateServiceAccountID is the service account whose IAM tokens, minted with
+	// the configured credentials, authenticate the API calls.
+	ImpersonateServiceAccountID types.String `tfsdk:"impersonate_service_account_id"`
+}
 
-	retryInterceptor := retry.Interceptor(
-		retry.WithMax(int(c.ProviderState.MaxRetries.ValueInt64())),
-		retry.WithCodes(codes.Unavailable),
-		retry.WithAttemptHeader(true),
-		retry.WithBackoff(backoffExponentialWithJitter(defaultExponentialBackoffBase, defaultExponentialBackoffCap)))
+// TODO: remove yandex.Config when it is not used
+type Config struct {
+	ProviderState State
 
-	var interceptors = []grpc.UnaryClientInterceptor{
-		retryInterceptor,
-		requestIDInterceptor,
-	}
+	UserAgent types.String
+	SDK       *ycsdk.SDK
 
-	// Support deep API logging in case user has requested it.
-	if os.Getenv("TF_ENABLE_API_LOGGING") != "" {
-		log.Print("[INFO] API logging has been requested, turning on")
-		interceptors = append(interceptors, logging.NewAPILoggingUnaryInterceptor())
-	}
+	// DefaultS3Session is the Object Storage session with the provider storage access keys,
+	// nil if there are no such keys.
+	DefaultS3Session *session.Session
 
-	// Make sure retry interceptor is above id interceptor.
-	// Now we will have new request id for every retry attempt.
-	interceptorChain := grpc_middleware.ChainUnaryClient(interceptors...)
+	// PreventDestroy rejects the Delete of resources of the listed types or with the listed labels.
+	PreventDestroy common.PreventDestroyRules
 
-	c.SDK, err = ycsdk.Build(ctx, *yandexSDKConfig,
-		grpc.WithUserAgent(c.UserAgent.ValueString()),
-		grpc.WithDefaultCallOptions(grpc.Header(&headerMD)),
-		grpc.WithUnaryInterceptor(interceptorChain))
+	// IAMCompareBeforeSet makes the authoritative IAM resources fail instead of overwriting access bindings
+	// changed since they were read to plan the change, unless the resource overrides it.
+	IAMCompareBeforeSet bool
 
-	return err
+	client *client.Client
 }
 
-func (c *Config) Credentials(ctx context.Context) (ycsdk.Credentials, error) {
-	if c.ProviderState.ServiceAccountKeyFileOrContent.ValueString() != "" {
-		contents, _, err := pathOrContents(c.ProviderState.ServiceAccountKeyFileOrContent.ValueString())
-		if err != nil {
-			return nil, fmt.Errorf("Error loading Credentials: %s", err)
-		}
-
-		key, err := iamKeyFromJSONContent(contents)
-		if err != nil {
-			return nil, err
-		}
-		return ycsdk.ServiceAccountKey(key)
-	}
-
-	if c.ProviderState.Token.ValueString() != "" {
-		if strings.HasPrefix(
-			c.ProviderState.Token.ValueString(), "t1.",
-		) && strings.Count(
-			c.ProviderState.Token.ValueString(), ".",
-		) == 2 {
-			return ycsdk.NewIAMTokenCredentials(c.ProviderState.Token.ValueString()), nil
-		}
-		return ycsdk.OAuthToken(c.ProviderState.Token.ValueString()), nil
-	}
-
-	if sa := ycsdk.InstanceServiceAccount(); checkServiceAccountAvailable(ctx, sa) {
-		return sa, nil
+// Client configures and returns a fully initialized Yandex.Cloud SDK
+func (c *Config) InitAndValidate(ctx context.Context, terraformVersion string, sweeper bool) error {
+	settings, err := c.ProviderState.settings()
+	if err != nil {
+		return err
 	}
 
-	return nil, fmt.Errorf("one of 'token' or 'service_account_key_file' should be specified;" +
-		" if you are inside compute instance, you can attach service account to it in order to " +
-		"authenticate via instance service account")
-}
-
-func iamKeyFromJSONContent(content string) (*iamkey.Key, error) {
-	key := &iamkey.Key{}
-	err := json.Unmarshal([]byte(content), key)
+	// The framework provider has no stop context, ctx ends with the configure request. The client is
+	// released when the SDK provider sharing it stops.
+	ycClient, err := client.Shared(context.Background(), settings, terraformVersion, sweeper)
 	if err != nil {
-		return nil, fmt.Errorf("key unmarshal fail: %s", err)
+		return err
 	}
-	return key, nil
-}
 
-func backoffExponentialWithJitter(base time.Duration, cap time.Duration) retry.BackoffFunc {
-	return func(attempt int) time.Duration {
-		// First call of BackoffFunc would be with attempt arq equal 0
-		log.Printf("[DEBUG] API call retry attempt %d", attempt+1)
+	c.ProviderState.setSettings(ycClient.Settings)
+	c.UserAgent = types.StringValue(ycClient.UserAgent)
+	c.SDK = ycClient.SDK
+	c.DefaultS3Session = ycClient.S3Session
+	c.client = ycClient
 
-		to := getExponentialTimeout(attempt, base)
-		// Using float types here, because exponential time can be really big, and converting it to time.Duration may
-		// result in undefined behaviour. Its safe conversion, when we have compared it to our 'cap' value.
-		if to > float64(cap) {
-			to = float64(cap)
+	var preventDestroyTypes []string
+	if list := c.ProviderState.PreventDestroyTypes; !list.IsNull() && !list.IsUnknown() {
+		if diags := list.ElementsAs(ctx, &preventDestroyTypes, false); diags.HasError() {
+			return fmt.Errorf("invalid prevent_destroy_types: %v", diags)
 		}
-
-		return time.Duration(to * rand.Float64())
 	}
+	c.PreventDestroy, err = common.ParsePreventDestroyRules(preventDestroyTypes)
+	if err != nil {
+		return fmt.Errorf("invalid prevent_destroy_types: %w", err)
+	}
+	c.IAMCompareBeforeSet = c.ProviderState.IAMCompareBeforeSet.ValueBool()
+	return nil
 }
 
-func getExponentialTimeout(attempt int, base time.Duration) float64 {
-	mult := math.Pow(2, float64(attempt))
-	return float64(base) * mult
-}
-
-func getProviderNameAndVersion() string {
-	// version is part of binary name
-	// https://www.terraform.io/docs/configuration/providers.html#plugin-names-and-versions
-	fullBinaryPath := os.Args[0]
-	binaryName := filepath.Base(fullBinaryPath)
-	parts := strings.Split(binaryName, "_")
-
-	if len(parts) < 2 {
-		return "unknown/unknown"
+// ConfigureLocks makes the MutexKV hold its locks in the lock backend of the provider.
+func (c *Config) ConfigureLocks(m *mutexkv.MutexKV) {
+	if c.client != nil {
+		c.client.ConfigureLocks(m)
 	}
-
-	parts[1] = strings.TrimPrefix(parts[1], "v")
-
-	return strings.Join(parts[:2], "/")
 }
 
-func checkServiceAccountAvailable(ctx context.Context, sa ycsdk.NonExchangeableCredentials) bool {
-	dialer := net.Dialer{Timeout: 50 * time.Millisecond}
-	conn, err := dialer.Dial("tcp", net.JoinHostPort(ycsdk.InstanceMetadataAddr, "80"))
+// Credentials returns the credentials of the provider block, see client.Settings.Credentials.
+func (c *Config) Credentials(ctx context.Context) (ycsdk.Credentials, *ycsdk.SDK, error) {
+	settings, err := c.ProviderState.settings()
 	if err != nil {
-		return false
+		return nil, nil, err
 	}
-	_ = conn.Close()
-	_, err = sa.IAMToken(ctx)
-	return err == nil
+	return settings.Credentials(ctx)
 }
 
-// copy of github.com/hashicorp/terraform-plugin-SDK/helper/pathorcontents.Read()
-func pathOrContents(poc string) (string, bool, error) {
-	if len(poc) == 0 {
-		return poc, false, nil
+// settings returns the client settings of the provider block, completed with the environment variables
+// and the defaults.
+func (s *State) settings() (client.Settings, error) {
+	settings := client.Settings{
+		Endpoint:                       s.Endpoint.ValueString(),
+		FolderID:                       s.FolderID.ValueString(),
+		CloudID:                        s.CloudID.ValueString(),
+		OrganizationID:                 s.OrganizationID.ValueString(),
+		Zone:                           s.Zone.ValueString(),
+		Region:                         s.Region.ValueString(),
+		Token:                          s.Token.ValueString(),
+		ServiceAccountKeyFileOrContent: s.ServiceAccountKeyFileOrContent.ValueString(),
+		Plaintext:                      s.Plaintext.ValueBool(),
+		Insecure:                       s.Insecure.ValueBool(),
+		MaxRetries:                     int(s.MaxRetries.ValueInt64()),
+		StorageEndpoint:                s.StorageEndpoint.ValueString(),
+		YMQEndpoint:                    s.YMQEndpoint.ValueString(),
+		StorageAccessKey:               s.StorageAccessKey.ValueString(),
+		StorageSecretKey:               s.StorageSecretKey.ValueString(),
+		YMQAccessKey:                   s.YMQAccessKey.ValueString(),
+		YMQSecretKey:                   s.YMQSecretKey.ValueString(),
+		SharedCredentialsFile:          s.SharedCredentialsFile.ValueString(),
+		Profile:                        s.Profile.ValueString(),
+		RateLimits:                     s.rateLimits(),
+		ImpersonateServiceAccountID:    s.ImpersonateServiceAccountID.ValueString(),
 	}
+	settings.SetDefaults()
 
-	path := poc
-	if path[0] == '~' {
-		var err error
-		path, err = homedir.Expand(path)
-		if err != nil {
-			return path, true, err
-		}
+	workloadIdentity, err := s.workloadIdentity()
+	if err != nil {
+		return settings, err
 	}
+	settings.WorkloadIdentity = workloadIdentity
 
-	if _, err := os.Stat(path); err == nil {
-		contents, err := ioutil.ReadFile(path)
-		return string(contents), true, err
+	retryPolicy, err := s.retryPolicy(settings.MaxRetries)
+	if err != nil {
+		return settings, fmt.Errorf("invalid retry_policy: %w", err)
 	}
+	settings.RetryPolicy = retryPolicy
+
+	lockBackend, err := s.lockBackend()
+	if err != nil {
+		return settings, err
+	}
+	settings.LockBackend = lockBackend
+	return settings, nil
+}
 
-	return poc, false, nil
+// setSettings sets the settings resolved by the client, e.g. from the shared credentials profile.
+func (s *State) setSettings(settings client.Settings) {
+	s.Endpoint = types.StringValue(settings.Endpoint)
+	s.FolderID = types.StringValue(settings.FolderID)
+	s.CloudID = types.StringValue(settings.CloudID)
+	s.OrganizationID = types.StringValue(settings.OrganizationID)
+	s.Zone = types.StringValue(settings.Zone)
+	s.Region = types.StringValue(settings.Region)
+	s.Token = types.StringValue(settings.Token)
+	s.ServiceAccountKeyFileOrContent = types.StringValue(settings.ServiceAccountKeyFileOrContent)
+	s.Plaintext = types.BoolValue(settings.Plaintext)
+	s.Insecure = types.BoolValue(settings.Insecure)
+	s.MaxRetries = types.Int64Value(int64(settings.MaxRetries))
+	s.StorageEndpoint = types.StringValue(settings.StorageEndpoint)
+	s.YMQEndpoint = types.StringValue(settings.YMQEndpoint)
+	s.StorageAccessKey = types.StringValue(settings.StorageAccessKey)
+	s.StorageSecretKey = types.StringValue(settings.StorageSecretKey)
+	s.YMQAccessKey = types.StringValue(settings.YMQAccessKey)
+	s.YMQSecretKey = types.StringValue(settings.YMQSecretKey)
+	s.SharedCredentialsFile = types.StringValue(settings.SharedCredentialsFile)
+	s.Profile = types.StringValue(settings.Profile)
+	s.ImpersonateServiceAccountID = types.StringValue(settings.ImpersonateServiceAccountID)
 }
@@ -0,0 +1,43 @@
+package provider_config
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common/mutexkv"
+)
+
+type LockBackend struct {
+	Type          types.String `tfsdk:"type"`
+	Path          types.String `tfsdk:"path"`
+	Bucket        types.String `tfsdk:"bucket"`
+	Prefix        types.String `tfsdk:"prefix"`
+	Endpoint      types.String `tfsdk:"endpoint"`
+	Table         types.String `tfsdk:"table"`
+	Timeout       types.String `tfsdk:"timeout"`
+	LeaseDuration types.String `tfsdk:"lease_duration"`
+	Holder        types.String `tfsdk:"holder"`
+}
+
+// lockBackend returns nil if there is no lock_backend block, so that the locks are held in memory.
+func (s *State) lockBackend() (*mutexkv.Config, error) {
+	if len(s.LockBackend) == 0 {
+		return nil, nil
+	}
+	if len(s.LockBackend) > 1 {
+		return nil, fmt.Errorf("at most one lock_backend block is allowed, got %d", len(s.LockBackend))
+	}
+	lb := s.LockBackend[0]
+	return &mutexkv.Config{
+		Type:          lb.Type.ValueString(),
+		Path:          lb.Path.ValueString(),
+		Bucket:        lb.Bucket.ValueString(),
+		Prefix:        lb.Prefix.ValueString(),
+		Endpoint:      lb.Endpoint.ValueString(),
+		Table:         lb.Table.ValueString(),
+		Timeout:       lb.Timeout.ValueString(),
+		LeaseDuration: lb.LeaseDuration.ValueString(),
+		Holder:        lb.Holder.ValueString(),
+	}, nil
+}
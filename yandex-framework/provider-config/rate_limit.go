@@ -0,0 +1,27 @@
+package provider_config
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ratelimit"
+)
+
+type RateLimit struct {
+	Service           types.String  `tfsdk:"service"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+	MaxInFlight       types.Int64   `tfsdk:"max_in_flight"`
+}
+
+func (s *State) rateLimits() []ratelimit.Rule {
+	rules := make([]ratelimit.Rule, 0, len(s.RateLimit))
+	for _, l := range s.RateLimit {
+		rules = append(rules, ratelimit.Rule{
+			Service:           l.Service.ValueString(),
+			RequestsPerSecond: l.RequestsPerSecond.ValueFloat64(),
+			Burst:             int(l.Burst.ValueInt64()),
+			MaxInFlight:       int(l.MaxInFlight.ValueInt64()),
+		})
+	}
+	return rules
+}
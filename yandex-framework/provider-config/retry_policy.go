@@ -0,0 +1,78 @@
+package provider_config
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+)
+
+type RetryPolicy struct {
+	Codes                      []types.String `tfsdk:"codes"`
+	BaseBackoff                types.String   `tfsdk:"base_backoff"`
+	MaxBackoff                 types.String   `tfsdk:"max_backoff"`
+	MaxElapsedTime             types.String   `tfsdk:"max_elapsed_time"`
+	RetryConflictingOperations types.Bool     `tfsdk:"retry_conflicting_operations"`
+
+	MethodOverride []RetryPolicyMethodOverride `tfsdk:"method_override"`
+}
+
+type RetryPolicyMethodOverride struct {
+	Method                     types.String   `tfsdk:"method"`
+	MaxRetries                 types.Int64    `tfsdk:"max_retries"`
+	Codes                      []types.String `tfsdk:"codes"`
+	BaseBackoff                types.String   `tfsdk:"base_backoff"`
+	MaxBackoff                 types.String   `tfsdk:"max_backoff"`
+	MaxElapsedTime             types.String   `tfsdk:"max_elapsed_time"`
+	RetryConflictingOperations types.Bool     `tfsdk:"retry_conflicting_operations"`
+}
+
+// retryPolicy returns nil if there is no retry_policy block, so that the default retries are used.
+func (s *State) retryPolicy(maxRetries int) (*retrypolicy.Policy, error) {
+	if len(s.RetryPolicy) == 0 {
+		return nil, nil
+	}
+	if len(s.RetryPolicy) > 1 {
+		return nil, fmt.Errorf("at most one retry_policy block is allowed, got %d", len(s.RetryPolicy))
+	}
+	policy := s.RetryPolicy[0]
+
+	var overrides []retrypolicy.RuleConfig
+	for _, o := range policy.MethodOverride {
+		overrides = append(overrides, retrypolicy.RuleConfig{
+			Method:                     o.Method.ValueString(),
+			MaxRetries:                 intPointer(o.MaxRetries),
+			Codes:                      stringValues(o.Codes),
+			BaseBackoff:                o.BaseBackoff.ValueString(),
+			MaxBackoff:                 o.MaxBackoff.ValueString(),
+			MaxElapsedTime:             o.MaxElapsedTime.ValueString(),
+			RetryConflictingOperations: o.RetryConflictingOperations.ValueBoolPointer(),
+		})
+	}
+
+	return retrypolicy.NewPolicy(maxRetries, retrypolicy.RuleConfig{
+		Codes:                      stringValues(policy.Codes),
+		BaseBackoff:                policy.BaseBackoff.ValueString(),
+		MaxBackoff:                 policy.MaxBackoff.ValueString(),
+		MaxElapsedTime:             policy.MaxElapsedTime.ValueString(),
+		RetryConflictingOperations: policy.RetryConflictingOperations.ValueBoolPointer(),
+	}, overrides)
+}
+
+// intPointer returns nil for a null value, so that an explicit zero can be told from an unset value.
+func intPointer(v types.Int64) *int {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	i := int(v.ValueInt64())
+	return &i
+}
+
+func stringValues(values []types.String) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		result = append(result, v.ValueString())
+	}
+	return result
+}
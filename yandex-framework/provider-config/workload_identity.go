@@ -0,0 +1,35 @@
+package provider_config
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/workloadidentity"
+)
+
+type WorkloadIdentity struct {
+	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	Audience         types.String `tfsdk:"audience"`
+	TokenFile        types.String `tfsdk:"token_file"`
+	TokenEnv         types.String `tfsdk:"token_env"`
+	Endpoint         types.String `tfsdk:"endpoint"`
+}
+
+// workloadIdentity returns nil if there is no workload_identity block.
+func (s *State) workloadIdentity() (*workloadidentity.Config, error) {
+	if len(s.WorkloadIdentity) == 0 {
+		return nil, nil
+	}
+	if len(s.WorkloadIdentity) > 1 {
+		return nil, fmt.Errorf("at most one workload_identity block is allowed, got %d", len(s.WorkloadIdentity))
+	}
+	wi := s.WorkloadIdentity[0]
+	return &workloadidentity.Config{
+		ServiceAccountID: wi.ServiceAccountID.ValueString(),
+		Audience:         wi.Audience.ValueString(),
+		TokenFile:        wi.TokenFile.ValueString(),
+		TokenEnv:         wi.TokenEnv.ValueString(),
+		Endpoint:         wi.Endpoint.ValueString(),
+	}, nil
+}
@@ -7,10 +7,12 @@ import (
 	yandex_datasphere_community "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-datasphere/community"
 	yandex_datasphere_project "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-datasphere/project"
 	"os"
-	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -19,8 +21,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/functions"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/iam"
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
 	yandex_billing_cloud_binding "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-billing-cloud-binding"
+	yandex_iam_temporary_static_access_key "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-iam/temporary-static-access-key"
+	yandex_iam_token "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-iam/token"
+	yandex_lockbox_secret_version "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-lockbox/secret-version"
+	yandex_operation "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-operation"
+	yandex_storage_bucket "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-storage/bucket"
+	yandex_storage_object "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-storage/object"
 )
 
 type saKeyValidator struct{}
@@ -51,6 +61,11 @@ func (v saKeyValidator) ValidateString(ctx context.Context, req validator.String
 	}
 }
 
+var (
+	_ provider.ProviderWithEphemeralResources = &Provider{}
+	_ provider.ProviderWithFunctions          = &Provider{}
+)
+
 type Provider struct {
 	emptyFolder bool
 	config      provider_config.Config
@@ -158,77 +173,192 @@ func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp
 				Optional:    true,
 				Description: common.Descriptions["profile"],
 			},
+			"impersonate_service_account_id": schema.StringAttribute{
+				Optional:    true,
+				Description: common.Descriptions["impersonate_service_account_id"],
+			},
+			"default_labels": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: common.Descriptions["default_labels"],
+			},
+			"prevent_destroy_types": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: common.Descriptions["prevent_destroy_types"],
+			},
+			"iam_compare_before_set": schema.BoolAttribute{
+				Optional:    true,
+				Description: common.Descriptions["iam_compare_before_set"],
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"retry_policy":      retryPolicyBlock(),
+			"rate_limit":        rateLimitBlock(),
+			"workload_identity": workloadIdentityBlock(),
+			"lock_backend":      lockBackendBlock(),
 		},
 	}
 }
 
-func setToDefaultIfNeeded(field types.String, osEnvName string, defaultVal string) types.String {
-	if len(field.ValueString()) != 0 {
-		return field
-	}
-	field = types.StringValue(os.Getenv(osEnvName))
-	if len(field.ValueString()) == 0 {
-		field = types.StringValue(defaultVal)
+func retryPolicyRuleAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"codes": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: common.Descriptions["retry_policy.codes"],
+		},
+		"base_backoff": schema.StringAttribute{
+			Optional:    true,
+			Description: common.Descriptions["retry_policy.base_backoff"],
+		},
+		"max_backoff": schema.StringAttribute{
+			Optional:    true,
+			Description: common.Descriptions["retry_policy.max_backoff"],
+		},
+		"max_elapsed_time": schema.StringAttribute{
+			Optional:    true,
+			Description: common.Descriptions["retry_policy.max_elapsed_time"],
+		},
+		"retry_conflicting_operations": schema.BoolAttribute{
+			Optional:    true,
+			Description: common.Descriptions["retry_policy.retry_conflicting_operations"],
+		},
 	}
-	return field
 }
 
-func setToDefaultBoolIfNeeded(field types.Bool, osEnvName string, defaultVal bool) types.Bool {
-	if field.IsUnknown() || field.IsNull() {
-		env := os.Getenv(osEnvName)
-		v, err := strconv.ParseBool(env)
-		if err != nil {
-			return types.BoolValue(v)
-		}
-		return types.BoolValue(defaultVal)
+func retryPolicyBlock() schema.Block {
+	override := retryPolicyRuleAttributes()
+	override["method"] = schema.StringAttribute{
+		Required:    true,
+		Description: common.Descriptions["retry_policy.method_override.method"],
+	}
+	override["max_retries"] = schema.Int64Attribute{
+		Optional:    true,
+		Description: common.Descriptions["retry_policy.method_override.max_retries"],
+	}
+
+	return schema.ListNestedBlock{
+		Description: common.Descriptions["retry_policy"],
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: retryPolicyRuleAttributes(),
+			Blocks: map[string]schema.Block{
+				"method_override": schema.ListNestedBlock{
+					Description: common.Descriptions["retry_policy.method_override"],
+					NestedObject: schema.NestedBlockObject{
+						Attributes: override,
+					},
+				},
+			},
+		},
 	}
-	return field
 }
 
-func setDefaults(config provider_config.State) provider_config.State {
-	config.Endpoint = setToDefaultIfNeeded(config.Endpoint, "YC_ENDPOINT", common.DefaultEndpoint)
-	config.FolderID = setToDefaultIfNeeded(config.FolderID, "YC_FOLDER_ID", "")
-	config.CloudID = setToDefaultIfNeeded(config.CloudID, "YC_CLOUD_ID", "")
-	config.OrganizationID = setToDefaultIfNeeded(config.OrganizationID, "YC_ORGANIZATION_ID", "")
-	config.Region = setToDefaultIfNeeded(config.Region, "YC_REGION", common.DefaultRegion)
-	config.Zone = setToDefaultIfNeeded(config.Zone, "YC_ZONE", "")
-	config.Token = setToDefaultIfNeeded(config.Token, "YC_TOKEN", "")
-	config.ServiceAccountKeyFileOrContent = setToDefaultIfNeeded(config.ServiceAccountKeyFileOrContent, "YC_SERVICE_ACCOUNT_KEY_FILE", "")
-	config.StorageEndpoint = setToDefaultIfNeeded(config.StorageEndpoint, "YC_STORAGE_ENDPOINT_URL", common.DefaultStorageEndpoint)
-	config.StorageAccessKey = setToDefaultIfNeeded(config.StorageAccessKey, "YC_STORAGE_ACCESS_KEY", "")
-	config.StorageSecretKey = setToDefaultIfNeeded(config.StorageSecretKey, "YC_STORAGE_SECRET_KEY", "")
-	config.YMQEndpoint = setToDefaultIfNeeded(config.YMQEndpoint, "YC_MESSAGE_QUEUE_ENDPOINT", common.DefaultYMQEndpoint)
-	config.YMQAccessKey = setToDefaultIfNeeded(config.YMQAccessKey, "YC_MESSAGE_QUEUE_ACCESS_KEY", "")
-	config.YMQSecretKey = setToDefaultIfNeeded(config.YMQSecretKey, "YC_MESSAGE_QUEUE_SECRET_KEY", "")
+func rateLimitBlock() schema.Block {
+	return schema.ListNestedBlock{
+		Description: common.Descriptions["rate_limit"],
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"service": schema.StringAttribute{
+					Required:    true,
+					Description: common.Descriptions["rate_limit.service"],
+				},
+				"requests_per_second": schema.Float64Attribute{
+					Optional:    true,
+					Description: common.Descriptions["rate_limit.requests_per_second"],
+				},
+				"burst": schema.Int64Attribute{
+					Optional:    true,
+					Description: common.Descriptions["rate_limit.burst"],
+				},
+				"max_in_flight": schema.Int64Attribute{
+					Optional:    true,
+					Description: common.Descriptions["rate_limit.max_in_flight"],
+				},
+			},
+		},
+	}
+}
 
-	config.Insecure = setToDefaultBoolIfNeeded(config.Insecure, "YC_INSECURE", false)
-	config.Plaintext = setToDefaultBoolIfNeeded(config.Plaintext, "YC_PLAINTEXT", false)
+func workloadIdentityBlock() schema.Block {
+	return schema.ListNestedBlock{
+		Description: common.Descriptions["workload_identity"],
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"service_account_id": schema.StringAttribute{
+					Required:    true,
+					Description: common.Descriptions["workload_identity.service_account_id"],
+				},
+				"audience": schema.StringAttribute{
+					Optional:    true,
+					Description: common.Descriptions["workload_identity.audience"],
+				},
+				"token_file": schema.StringAttribute{
+					Optional:    true,
+					Description: common.Descriptions["workload_identity.token_file"],
+				},
+				"token_env": schema.StringAttribute{
+					Optional:    true,
+					Description: common.Descriptions["workload_identity.token_env"],
+				},
+				"endpoint": schema.StringAttribute{
+					Optional:    true,
+					Description: common.Descriptions["workload_identity.endpoint"],
+				},
+			},
+		},
+	}
+}
 
-	if config.MaxRetries.IsUnknown() || config.MaxRetries.IsNull() {
-		config.MaxRetries = types.Int64Value(common.DefaultMaxRetries)
+func lockBackendBlock() schema.Block {
+	attributes := map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			Required:    true,
+			Description: common.Descriptions["lock_backend.type"],
+		},
 	}
-	if config.Profile.IsUnknown() || config.Profile.IsNull() {
-		config.Profile = types.StringValue("default")
+	for _, name := range []string{"path", "bucket", "prefix", "endpoint", "table", "timeout", "lease_duration", "holder"} {
+		attributes[name] = schema.StringAttribute{
+			Optional:    true,
+			Description: common.Descriptions["lock_backend."+name],
+		}
 	}
 
-	return config
+	return schema.ListNestedBlock{
+		Description: common.Descriptions["lock_backend"],
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: attributes,
+		},
+	}
 }
 
 func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	// Unmarshal config
 	p.config = provider_config.Config{}
 	resp.Diagnostics.Append(req.Config.Get(ctx, &p.config.ProviderState)...)
-	p.config.UserAgent = types.StringValue(req.TerraformVersion)
-	p.config.ProviderState = setDefaults(p.config.ProviderState)
-	if p.emptyFolder {
-		p.config.ProviderState.FolderID = types.StringValue("")
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	if err := p.config.InitAndValidate(ctx, req.TerraformVersion, false); err != nil {
 		resp.Diagnostics.AddError("Failed to configure", err.Error())
 	}
+	p.config.ConfigureLocks(iam.MutexKV)
+	if p.emptyFolder {
+		p.config.ProviderState.FolderID = types.StringValue("")
+	}
 	resp.ResourceData = &p.config
 	resp.DataSourceData = &p.config
+	resp.EphemeralResourceData = &p.config
 }
 
 func (p *Provider) Resources(_ context.Context) []func() resource.Resource {
@@ -240,8 +370,14 @@ func (p *Provider) Resources(_ context.Context) []func() resource.Resource {
 		},
 		yandex_datasphere_project.NewResource,
 		yandex_datasphere_project.NewIamBinding,
+		yandex_datasphere_project.NewIamMember,
+		yandex_datasphere_project.NewIamPolicy,
 		yandex_datasphere_community.NewResource,
 		yandex_datasphere_community.NewIamBinding,
+		yandex_datasphere_community.NewIamMember,
+		yandex_datasphere_community.NewIamPolicy,
+		yandex_storage_bucket.NewResource,
+		yandex_storage_object.NewResource,
 	}
 }
 
@@ -257,6 +393,24 @@ func (p *Provider) DataSources(_ context.Context) []func() datasource.DataSource
 	}
 }
 
+func (p *Provider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		yandex_lockbox_secret_version.NewEphemeralResource,
+		yandex_iam_token.NewEphemeralResource,
+		yandex_iam_temporary_static_access_key.NewEphemeralResource,
+		yandex_operation.NewEphemeralResource,
+	}
+}
+
+func (p *Provider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewParseVirtualHostIDFunction,
+		functions.NewIAMMemberFunction,
+		functions.NewCIDRSubnetsForZonesFunction,
+		functions.NewS3WebsiteEndpointFunction,
+	}
+}
+
 func (p *Provider) GetConfig() provider_config.Config {
 	return p.config
 }
@@ -188,7 +188,7 @@ func setTestIDs() error {
 			ServiceAccountKeyFileOrContent: types.StringValue(os.Getenv("YC_SERVICE_ACCOUNT_KEY_FILE")),
 		},
 	}
-	credentials, err := providerConfig.Credentials(ctx)
+	credentials, _, err := providerConfig.Credentials(ctx)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDatasphereProjectResourceIamMember(t *testing.T) {
+	communityName := acctest.RandStringFromCharSet(63, acctest.CharSetAlpha)
+	projectName := acctest.RandStringFromCharSet(63, acctest.CharSetAlpha)
+
+	userID := "allUsers"
+	role := "datasphere.community-projects.viewer"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasphereProjectIamMemberConfig(communityName, projectName, role, userID),
+				Check: resource.ComposeTestCheckFunc(
+					testDatasphereProjectExists(testProjectResourceName),
+					testAccCheckDatasphereProjectIam(testProjectResourceName, role, []string{"system:" + userID}),
+				),
+			},
+			{
+				ResourceName:                         "yandex_datasphere_project_iam_member.test-project-member",
+				ImportStateIdFunc:                    importIamMemberIdFunc(testProjectResourceName, role, "system:"+userID),
+				ImportState:                          true,
+				ImportStateVerifyIdentifierAttribute: "project_id",
+			},
+		},
+	})
+}
+
+func TestAccDatasphereProjectResourceIamPolicy(t *testing.T) {
+	communityName := acctest.RandStringFromCharSet(63, acctest.CharSetAlpha)
+	projectName := acctest.RandStringFromCharSet(63, acctest.CharSetAlpha)
+
+	userID := "allUsers"
+	role := "datasphere.community-projects.viewer"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasphereProjectIamPolicyConfig(communityName, projectName, role, userID),
+				Check: resource.ComposeTestCheckFunc(
+					testDatasphereProjectExists(testProjectResourceName),
+					testAccCheckDatasphereProjectIam(testProjectResourceName, role, []string{"system:" + userID}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasphereProjectIamMemberConfig(communityName, projectName, role, userID string) string {
+	return fmt.Sprintf(`
+resource "yandex_datasphere_community" "test-community" {
+  name = "%s"
+  billing_account_id = "%s"
+  organization_id = "%s"
+}
+
+resource "yandex_datasphere_project_iam_member" "test-project-member" {
+  role = "%s"
+  member = "system:%s"
+  project_id = yandex_datasphere_project.test-project.id
+}
+
+resource "yandex_datasphere_project" "test-project" {
+  name = "%s"
+  community_id = yandex_datasphere_community.test-community.id
+}
+`, communityName, getBillingAccountId(), getExampleOrganizationID(), role, userID, projectName)
+}
+
+func testAccDatasphereProjectIamPolicyConfig(communityName, projectName, role, userID string) string {
+	return fmt.Sprintf(`
+resource "yandex_datasphere_community" "test-community" {
+  name = "%s"
+  billing_account_id = "%s"
+  organization_id = "%s"
+}
+
+data "yandex_iam_policy" "test-project-policy" {
+  binding {
+    role    = "%s"
+    members = ["system:%s"]
+  }
+}
+
+resource "yandex_datasphere_project_iam_policy" "test-project-policy" {
+  policy_data = data.yandex_iam_policy.test-project-policy.policy_data
+  project_id  = yandex_datasphere_project.test-project.id
+}
+
+resource "yandex_datasphere_project" "test-project" {
+  name = "%s"
+  community_id = yandex_datasphere_community.test-community.id
+}
+`, communityName, getBillingAccountId(), getExampleOrganizationID(), role, userID, projectName)
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testTemporaryStaticAccessKeyEchoName = "echo.test-access-key"
+
+func TestAccIamTemporaryStaticAccessKeyEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"yandex": testAccProviderFactories["yandex"],
+			"echo":   echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testIamTemporaryStaticAccessKeyEphemeralConfig(getExampleFolderID()),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(testTemporaryStaticAccessKeyEchoName, tfjsonpath.New("data").AtMapKey("folder_id"),
+						knownvalue.StringExact(getExampleFolderID())),
+					statecheck.ExpectKnownValue(testTemporaryStaticAccessKeyEchoName, tfjsonpath.New("data").AtMapKey("service_account_id"),
+						knownvalue.NotNull()),
+					statecheck.ExpectKnownValue(testTemporaryStaticAccessKeyEchoName, tfjsonpath.New("data").AtMapKey("access_key"),
+						knownvalue.NotNull()),
+					statecheck.ExpectKnownValue(testTemporaryStaticAccessKeyEchoName, tfjsonpath.New("data").AtMapKey("secret_key"),
+						knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func testIamTemporaryStaticAccessKeyEphemeralConfig(folderID string) string {
+	return fmt.Sprintf(`
+ephemeral "yandex_iam_temporary_static_access_key" "test-access-key" {
+  folder_id = "%s"
+  role      = "storage.viewer"
+}
+
+provider "echo" {
+  data = ephemeral.yandex_iam_temporary_static_access_key.test-access-key
+}
+
+resource "echo" "test-access-key" {}
+`, folderID)
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testIamTokenEphemeralEchoName = "echo.test-iam-token"
+
+func TestAccIamTokenEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"yandex": testAccProviderFactories["yandex"],
+			"echo":   echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testIamTokenEphemeralConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(testIamTokenEphemeralEchoName, tfjsonpath.New("data").AtMapKey("iam_token"),
+						knownvalue.NotNull()),
+					statecheck.ExpectKnownValue(testIamTokenEphemeralEchoName, tfjsonpath.New("data").AtMapKey("expires_at"),
+						knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+const testIamTokenEphemeralConfig = `
+ephemeral "yandex_iam_token" "test-iam-token" {}
+
+provider "echo" {
+  data = ephemeral.yandex_iam_token.test-iam-token
+}
+
+resource "echo" "test-iam-token" {}
+`
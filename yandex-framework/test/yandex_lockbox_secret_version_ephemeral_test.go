@@ -0,0 +1,75 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testLockboxEphemeralEchoName = "echo.test-secret-version"
+
+func TestAccLockboxSecretVersionEphemeralResource(t *testing.T) {
+	secretName := "a" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"yandex": testAccProviderFactories["yandex"],
+			"echo":   echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testLockboxSecretVersionEphemeralConfig(secretName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(testLockboxEphemeralEchoName, tfjsonpath.New("data").AtMapKey("entries"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"key":        knownvalue.StringExact("password"),
+								"text_value": knownvalue.StringExact("secret-value"),
+							}),
+						})),
+					statecheck.ExpectKnownValue(testLockboxEphemeralEchoName, tfjsonpath.New("data").AtMapKey("version_id"),
+						knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
+
+func testLockboxSecretVersionEphemeralConfig(secretName string) string {
+	return fmt.Sprintf(`
+resource "yandex_lockbox_secret" "test-secret" {
+  name = "%s"
+}
+
+resource "yandex_lockbox_secret_version" "test-version" {
+  secret_id = yandex_lockbox_secret.test-secret.id
+  entries {
+    key        = "password"
+    text_value = "secret-value"
+  }
+}
+
+ephemeral "yandex_lockbox_secret_version" "test-secret-version" {
+  secret_id  = yandex_lockbox_secret.test-secret.id
+  version_id = yandex_lockbox_secret_version.test-version.id
+}
+
+provider "echo" {
+  data = ephemeral.yandex_lockbox_secret_version.test-secret-version
+}
+
+resource "echo" "test-secret-version" {}
+`, secretName)
+}
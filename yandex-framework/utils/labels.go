@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+// ExpandLabels converts a labels map attribute into a Go map. Null and unknown values produce an empty map.
+func ExpandLabels(ctx context.Context, labels types.Map) (map[string]string, diag.Diagnostics) {
+	result := make(map[string]string, len(labels.Elements()))
+	if labels.IsNull() || labels.IsUnknown() {
+		return result, nil
+	}
+	diags := labels.ElementsAs(ctx, &result, false)
+	return result, diags
+}
+
+// MergeDefaultLabels returns the labels to be sent to the API: the provider default labels
+// overlaid with the labels set on the resource itself.
+func MergeDefaultLabels(ctx context.Context, defaults types.Map, own types.Map) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	defaultLabels, d := ExpandLabels(ctx, defaults)
+	diags.Append(d...)
+	ownLabels, d := ExpandLabels(ctx, own)
+	diags.Append(d...)
+
+	return common.MergeLabels(defaultLabels, ownLabels), diags
+}
+
+// StripDefaultLabels splits the labels returned by the API into the value of the labels attribute,
+// i.e. all of them except the provider default ones not set on the resource itself, and the value
+// of the all_labels attribute. previousAll is the all_labels value planned or read before, it tells
+// the labels derived from the defaults even if they changed since then. The labels value is null
+// if there is nothing left and the resource had no labels attribute set, all_labels is never null.
+func StripDefaultLabels(ctx context.Context, all, own, previousAll, defaults types.Map) (types.Map, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	allLabels, d := ExpandLabels(ctx, all)
+	diags.Append(d...)
+	ownLabels, d := ExpandLabels(ctx, own)
+	diags.Append(d...)
+	previousAllLabels, d := ExpandLabels(ctx, previousAll)
+	diags.Append(d...)
+	defaultLabels, d := ExpandLabels(ctx, defaults)
+	diags.Append(d...)
+	if diags.HasError() {
+		return own, all, diags
+	}
+
+	allValue, d := types.MapValueFrom(ctx, types.StringType, allLabels)
+	diags.Append(d...)
+
+	labels := common.StripDefaultLabels(allLabels, ownLabels, previousAllLabels, defaultLabels)
+	if len(labels) == 0 && own.IsNull() {
+		return types.MapNull(types.StringType), allValue, diags
+	}
+
+	labelsValue, d := types.MapValueFrom(ctx, types.StringType, labels)
+	diags.Append(d...)
+	return labelsValue, allValue, diags
+}
+
+// ModifyPlanAllLabels plans the all_labels attribute of a resource as its labels merged with
+// the provider default labels, so that changes of the defaults show up as resource updates.
+func ModifyPlanAllLabels(ctx context.Context, defaults types.Map, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var labels types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() || labels.IsUnknown() {
+		return
+	}
+
+	merged, diags := MergeDefaultLabels(ctx, defaults, labels)
+	resp.Diagnostics.Append(diags...)
+	allLabels, diags := types.MapValueFrom(ctx, types.StringType, merged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("all_labels"), allLabels)...)
+}
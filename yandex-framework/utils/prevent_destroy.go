@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+// preventDestroyLabelAttributes are the attributes whose values are matched against the labels
+// listed in the provider prevent_destroy_types, the first one present in the schema is used.
+var preventDestroyLabelAttributes = []string{"all_labels", "labels", "tags"}
+
+// PreventDestroy reports whether the Delete of the resource in state is rejected by the provider
+// prevent_destroy_types, in which case the error is added to diags.
+func PreventDestroy(ctx context.Context, rules common.PreventDestroyRules, resourceType string, state tfsdk.State, diags *diag.Diagnostics) bool {
+	if rules.IsEmpty() {
+		return false
+	}
+
+	var labels map[string]string
+	for _, name := range preventDestroyLabelAttributes {
+		attribute, d := state.Schema.AttributeAtPath(ctx, path.Root(name))
+		if d.HasError() || !attribute.GetType().Equal(types.MapType{ElemType: types.StringType}) {
+			continue
+		}
+		var value types.Map
+		diags.Append(state.GetAttribute(ctx, path.Root(name), &value)...)
+		m, d := ExpandLabels(ctx, value)
+		diags.Append(d...)
+		labels = m
+		break
+	}
+	if diags.HasError() {
+		return true
+	}
+
+	entry, ok := rules.Match(resourceType, labels)
+	if !ok {
+		return false
+	}
+
+	var id types.String
+	if _, d := state.Schema.AttributeAtPath(ctx, path.Root("id")); !d.HasError() {
+		diags.Append(state.GetAttribute(ctx, path.Root("id"), &id)...)
+	}
+	diags.AddError("Resource Is Protected From Deletion", common.PreventDestroyError(resourceType, id.ValueString(), entry).Error())
+	return true
+}
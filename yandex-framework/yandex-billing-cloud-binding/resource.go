@@ -14,6 +14,7 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/utils"
 )
 
 type bindingResource struct {
@@ -151,6 +152,9 @@ func (r *bindingResource) Update(ctx context.Context,
 
 func (r *bindingResource) Delete(ctx context.Context,
 	req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if utils.PreventDestroy(ctx, r.providerConfig.PreventDestroy, "yandex_billing_cloud_binding", req.State, &resp.Diagnostics) {
+		return
+	}
 	log.Printf("[INFO] The resource of binding to billign account is deleted " +
 		"however the binding itself still exists. " +
 		"This is an excepted behaviour. See documentation for details.")
@@ -12,6 +12,7 @@ type communityDataModel struct {
 	Name             types.String   `tfsdk:"name"`
 	Description      types.String   `tfsdk:"description"`
 	Labels           types.Map      `tfsdk:"labels"`
+	AllLabels        types.Map      `tfsdk:"all_labels"`
 	OrganizationId   types.String   `tfsdk:"organization_id"`
 	BillingAccountId types.String   `tfsdk:"billing_account_id"`
 	Timeouts         timeouts.Value `tfsdk:"timeouts"`
@@ -61,12 +61,9 @@ func (r *communityResource) Create(ctx context.Context, req resource.CreateReque
 		OrganizationId:   plannedCommunity.OrganizationId.ValueString(),
 		BillingAccountId: plannedCommunity.BillingAccountId.ValueString(),
 	}
-	if !plannedCommunity.Labels.IsNull() && !plannedCommunity.Labels.IsUnknown() {
-		labels := make(map[string]string, len(plannedCommunity.Labels.Elements()))
-		resp.Diagnostics.Append(plannedCommunity.Labels.ElementsAs(ctx, &labels, false)...)
-		createCommunityRequestData.SetLabels(labels)
-
-	}
+	labels, diags := utils.MergeDefaultLabels(ctx, r.providerConfig.ProviderState.DefaultLabels, plannedCommunity.Labels)
+	resp.Diagnostics.Append(diags...)
+	createCommunityRequestData.SetLabels(labels)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -122,7 +119,9 @@ func (r *communityResource) Create(ctx context.Context, req resource.CreateReque
 
 	plannedCommunity.Id = types.StringValue(createdCommunity.Id)
 
+	ownLabels, previousAllLabels := plannedCommunity.Labels, plannedCommunity.AllLabels
 	convertToTerraformModel(ctx, &plannedCommunity, createdCommunity, &resp.Diagnostics)
+	stripDefaultLabels(ctx, &plannedCommunity, ownLabels, previousAllLabels, r.providerConfig.ProviderState.DefaultLabels, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plannedCommunity)...)
 }
@@ -155,7 +154,9 @@ func (r *communityResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ownLabels, previousAllLabels := stateCommunity.Labels, stateCommunity.AllLabels
 	convertToTerraformModel(ctx, &stateCommunity, existingCommunity, &resp.Diagnostics)
+	stripDefaultLabels(ctx, &stateCommunity, ownLabels, previousAllLabels, r.providerConfig.ProviderState.DefaultLabels, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCommunity)...)
 }
@@ -191,10 +192,10 @@ func (r *communityResource) Update(ctx context.Context, req resource.UpdateReque
 	if !plannedCommunity.Name.Equal(stateCommunity.Name) {
 		updatePaths = append(updatePaths, "name")
 	}
-	if !plannedCommunity.Labels.Equal(stateCommunity.Labels) {
+	if !plannedCommunity.Labels.Equal(stateCommunity.Labels) || !plannedCommunity.AllLabels.Equal(stateCommunity.AllLabels) {
 		updatePaths = append(updatePaths, "labels")
-		labels := make(map[string]string, len(plannedCommunity.Labels.Elements()))
-		resp.Diagnostics.Append(plannedCommunity.Labels.ElementsAs(ctx, &labels, false)...)
+		labels, diags := utils.MergeDefaultLabels(ctx, r.providerConfig.ProviderState.DefaultLabels, plannedCommunity.Labels)
+		resp.Diagnostics.Append(diags...)
 		updateCommunityRequest.SetLabels(labels)
 	}
 
@@ -259,13 +260,18 @@ func (r *communityResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Community was update with following parameters %+v", updatedCommunity))
+	ownLabels, previousAllLabels := plannedCommunity.Labels, plannedCommunity.AllLabels
 	convertToTerraformModel(ctx, &plannedCommunity, updatedCommunity, &resp.Diagnostics)
+	stripDefaultLabels(ctx, &plannedCommunity, ownLabels, previousAllLabels, r.providerConfig.ProviderState.DefaultLabels, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plannedCommunity)...)
 }
 
 func (r *communityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Info(ctx, "Deleting community resource")
+	if utils.PreventDestroy(ctx, r.providerConfig.PreventDestroy, "yandex_datasphere_community", req.State, &resp.Diagnostics) {
+		return
+	}
 
 	var stateCommunity communityDataModel
 
@@ -347,6 +353,13 @@ func (r *communityResource) Configure(_ context.Context, req resource.ConfigureR
 	r.providerConfig = providerConfig
 }
 
+func (r *communityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerConfig == nil {
+		return
+	}
+	utils.ModifyPlanAllLabels(ctx, r.providerConfig.ProviderState.DefaultLabels, req, resp)
+}
+
 func (r *communityResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	tflog.Info(ctx, "Initializing community resource schema")
 	resp.Schema = schema.Schema{
@@ -417,6 +430,10 @@ func (r *communityResource) Schema(ctx context.Context, _ resource.SchemaRequest
 					),
 				},
 			},
+			"all_labels": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
 				Update: true,
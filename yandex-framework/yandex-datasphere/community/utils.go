@@ -19,5 +19,15 @@ func convertToTerraformModel(ctx context.Context, terraformModel *communityDataM
 
 	labels, diags := types.MapValueFrom(ctx, types.StringType, grpcModel.Labels)
 	terraformModel.Labels = labels
+	terraformModel.AllLabels = labels
+	diag.Append(diags...)
+}
+
+// stripDefaultLabels leaves in the labels attribute only the labels set on the community itself,
+// the provider default labels are kept in all_labels only.
+func stripDefaultLabels(ctx context.Context, terraformModel *communityDataModel, ownLabels, previousAllLabels, defaultLabels types.Map, diag *diag.Diagnostics) {
+	labels, allLabels, diags := utils.StripDefaultLabels(ctx, terraformModel.AllLabels, ownLabels, previousAllLabels, defaultLabels)
+	terraformModel.Labels = labels
+	terraformModel.AllLabels = allLabels
 	diag.Append(diags...)
 }
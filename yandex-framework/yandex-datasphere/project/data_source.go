@@ -43,6 +43,10 @@ func (d *projectDataSource) Schema(ctx context.Context, _ datasource.SchemaReque
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"all_labels": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"settings": schema.SingleNestedAttribute{
 				Attributes: map[string]schema.Attribute{
 					"service_account_id":   schema.StringAttribute{Computed: true},
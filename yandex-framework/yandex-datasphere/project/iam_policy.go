@@ -0,0 +1,10 @@
+package yandex_datasphere_project
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	iam_binding "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/iam"
+)
+
+func NewIamPolicy() resource.Resource {
+	return iam_binding.NewIamPolicy(newProjectIamUpdater())
+}
@@ -12,6 +12,7 @@ type projectDataModel struct {
 	Name        types.String   `tfsdk:"name"`
 	Description types.String   `tfsdk:"description"`
 	Labels      types.Map      `tfsdk:"labels"`
+	AllLabels   types.Map      `tfsdk:"all_labels"`
 	CreatedBy   types.String   `tfsdk:"created_by"`
 	Settings    types.Object   `tfsdk:"settings"`
 	Limits      types.Object   `tfsdk:"limits"`
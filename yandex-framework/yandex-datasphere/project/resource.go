@@ -54,12 +54,9 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		CommunityId: plannedProject.CommunityId.ValueString(),
 		Description: plannedProject.Description.ValueString(),
 	}
-	if !plannedProject.Labels.IsNull() && !plannedProject.Labels.IsUnknown() {
-		labels := make(map[string]string, len(plannedProject.Labels.Elements()))
-		resp.Diagnostics.Append(plannedProject.Labels.ElementsAs(ctx, &labels, false)...)
-		createProjectRequestData.SetLabels(labels)
-
-	}
+	labels, diags := utils.MergeDefaultLabels(ctx, r.providerConfig.ProviderState.DefaultLabels, plannedProject.Labels)
+	resp.Diagnostics.Append(diags...)
+	createProjectRequestData.SetLabels(labels)
 
 	if plannedProject.Settings.IsNull() || plannedProject.Settings.IsUnknown() {
 		tflog.Info(ctx, "Settings field is not set, would be used default settings")
@@ -79,10 +76,6 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 			DefaultFolderId:   settings.DefaultFolderId.ValueString(),
 		}
 
-		if !settings.CommitMode.IsNull() && !settings.CommitMode.IsUnknown() {
-			createProjectSettingsRequestData.SetCommitMode(datasphere.Project_Settings_CommitMode(
-				datasphere.Project_Settings_CommitMode_value[settings.CommitMode.ValueString()]))
-		}
 		if !settings.SecurityGroupIds.IsNull() && !settings.SecurityGroupIds.IsUnknown() {
 			settingsSecurityGroups := make([]string, 0, len(settings.SecurityGroupIds.Elements()))
 			resp.Diagnostics.Append(settings.SecurityGroupIds.ElementsAs(ctx, &settingsSecurityGroups, false)...)
@@ -198,7 +191,9 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Project with following id %s was created", createdProject.Id))
+	ownLabels, previousAllLabels := plannedProject.Labels, plannedProject.AllLabels
 	convertToTerraformModel(ctx, &plannedProject, createdProject, &resp.Diagnostics, updatedBalance)
+	stripDefaultLabels(ctx, &plannedProject, ownLabels, previousAllLabels, r.providerConfig.ProviderState.DefaultLabels, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plannedProject)...)
 }
@@ -235,7 +230,9 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	ownLabels, previousAllLabels := stateProject.Labels, stateProject.AllLabels
 	convertToTerraformModel(ctx, &stateProject, existingProject, &resp.Diagnostics, unitBalance.UnitBalance)
+	stripDefaultLabels(ctx, &stateProject, ownLabels, previousAllLabels, r.providerConfig.ProviderState.DefaultLabels, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &stateProject)...)
 }
@@ -271,10 +268,10 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	if !planProject.Name.Equal(stateProject.Name) {
 		updatePaths = append(updatePaths, "name")
 	}
-	if !planProject.Labels.Equal(stateProject.Labels) {
+	if !planProject.Labels.Equal(stateProject.Labels) || !planProject.AllLabels.Equal(stateProject.AllLabels) {
 		updatePaths = append(updatePaths, "labels")
-		labels := make(map[string]string, len(planProject.Labels.Elements()))
-		resp.Diagnostics.Append(planProject.Labels.ElementsAs(ctx, &labels, false)...)
+		labels, diags := utils.MergeDefaultLabels(ctx, r.providerConfig.ProviderState.DefaultLabels, planProject.Labels)
+		resp.Diagnostics.Append(diags...)
 		updateProjectRequest.SetLabels(labels)
 	}
 	if !planProject.Settings.Equal(stateProject.Settings) {
@@ -299,11 +296,6 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		if !planProjectSettings.DataProcClusterId.Equal(stateProjectSettings.DataProcClusterId) {
 			updatePaths = append(updatePaths, pathPrefix+"data_proc_cluster_id")
 		}
-		if !planProjectSettings.CommitMode.Equal(stateProjectSettings.CommitMode) {
-			updatePaths = append(updatePaths, pathPrefix+"commit_mode")
-			updateProjectSettingsRequestData.SetCommitMode(datasphere.Project_Settings_CommitMode(
-				datasphere.Project_Settings_CommitMode_value[planProjectSettings.CommitMode.ValueString()]))
-		}
 		if !planProjectSettings.SecurityGroupIds.Equal(stateProjectSettings.SecurityGroupIds) {
 			updatePaths = append(updatePaths, pathPrefix+"security_group_ids")
 			settingsSecurityGroups := make([]string, 0, len(planProjectSettings.SecurityGroupIds.Elements()))
@@ -447,13 +439,18 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 			updatedBalance,
 		),
 	)
+	ownLabels, previousAllLabels := planProject.Labels, planProject.AllLabels
 	convertToTerraformModel(ctx, &planProject, updatedProject, &resp.Diagnostics, updatedBalance)
+	stripDefaultLabels(ctx, &planProject, ownLabels, previousAllLabels, r.providerConfig.ProviderState.DefaultLabels, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &planProject)...)
 }
 
 func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Info(ctx, "Deleting project resource")
+	if utils.PreventDestroy(ctx, r.providerConfig.PreventDestroy, "yandex_datasphere_project", req.State, &resp.Diagnostics) {
+		return
+	}
 	var stateProject projectDataModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &stateProject)...)
@@ -516,6 +513,13 @@ func (r *projectResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.providerConfig = providerConfig
 }
 
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerConfig == nil {
+		return
+	}
+	utils.ModifyPlanAllLabels(ctx, r.providerConfig.ProviderState.DefaultLabels, req, resp)
+}
+
 func (r *projectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	tflog.Info(ctx, "Initializing schema")
 
@@ -581,6 +585,10 @@ func (r *projectResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 					),
 				},
 			},
+			"all_labels": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"settings": schema.SingleNestedAttribute{
 				Attributes: map[string]schema.Attribute{
 					"service_account_id": schema.StringAttribute{
@@ -605,8 +613,9 @@ func (r *projectResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 						},
 					},
 					"commit_mode": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
+						Optional:           true,
+						Computed:           true,
+						DeprecationMessage: "The commit mode is no longer supported by the DataSphere API and is ignored.",
 						PlanModifiers: []planmodifier.String{
 							stringplanmodifier.UseStateForUnknown(),
 						},
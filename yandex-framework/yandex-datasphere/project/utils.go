@@ -20,6 +20,7 @@ func convertToTerraformModel(ctx context.Context, terraformModel *projectDataMod
 
 	labels, diags := types.MapValueFrom(ctx, types.StringType, grpcModel.Labels)
 	terraformModel.Labels = labels
+	terraformModel.AllLabels = labels
 	diag.Append(diags...)
 
 	if grpcModel.Settings != nil {
@@ -28,7 +29,12 @@ func convertToTerraformModel(ctx context.Context, terraformModel *projectDataMod
 		settings.ServiceAccountId = types.StringValue(grpcModel.Settings.ServiceAccountId)
 		settings.SubnetId = types.StringValue(grpcModel.Settings.SubnetId)
 		settings.DataProcClusterId = types.StringValue(grpcModel.Settings.DataProcClusterId)
-		settings.CommitMode = types.StringValue(grpcModel.Settings.CommitMode.String())
+		// The API no longer has the commit mode, so the configured one is kept as is.
+		settings.CommitMode = types.StringValue("COMMIT_MODE_UNSPECIFIED")
+		if commitMode, ok := terraformModel.Settings.Attributes()["commit_mode"].(types.String); ok &&
+			!commitMode.IsNull() && !commitMode.IsUnknown() {
+			settings.CommitMode = commitMode
+		}
 
 		if grpcModel.Settings.SecurityGroupIds != nil && len(grpcModel.Settings.SecurityGroupIds) > 0 {
 			securityGroups, diags := types.SetValueFrom(ctx, types.StringType, grpcModel.Settings.SecurityGroupIds)
@@ -60,3 +66,12 @@ func convertToTerraformModel(ctx context.Context, terraformModel *projectDataMod
 		terraformModel.Limits = limitsObject
 	}
 }
+
+// stripDefaultLabels leaves in the labels attribute only the labels set on the project itself,
+// the provider default labels are kept in all_labels only.
+func stripDefaultLabels(ctx context.Context, terraformModel *projectDataModel, ownLabels, previousAllLabels, defaultLabels types.Map, diag *diag.Diagnostics) {
+	labels, allLabels, diags := utils.StripDefaultLabels(ctx, terraformModel.AllLabels, ownLabels, previousAllLabels, defaultLabels)
+	terraformModel.Labels = labels
+	terraformModel.AllLabels = allLabels
+	diag.Append(diags...)
+}
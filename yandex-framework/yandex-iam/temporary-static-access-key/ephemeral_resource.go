@@ -0,0 +1,153 @@
+package yandex_iam_temporary_static_access_key
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common/client"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/iam"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+)
+
+// privateStateKey is the key of the private state the created key is kept under until Close.
+const privateStateKey = "temporary_static_access_key"
+
+// accessKeyEphemeralResource creates a static access key of a temporary service account
+// each time Terraform opens it, and deletes both when Terraform closes it.
+type accessKeyEphemeralResource struct {
+	providerConfig *provider_config.Config
+}
+
+func NewEphemeralResource() ephemeral.EphemeralResource {
+	return &accessKeyEphemeralResource{}
+}
+
+var _ ephemeral.EphemeralResourceWithClose = &accessKeyEphemeralResource{}
+
+func (r *accessKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_temporary_static_access_key"
+}
+
+func (r *accessKeyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a static access key of a temporary service account with a role in a folder. " +
+			"The key and the service account are deleted as soon as Terraform does not need them anymore.",
+		Attributes: map[string]schema.Attribute{
+			"folder_id": schema.StringAttribute{
+				Description: "ID of the folder to create the temporary service account in. If it is not set, the provider `folder_id` is used.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"role": schema.StringAttribute{
+				Description: "The role the temporary service account is granted in the folder.",
+				Required:    true,
+			},
+			"service_account_id": schema.StringAttribute{
+				Description: "ID of the temporary service account.",
+				Computed:    true,
+			},
+			"access_key": schema.StringAttribute{
+				Description: "ID of the static access key.",
+				Computed:    true,
+			},
+			"secret_key": schema.StringAttribute{
+				Description: "Private part of the static access key.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *accessKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model accessKeyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	folderID := model.FolderID.ValueString()
+	if folderID == "" {
+		folderID = r.providerConfig.ProviderState.FolderID.ValueString()
+	}
+	if folderID == "" {
+		resp.Diagnostics.AddError(
+			"Unable to create temporary static access key",
+			"Cannot determine folder_id: please set 'folder_id' key in this resource or at provider level",
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating temporary static access key with role %s in folder %s", model.Role.ValueString(), folderID))
+
+	key, err := client.CreateTemporaryStaticAccessKey(ctx, r.providerConfig.SDK, folderID, model.Role.ValueString(), iam.MutexKV)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create temporary static access key",
+			fmt.Sprintf("Error while creating temporary static access key: %s", err),
+		)
+		return
+	}
+
+	// The secret key is not needed to delete the key, so it is not kept in the private state.
+	private := *key
+	private.SecretKey = ""
+	privateJSON, err := json.Marshal(private)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to save temporary static access key", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateStateKey, privateJSON)...)
+
+	model.FolderID = types.StringValue(folderID)
+	model.ServiceAccountID = types.StringValue(key.ServiceAccountID)
+	model.AccessKey = types.StringValue(key.AccessKey)
+	model.SecretKey = types.StringValue(key.SecretKey)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+func (r *accessKeyEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateJSON, diags := req.Private.GetKey(ctx, privateStateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || privateJSON == nil {
+		return
+	}
+
+	var key client.TemporaryStaticAccessKey
+	if err := json.Unmarshal(privateJSON, &key); err != nil {
+		resp.Diagnostics.AddError("Unable to read temporary static access key", err.Error())
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting temporary static access key %s of service account %s", key.KeyID, key.ServiceAccountID))
+
+	if err := key.Delete(ctx, r.providerConfig.SDK, iam.MutexKV); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete temporary static access key",
+			fmt.Sprintf("Error while deleting temporary static access key: %s", err),
+		)
+	}
+}
+
+func (r *accessKeyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(*provider_config.Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider_config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerConfig = providerConfig
+}
@@ -0,0 +1,13 @@
+package yandex_iam_temporary_static_access_key
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type accessKeyModel struct {
+	FolderID         types.String `tfsdk:"folder_id"`
+	Role             types.String `tfsdk:"role"`
+	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	AccessKey        types.String `tfsdk:"access_key"`
+	SecretKey        types.String `tfsdk:"secret_key"`
+}
@@ -0,0 +1,101 @@
+package yandex_iam_token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+)
+
+// tokenEphemeralResource mints a short-lived IAM token each time Terraform opens it,
+// either of the provider identity or of a service account the provider identity may act as.
+type tokenEphemeralResource struct {
+	providerConfig *provider_config.Config
+}
+
+func NewEphemeralResource() ephemeral.EphemeralResource {
+	return &tokenEphemeralResource{}
+}
+
+func (r *tokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_token"
+}
+
+func (r *tokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a short-lived IAM token without persisting it in the state.",
+		Attributes: map[string]schema.Attribute{
+			"service_account_id": schema.StringAttribute{
+				Description: "ID of the service account to create the IAM token for. The provider identity must be allowed " +
+					"to act as the service account. If it is not set, the IAM token of the provider identity is created.",
+				Optional: true,
+			},
+			"iam_token": schema.StringAttribute{
+				Description: "The IAM token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Expiration time of the IAM token in RFC3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *tokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model tokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		token *iam.CreateIamTokenResponse
+		err   error
+	)
+	if saID := model.ServiceAccountID.ValueString(); saID != "" {
+		tflog.Info(ctx, fmt.Sprintf("Creating IAM token for service account %s", saID))
+		token, err = r.providerConfig.SDK.IAM().IamToken().CreateForServiceAccount(ctx, &iam.CreateIamTokenForServiceAccountRequest{
+			ServiceAccountId: saID,
+		})
+	} else {
+		tflog.Info(ctx, "Creating IAM token of the provider identity")
+		token, err = r.providerConfig.SDK.CreateIAMToken(ctx)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create IAM token",
+			fmt.Sprintf("Error while creating IAM token: %s", err),
+		)
+		return
+	}
+
+	model.IAMToken = types.StringValue(token.GetIamToken())
+	model.ExpiresAt = types.StringValue(token.GetExpiresAt().AsTime().Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+func (r *tokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(*provider_config.Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider_config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerConfig = providerConfig
+}
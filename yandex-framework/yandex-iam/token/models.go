@@ -0,0 +1,11 @@
+package yandex_iam_token
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type tokenModel struct {
+	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	IAMToken         types.String `tfsdk:"iam_token"`
+	ExpiresAt        types.String `tfsdk:"expires_at"`
+}
@@ -0,0 +1,111 @@
+package yandex_lockbox_secret_version
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/lockbox/v1"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+)
+
+// secretVersionEphemeralResource reads the payload of a Lockbox secret version each time
+// Terraform opens it, so the secret entries never get into the plan or the state.
+type secretVersionEphemeralResource struct {
+	providerConfig *provider_config.Config
+}
+
+func NewEphemeralResource() ephemeral.EphemeralResource {
+	return &secretVersionEphemeralResource{}
+}
+
+func (r *secretVersionEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lockbox_secret_version"
+}
+
+func (r *secretVersionEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the payload of a Yandex Cloud Lockbox secret version without persisting it in the state.",
+		Attributes: map[string]schema.Attribute{
+			"secret_id": schema.StringAttribute{
+				Description: "The Yandex Cloud Lockbox secret ID.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(0, 50),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				Description: "The Yandex Cloud Lockbox secret version ID. The current version of the secret is read if it is not set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"entries": schema.ListNestedAttribute{
+				Description: "List of entries in the Yandex Cloud Lockbox secret version.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "The key of the entry.",
+							Computed:    true,
+						},
+						"text_value": schema.StringAttribute{
+							Description: "The text value of the entry.",
+							Computed:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *secretVersionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model secretVersionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Reading payload of Lockbox secret %s version %q",
+		model.SecretID.ValueString(), model.VersionID.ValueString()))
+
+	payload, err := r.providerConfig.SDK.LockboxPayload().Payload().Get(ctx, &lockbox.GetPayloadRequest{
+		SecretId:  model.SecretID.ValueString(),
+		VersionId: model.VersionID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Lockbox secret version payload",
+			fmt.Sprintf("Error while reading payload of secret %s: %s", model.SecretID.ValueString(), err),
+		)
+		return
+	}
+
+	model.VersionID = types.StringValue(payload.GetVersionId())
+	model.Entries = flattenEntries(payload.GetEntries())
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+func (r *secretVersionEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(*provider_config.Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider_config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerConfig = providerConfig
+}
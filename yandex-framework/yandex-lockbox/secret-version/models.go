@@ -0,0 +1,28 @@
+package yandex_lockbox_secret_version
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/lockbox/v1"
+)
+
+type secretVersionModel struct {
+	SecretID  types.String `tfsdk:"secret_id"`
+	VersionID types.String `tfsdk:"version_id"`
+	Entries   []entryModel `tfsdk:"entries"`
+}
+
+type entryModel struct {
+	Key       types.String `tfsdk:"key"`
+	TextValue types.String `tfsdk:"text_value"`
+}
+
+func flattenEntries(entries []*lockbox.Payload_Entry) []entryModel {
+	result := make([]entryModel, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, entryModel{
+			Key:       types.StringValue(e.GetKey()),
+			TextValue: types.StringValue(e.GetTextValue()),
+		})
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package yandex_operation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/ephemeral/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common/client"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+)
+
+const defaultOpenTimeout = 30 * time.Minute
+
+// operationEphemeralResource waits for an operation started outside of Terraform each time
+// Terraform opens it, without keeping the operation in the state.
+type operationEphemeralResource struct {
+	providerConfig *provider_config.Config
+}
+
+func NewEphemeralResource() ephemeral.EphemeralResource {
+	return &operationEphemeralResource{}
+}
+
+func (r *operationEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_operation"
+}
+
+func (r *operationEphemeralResource) Schema(ctx context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Waits for an operation started outside of Terraform to complete and exposes its result.",
+		Attributes: map[string]schema.Attribute{
+			"operation_id": schema.StringAttribute{
+				Description: "ID of the operation to wait for.",
+				Required:    true,
+			},
+			"done": schema.BoolAttribute{
+				Description: "Whether the operation has completed.",
+				Computed:    true,
+			},
+			"error": schema.StringAttribute{
+				Description: "Error the operation failed with, empty if it succeeded.",
+				Computed:    true,
+			},
+			"metadata_json": schema.StringAttribute{
+				Description: "Metadata of the operation in JSON format.",
+				Computed:    true,
+			},
+			"response_json": schema.StringAttribute{
+				Description: "Response of the operation in JSON format, empty if the operation failed.",
+				Computed:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx),
+		},
+	}
+}
+
+func (r *operationEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model operationModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	openTimeout, timeoutInitError := model.Timeouts.Open(ctx, defaultOpenTimeout)
+	if timeoutInitError != nil {
+		resp.Diagnostics.Append(timeoutInitError...)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, openTimeout)
+	defer cancel()
+
+	operationID := model.OperationID.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Waiting for operation %s to complete", operationID))
+	result, err := client.WaitOperation(ctx, r.providerConfig.SDK, operationID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to wait for operation",
+			err.Error(),
+		)
+		return
+	}
+
+	model.Done = types.BoolValue(result.Done)
+	model.Error = types.StringValue(result.Error)
+	model.MetadataJSON = types.StringValue(result.MetadataJSON)
+	model.ResponseJSON = types.StringValue(result.ResponseJSON)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+func (r *operationEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(*provider_config.Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider_config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerConfig = providerConfig
+}
@@ -0,0 +1,106 @@
+package yandex_operation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/common/client"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/fakecloud"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+)
+
+func newFakeCloudProviderConfig(t *testing.T) *provider_config.Config {
+	server, err := fakecloud.NewServer()
+	require.NoError(t, err, "failed to start fake cloud server")
+	t.Cleanup(server.Stop)
+
+	server.AddCloud("fake-cloud-id", "fake-cloud")
+	server.AddFolder("fake-cloud-id", "fake-folder-id", "fake-folder")
+
+	c, err := client.New(context.Background(), client.Settings{
+		Endpoint:   server.Addr(),
+		FolderID:   "fake-folder-id",
+		Token:      fakecloud.Token,
+		Plaintext:  true,
+		MaxRetries: common.DefaultMaxRetries,
+	}, "", false)
+	require.NoError(t, err, "failed to build client against fake cloud server")
+	t.Cleanup(c.Close)
+
+	return &provider_config.Config{SDK: c.SDK}
+}
+
+func openOperation(t *testing.T, r ephemeral.EphemeralResource, operationID string, openTimeout *string) *ephemeral.OpenResponse {
+	ctx := context.Background()
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	r.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "%v", schemaResp.Diagnostics)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	timeoutsType := objectType.AttributeTypes["timeouts"].(tftypes.Object)
+	timeouts := tftypes.NewValue(timeoutsType, nil)
+	if openTimeout != nil {
+		timeouts = tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+			"open": tftypes.NewValue(tftypes.String, *openTimeout),
+		})
+	}
+
+	req := ephemeral.OpenRequest{Config: tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"operation_id":  tftypes.NewValue(tftypes.String, operationID),
+			"done":          tftypes.NewValue(tftypes.Bool, nil),
+			"error":         tftypes.NewValue(tftypes.String, nil),
+			"metadata_json": tftypes.NewValue(tftypes.String, nil),
+			"response_json": tftypes.NewValue(tftypes.String, nil),
+			"timeouts":      timeouts,
+		}),
+	}}
+	resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, nil),
+	}}
+	r.(*operationEphemeralResource).Open(ctx, req, resp)
+	return resp
+}
+
+func TestOperationEphemeralResourceOpen_offline(t *testing.T) {
+	providerConfig := newFakeCloudProviderConfig(t)
+	r := &operationEphemeralResource{providerConfig: providerConfig}
+
+	op, err := providerConfig.SDK.VPC().Network().Create(context.Background(), &vpc.CreateNetworkRequest{
+		FolderId: "fake-folder-id",
+		Name:     "tf-network",
+	})
+	require.NoError(t, err)
+
+	openTimeout := "1m"
+	resp := openOperation(t, r, op.GetId(), &openTimeout)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var model operationModel
+	require.False(t, resp.Result.Get(context.Background(), &model).HasError())
+	assert.True(t, model.Done.ValueBool())
+	assert.Empty(t, model.Error.ValueString())
+	assert.Contains(t, model.MetadataJSON.ValueString(), "networkId")
+	assert.Contains(t, model.ResponseJSON.ValueString(), "tf-network")
+
+	resp = openOperation(t, r, op.GetId(), nil)
+	assert.False(t, resp.Diagnostics.HasError(), "the open timeout must default without timeouts: %v", resp.Diagnostics)
+
+	openTimeout = "soon"
+	resp = openOperation(t, r, op.GetId(), &openTimeout)
+	assert.True(t, resp.Diagnostics.HasError(), "invalid open timeout must be reported")
+
+	resp = openOperation(t, r, "unknown-operation", nil)
+	assert.True(t, resp.Diagnostics.HasError(), "unknown operation must be reported")
+}
@@ -0,0 +1,15 @@
+package yandex_operation
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/ephemeral/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type operationModel struct {
+	OperationID  types.String   `tfsdk:"operation_id"`
+	Done         types.Bool     `tfsdk:"done"`
+	Error        types.String   `tfsdk:"error"`
+	MetadataJSON types.String   `tfsdk:"metadata_json"`
+	ResponseJSON types.String   `tfsdk:"response_json"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
@@ -0,0 +1,166 @@
+package yandex_storage_bucket
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// objectAs returns the model of the object, nil if the object is null or unknown.
+func objectAs[T any](ctx context.Context, value types.Object, diags *diag.Diagnostics) *T {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var model T
+	diags.Append(value.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	return &model
+}
+
+// objectFrom returns the object of the model, null if the model is nil.
+func objectFrom[T any](ctx context.Context, attrTypes map[string]attr.Type, model *T, diags *diag.Diagnostics) types.Object {
+	if model == nil {
+		return types.ObjectNull(attrTypes)
+	}
+
+	value, d := types.ObjectValueFrom(ctx, attrTypes, model)
+	diags.Append(d...)
+	return value
+}
+
+// listAs returns the models of the list elements, nil if the list is null or unknown.
+func listAs[T any](ctx context.Context, value types.List, diags *diag.Diagnostics) []T {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var models []T
+	diags.Append(value.ElementsAs(ctx, &models, false)...)
+	return models
+}
+
+// listFrom returns the list of the models, null if there are no models.
+func listFrom[T any](ctx context.Context, attrTypes map[string]attr.Type, models []T, diags *diag.Diagnostics) types.List {
+	elemType := types.ObjectType{AttrTypes: attrTypes}
+	if len(models) == 0 {
+		return types.ListNull(elemType)
+	}
+
+	value, d := types.ListValueFrom(ctx, elemType, models)
+	diags.Append(d...)
+	return value
+}
+
+// setAs returns the models of the set elements, nil if the set is null or unknown.
+func setAs[T any](ctx context.Context, value types.Set, diags *diag.Diagnostics) []T {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var models []T
+	diags.Append(value.ElementsAs(ctx, &models, false)...)
+	return models
+}
+
+// setFrom returns the set of the models, null if there are no models.
+func setFrom[T any](ctx context.Context, attrTypes map[string]attr.Type, models []T, diags *diag.Diagnostics) types.Set {
+	elemType := types.ObjectType{AttrTypes: attrTypes}
+	if len(models) == 0 {
+		return types.SetNull(elemType)
+	}
+
+	value, d := types.SetValueFrom(ctx, elemType, models)
+	diags.Append(d...)
+	return value
+}
+
+// elementsValue is a list or a set value.
+type elementsValue interface {
+	IsNull() bool
+	IsUnknown() bool
+	ElementsAs(ctx context.Context, target interface{}, allowUnhandled bool) diag.Diagnostics
+}
+
+// stringsAs returns the elements of the string list or set as AWS strings.
+func stringsAs(ctx context.Context, value elementsValue, diags *diag.Diagnostics) []*string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var values []string
+	diags.Append(value.ElementsAs(ctx, &values, false)...)
+	return aws.StringSlice(values)
+}
+
+// stringListFrom returns the list of the AWS strings, null if there are no strings.
+func stringListFrom(values []*string) types.List {
+	if len(values) == 0 {
+		return types.ListNull(types.StringType)
+	}
+
+	elements := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, types.StringValue(aws.StringValue(v)))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// stringMapAs returns the elements of the string map, nil if the map is null or unknown.
+func stringMapAs(ctx context.Context, value types.Map, diags *diag.Diagnostics) map[string]string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var values map[string]string
+	diags.Append(value.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+// stringMapFrom returns the map of the strings, null if there are no strings.
+func stringMapFrom(values map[string]string) types.Map {
+	if len(values) == 0 {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(values))
+	for k, v := range values {
+		elements[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// stringFrom returns the value of the AWS string, null if it is not set or empty.
+func stringFrom(value *string) types.String {
+	if aws.StringValue(value) == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(*value)
+}
+
+// int64From returns the value of the AWS integer, null if it is not set.
+func int64From(value *int64) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(*value)
+}
+
+// stringSet returns the value of the string if it is set and not empty.
+func stringSet(value types.String) (string, bool) {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return "", false
+	}
+	return value.ValueString(), true
+}
+
+// int64Set returns the value of the integer if it is set.
+func int64Set(value types.Int64) (int64, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return 0, false
+	}
+	return value.ValueInt64(), true
+}
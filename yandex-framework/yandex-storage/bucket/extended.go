@@ -0,0 +1,277 @@
+package yandex_storage_bucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	storagepb "github.com/yandex-cloud/go-genproto/yandex/cloud/storage/v1"
+	ycsdk "github.com/yandex-cloud/go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// The bucket properties that are managed by the Object Storage API only.
+
+func isStatusWithCode(err error, code codes.Code) bool {
+	grpcStatus, ok := status.FromError(err)
+	return ok && grpcStatus != nil && grpcStatus.Code() == code
+}
+
+func cannedACL(acl string) *storagepb.ACL {
+	baseACL := &storagepb.ACL{}
+	switch acl {
+	case bucketACLPublicRead:
+		baseACL.Grants = []*storagepb.ACL_Grant{{
+			Permission: storagepb.ACL_Grant_PERMISSION_READ,
+			GrantType:  storagepb.ACL_Grant_GRANT_TYPE_ALL_USERS,
+		}}
+	case bucketACLPublicReadWrite:
+		baseACL.Grants = []*storagepb.ACL_Grant{{
+			Permission: storagepb.ACL_Grant_PERMISSION_READ,
+			GrantType:  storagepb.ACL_Grant_GRANT_TYPE_ALL_USERS,
+		}, {
+			Permission: storagepb.ACL_Grant_PERMISSION_READ,
+			GrantType:  storagepb.ACL_Grant_GRANT_TYPE_ALL_USERS,
+		}}
+	case bucketACLAuthRead:
+		baseACL.Grants = []*storagepb.ACL_Grant{{
+			Permission: storagepb.ACL_Grant_PERMISSION_READ,
+			GrantType:  storagepb.ACL_Grant_GRANT_TYPE_ALL_AUTHENTICATED_USERS,
+		}}
+	case bucketACLPrivate,
+		bucketACLOwnerFullControl:
+		baseACL.Grants = []*storagepb.ACL_Grant{}
+	}
+
+	return baseACL
+}
+
+func anonymousAccessFlagsAs(ctx context.Context, value types.Object, diags *diag.Diagnostics) *storagepb.AnonymousAccessFlags {
+	flags := objectAs[anonymousAccessFlagsModel](ctx, value, diags)
+	if flags == nil {
+		return nil
+	}
+
+	accessFlags := new(storagepb.AnonymousAccessFlags)
+	if !flags.List.IsNull() && !flags.List.IsUnknown() {
+		accessFlags.List = wrapperspb.Bool(flags.List.ValueBool())
+	}
+	if !flags.Read.IsNull() && !flags.Read.IsUnknown() {
+		accessFlags.Read = wrapperspb.Bool(flags.Read.ValueBool())
+	}
+	if !flags.ConfigRead.IsNull() && !flags.ConfigRead.IsUnknown() {
+		accessFlags.ConfigRead = wrapperspb.Bool(flags.ConfigRead.ValueBool())
+	}
+
+	return accessFlags
+}
+
+func createBucketBySDK(ctx context.Context, sdk *ycsdk.SDK, plan *bucketModel, diags *diag.Diagnostics) error {
+	request := &storagepb.CreateBucketRequest{
+		Name:                 plan.Bucket.ValueString(),
+		FolderId:             plan.FolderID.ValueString(),
+		AnonymousAccessFlags: anonymousAccessFlagsAs(ctx, plan.AnonymousAccessFlags, diags),
+		Acl:                  cannedACL(plan.ACL.ValueString()),
+	}
+	if v, ok := stringSet(plan.DefaultStorageClass); ok {
+		request.DefaultStorageClass = v
+	}
+	if v, ok := int64Set(plan.MaxSize); ok {
+		request.MaxSize = v
+	}
+	if diags.HasError() {
+		return nil
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating Storage S3 bucket using sdk: %s", protojson.Format(request)))
+
+	op, err := sdk.WrapOperation(sdk.StorageAPI().Bucket().Create(ctx, request))
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Unable to create S3 bucket using sdk: %v", err))
+		return err
+	}
+
+	response, err := op.Response()
+	if err != nil {
+		return err
+	}
+
+	responseBucket, ok := response.(*storagepb.Bucket)
+	if !ok {
+		return fmt.Errorf("expected *storagepb.Bucket, got: %T", response)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Created Storage S3 bucket: %s", protojson.Format(responseBucket)))
+	return nil
+}
+
+// updateExtended applies the changes of the bucket properties managed by the Object Storage API.
+// The properties of a bucket created by the Object Storage API are already set.
+func updateExtended(ctx context.Context, sdk *ycsdk.SDK, plan, prior *bucketModel, createdBySDK bool, diags *diag.Diagnostics) error {
+	bucket := plan.Bucket.ValueString()
+	request := &storagepb.UpdateBucketRequest{
+		Name: bucket,
+	}
+
+	var paths []string
+	if !createdBySDK {
+		if _, ok := stringSet(plan.DefaultStorageClass); ok && hasChange(plan.DefaultStorageClass, prior.DefaultStorageClass) {
+			request.SetDefaultStorageClass(plan.DefaultStorageClass.ValueString())
+			paths = append(paths, "default_storage_class")
+		}
+		if _, ok := int64Set(plan.MaxSize); ok && hasChange(plan.MaxSize, prior.MaxSize) {
+			request.SetMaxSize(plan.MaxSize.ValueInt64())
+			paths = append(paths, "max_size")
+		}
+		if !plan.AnonymousAccessFlags.IsUnknown() && hasChange(plan.AnonymousAccessFlags, prior.AnonymousAccessFlags) {
+			request.AnonymousAccessFlags = anonymousAccessFlagsAs(ctx, plan.AnonymousAccessFlags, diags)
+			paths = append(paths, "anonymous_access_flags")
+		}
+	}
+	if diags.HasError() {
+		return nil
+	}
+
+	bucketAPI := sdk.StorageAPI().Bucket()
+
+	if len(paths) > 0 {
+		var err error
+		request.UpdateMask, err = fieldmaskpb.New(request, paths...)
+		if err != nil {
+			return fmt.Errorf("constructing field mask: %w", err)
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("updating S3 bucket extended parameters: %s", protojson.Format(request)))
+
+		op, err := sdk.WrapOperation(bucketAPI.Update(ctx, request))
+		if err == nil {
+			err = op.Wait(ctx)
+		}
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Storage api error updating S3 bucket extended parameters: %v", err))
+			return err
+		}
+	}
+
+	if !hasChange(plan.HTTPS, prior.HTTPS) {
+		return nil
+	}
+
+	https := objectAs[httpsModel](ctx, plan.HTTPS, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	if https != nil {
+		httpsUpdateRequest := &storagepb.SetBucketHTTPSConfigRequest{
+			Name: bucket,
+			Params: &storagepb.SetBucketHTTPSConfigRequest_CertificateManager{
+				CertificateManager: &storagepb.CertificateManagerHTTPSConfigParams{
+					CertificateId: https.CertificateID.ValueString(),
+				},
+			},
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("updating S3 bucket https config: %s", protojson.Format(httpsUpdateRequest)))
+
+		op, err := sdk.WrapOperation(bucketAPI.SetHTTPSConfig(ctx, httpsUpdateRequest))
+		if err == nil {
+			err = op.Wait(ctx)
+		}
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Storage api updating S3 bucket https config: %v", err))
+			return err
+		}
+		return nil
+	}
+
+	httpsDeleteRequest := &storagepb.DeleteBucketHTTPSConfigRequest{
+		Name: bucket,
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("deleting S3 bucket https config: %s", protojson.Format(httpsDeleteRequest)))
+
+	op, err := sdk.WrapOperation(bucketAPI.DeleteHTTPSConfig(ctx, httpsDeleteRequest))
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Storage api deleting S3 bucket https config: %v", err))
+		return err
+	}
+
+	return nil
+}
+
+// readExtended refreshes the bucket properties managed by the Object Storage API. It returns false
+// if the bucket does not exist.
+func readExtended(ctx context.Context, sdk *ycsdk.SDK, state *bucketModel, diags *diag.Diagnostics) (bool, error) {
+	name := state.Bucket.ValueString()
+	bucketAPI := sdk.StorageAPI().Bucket()
+
+	tflog.Debug(ctx, "Getting S3 bucket extended parameters")
+
+	bucket, err := bucketAPI.Get(ctx, &storagepb.GetBucketRequest{
+		Name: name,
+		View: storagepb.GetBucketRequest_VIEW_FULL,
+	})
+	if err != nil {
+		if isStatusWithCode(err, codes.NotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Bucket %s", protojson.Format(bucket)))
+
+	state.DefaultStorageClass = types.StringValue(bucket.GetDefaultStorageClass())
+	state.FolderID = types.StringValue(bucket.GetFolderId())
+	state.MaxSize = types.Int64Value(bucket.GetMaxSize())
+
+	var flags *anonymousAccessFlagsModel
+	if aaf := bucket.AnonymousAccessFlags; aaf != nil {
+		flags = &anonymousAccessFlagsModel{
+			List:       types.BoolValue(aaf.GetList().GetValue()),
+			Read:       types.BoolValue(aaf.GetRead().GetValue()),
+			ConfigRead: types.BoolValue(aaf.GetConfigRead().GetValue()),
+		}
+	}
+	state.AnonymousAccessFlags = objectFrom(ctx, anonymousAccessFlagsAttrTypes, flags, diags)
+
+	tflog.Debug(ctx, "trying to get S3 bucket https config")
+
+	https, err := bucketAPI.GetHTTPSConfig(ctx, &storagepb.GetBucketHTTPSConfigRequest{
+		Name: name,
+	})
+	switch {
+	case err == nil:
+	case isStatusWithCode(err, codes.NotFound),
+		isStatusWithCode(err, codes.PermissionDenied):
+		tflog.Info(ctx, fmt.Sprintf("Storage api got minor error getting S3 bucket https config %v", err))
+		state.HTTPS = types.ObjectNull(httpsAttrTypes)
+		return true, nil
+	default:
+		return true, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("S3 bucket https config: %s", protojson.Format(https)))
+
+	var httpsConfig *httpsModel
+	if https.SourceType == storagepb.HTTPSConfig_SOURCE_TYPE_MANAGED_BY_CERTIFICATE_MANAGER {
+		httpsConfig = &httpsModel{
+			CertificateID: types.StringValue(https.CertificateId),
+		}
+	}
+	state.HTTPS = objectFrom(ctx, httpsAttrTypes, httpsConfig, diags)
+
+	return true, nil
+}
@@ -0,0 +1,238 @@
+package yandex_storage_bucket
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type bucketModel struct {
+	ID                                types.String `tfsdk:"id"`
+	Bucket                            types.String `tfsdk:"bucket"`
+	BucketPrefix                      types.String `tfsdk:"bucket_prefix"`
+	BucketDomainName                  types.String `tfsdk:"bucket_domain_name"`
+	AccessKey                         types.String `tfsdk:"access_key"`
+	SecretKey                         types.String `tfsdk:"secret_key"`
+	ACL                               types.String `tfsdk:"acl"`
+	Grant                             types.Set    `tfsdk:"grant"`
+	Policy                            types.String `tfsdk:"policy"`
+	CORSRule                          types.List   `tfsdk:"cors_rule"`
+	Website                           types.Object `tfsdk:"website"`
+	WebsiteEndpoint                   types.String `tfsdk:"website_endpoint"`
+	WebsiteDomain                     types.String `tfsdk:"website_domain"`
+	Versioning                        types.Object `tfsdk:"versioning"`
+	ObjectLockConfiguration           types.Object `tfsdk:"object_lock_configuration"`
+	Logging                           types.Object `tfsdk:"logging"`
+	LifecycleRule                     types.List   `tfsdk:"lifecycle_rule"`
+	ForceDestroy                      types.Bool   `tfsdk:"force_destroy"`
+	ServerSideEncryptionConfiguration types.Object `tfsdk:"server_side_encryption_configuration"`
+	DefaultStorageClass               types.String `tfsdk:"default_storage_class"`
+	FolderID                          types.String `tfsdk:"folder_id"`
+	MaxSize                           types.Int64  `tfsdk:"max_size"`
+	AnonymousAccessFlags              types.Object `tfsdk:"anonymous_access_flags"`
+	HTTPS                             types.Object `tfsdk:"https"`
+	Tags                              types.Map    `tfsdk:"tags"`
+}
+
+type grantModel struct {
+	ID          types.String `tfsdk:"id"`
+	Type        types.String `tfsdk:"type"`
+	URI         types.String `tfsdk:"uri"`
+	Permissions types.Set    `tfsdk:"permissions"`
+}
+
+var grantAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"type":        types.StringType,
+	"uri":         types.StringType,
+	"permissions": types.SetType{ElemType: types.StringType},
+}
+
+type corsRuleModel struct {
+	AllowedHeaders types.List  `tfsdk:"allowed_headers"`
+	AllowedMethods types.List  `tfsdk:"allowed_methods"`
+	AllowedOrigins types.List  `tfsdk:"allowed_origins"`
+	ExposeHeaders  types.List  `tfsdk:"expose_headers"`
+	MaxAgeSeconds  types.Int64 `tfsdk:"max_age_seconds"`
+}
+
+var corsRuleAttrTypes = map[string]attr.Type{
+	"allowed_headers": types.ListType{ElemType: types.StringType},
+	"allowed_methods": types.ListType{ElemType: types.StringType},
+	"allowed_origins": types.ListType{ElemType: types.StringType},
+	"expose_headers":  types.ListType{ElemType: types.StringType},
+	"max_age_seconds": types.Int64Type,
+}
+
+type websiteModel struct {
+	IndexDocument         types.String `tfsdk:"index_document"`
+	ErrorDocument         types.String `tfsdk:"error_document"`
+	RedirectAllRequestsTo types.String `tfsdk:"redirect_all_requests_to"`
+	RoutingRules          types.String `tfsdk:"routing_rules"`
+}
+
+var websiteAttrTypes = map[string]attr.Type{
+	"index_document":           types.StringType,
+	"error_document":           types.StringType,
+	"redirect_all_requests_to": types.StringType,
+	"routing_rules":            types.StringType,
+}
+
+type versioningModel struct {
+	Enabled types.Bool `tfsdk:"enabled"`
+}
+
+var versioningAttrTypes = map[string]attr.Type{
+	"enabled": types.BoolType,
+}
+
+type objectLockConfigurationModel struct {
+	ObjectLockEnabled types.String `tfsdk:"object_lock_enabled"`
+	Rule              types.Object `tfsdk:"rule"`
+}
+
+var objectLockConfigurationAttrTypes = map[string]attr.Type{
+	"object_lock_enabled": types.StringType,
+	"rule":                types.ObjectType{AttrTypes: objectLockRuleAttrTypes},
+}
+
+type objectLockRuleModel struct {
+	DefaultRetention types.Object `tfsdk:"default_retention"`
+}
+
+var objectLockRuleAttrTypes = map[string]attr.Type{
+	"default_retention": types.ObjectType{AttrTypes: defaultRetentionAttrTypes},
+}
+
+type defaultRetentionModel struct {
+	Mode  types.String `tfsdk:"mode"`
+	Days  types.Int64  `tfsdk:"days"`
+	Years types.Int64  `tfsdk:"years"`
+}
+
+var defaultRetentionAttrTypes = map[string]attr.Type{
+	"mode":  types.StringType,
+	"days":  types.Int64Type,
+	"years": types.Int64Type,
+}
+
+type loggingModel struct {
+	TargetBucket types.String `tfsdk:"target_bucket"`
+	TargetPrefix types.String `tfsdk:"target_prefix"`
+}
+
+var loggingAttrTypes = map[string]attr.Type{
+	"target_bucket": types.StringType,
+	"target_prefix": types.StringType,
+}
+
+type lifecycleRuleModel struct {
+	ID                                 types.String `tfsdk:"id"`
+	Prefix                             types.String `tfsdk:"prefix"`
+	Tags                               types.Map    `tfsdk:"tags"`
+	Enabled                            types.Bool   `tfsdk:"enabled"`
+	AbortIncompleteMultipartUploadDays types.Int64  `tfsdk:"abort_incomplete_multipart_upload_days"`
+	Expiration                         types.Object `tfsdk:"expiration"`
+	NoncurrentVersionExpiration        types.Object `tfsdk:"noncurrent_version_expiration"`
+	Transition                         types.Set    `tfsdk:"transition"`
+	NoncurrentVersionTransition        types.Set    `tfsdk:"noncurrent_version_transition"`
+}
+
+var lifecycleRuleAttrTypes = map[string]attr.Type{
+	"id":                                     types.StringType,
+	"prefix":                                 types.StringType,
+	"tags":                                   types.MapType{ElemType: types.StringType},
+	"enabled":                                types.BoolType,
+	"abort_incomplete_multipart_upload_days": types.Int64Type,
+	"expiration":                             types.ObjectType{AttrTypes: expirationAttrTypes},
+	"noncurrent_version_expiration":          types.ObjectType{AttrTypes: noncurrentVersionExpirationAttrTypes},
+	"transition":                             types.SetType{ElemType: types.ObjectType{AttrTypes: transitionAttrTypes}},
+	"noncurrent_version_transition":          types.SetType{ElemType: types.ObjectType{AttrTypes: noncurrentVersionTransitionAttrTypes}},
+}
+
+type expirationModel struct {
+	Date                      types.String `tfsdk:"date"`
+	Days                      types.Int64  `tfsdk:"days"`
+	ExpiredObjectDeleteMarker types.Bool   `tfsdk:"expired_object_delete_marker"`
+}
+
+var expirationAttrTypes = map[string]attr.Type{
+	"date":                         types.StringType,
+	"days":                         types.Int64Type,
+	"expired_object_delete_marker": types.BoolType,
+}
+
+type noncurrentVersionExpirationModel struct {
+	Days types.Int64 `tfsdk:"days"`
+}
+
+var noncurrentVersionExpirationAttrTypes = map[string]attr.Type{
+	"days": types.Int64Type,
+}
+
+type transitionModel struct {
+	Date         types.String `tfsdk:"date"`
+	Days         types.Int64  `tfsdk:"days"`
+	StorageClass types.String `tfsdk:"storage_class"`
+}
+
+var transitionAttrTypes = map[string]attr.Type{
+	"date":          types.StringType,
+	"days":          types.Int64Type,
+	"storage_class": types.StringType,
+}
+
+type noncurrentVersionTransitionModel struct {
+	Days         types.Int64  `tfsdk:"days"`
+	StorageClass types.String `tfsdk:"storage_class"`
+}
+
+var noncurrentVersionTransitionAttrTypes = map[string]attr.Type{
+	"days":          types.Int64Type,
+	"storage_class": types.StringType,
+}
+
+type serverSideEncryptionConfigurationModel struct {
+	Rule types.Object `tfsdk:"rule"`
+}
+
+var serverSideEncryptionConfigurationAttrTypes = map[string]attr.Type{
+	"rule": types.ObjectType{AttrTypes: serverSideEncryptionRuleAttrTypes},
+}
+
+type serverSideEncryptionRuleModel struct {
+	ApplyServerSideEncryptionByDefault types.Object `tfsdk:"apply_server_side_encryption_by_default"`
+}
+
+var serverSideEncryptionRuleAttrTypes = map[string]attr.Type{
+	"apply_server_side_encryption_by_default": types.ObjectType{AttrTypes: serverSideEncryptionByDefaultAttrTypes},
+}
+
+type serverSideEncryptionByDefaultModel struct {
+	KMSMasterKeyID types.String `tfsdk:"kms_master_key_id"`
+	SSEAlgorithm   types.String `tfsdk:"sse_algorithm"`
+}
+
+var serverSideEncryptionByDefaultAttrTypes = map[string]attr.Type{
+	"kms_master_key_id": types.StringType,
+	"sse_algorithm":     types.StringType,
+}
+
+type anonymousAccessFlagsModel struct {
+	List       types.Bool `tfsdk:"list"`
+	Read       types.Bool `tfsdk:"read"`
+	ConfigRead types.Bool `tfsdk:"config_read"`
+}
+
+var anonymousAccessFlagsAttrTypes = map[string]attr.Type{
+	"list":        types.BoolType,
+	"read":        types.BoolType,
+	"config_read": types.BoolType,
+}
+
+type httpsModel struct {
+	CertificateID types.String `tfsdk:"certificate_id"`
+}
+
+var httpsAttrTypes = map[string]attr.Type{
+	"certificate_id": types.StringType,
+}
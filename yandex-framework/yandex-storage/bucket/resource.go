@@ -0,0 +1,314 @@
+package yandex_storage_bucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/utils"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-storage"
+)
+
+type bucketResource struct {
+	providerConfig *provider_config.Config
+}
+
+func NewResource() resource.Resource {
+	return &bucketResource{}
+}
+
+var (
+	_ resource.ResourceWithConfigValidators = &bucketResource{}
+	_ resource.ResourceWithImportState      = &bucketResource{}
+	_ resource.ResourceWithModifyPlan       = &bucketResource{}
+	_ resource.ResourceWithUpgradeState     = &bucketResource{}
+)
+
+func (r *bucketResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_bucket"
+}
+
+func (r *bucketResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = bucketSchema()
+}
+
+func (r *bucketResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("access_key"),
+			path.MatchRoot("secret_key"),
+		),
+	}
+}
+
+func (r *bucketResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeBucketStateV0},
+	}
+}
+
+func (r *bucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan bucketModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setWebsiteEndpoint(&plan)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("website_endpoint"), plan.WebsiteEndpoint)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("website_domain"), plan.WebsiteDomain)...)
+}
+
+func (r *bucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan bucketModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	s3Client, err := yandex_storage.NewS3Client(ctx, r.providerConfig, plan.AccessKey, plan.SecretKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create Storage Bucket", err.Error())
+		return
+	}
+
+	var bucket string
+	if v, ok := stringSet(plan.Bucket); ok {
+		bucket = v
+	} else if v, ok := stringSet(plan.BucketPrefix); ok {
+		bucket = id.PrefixedUniqueId(v)
+	} else {
+		bucket = id.UniqueId()
+	}
+	if err := yandex_storage.ValidateBucketName(bucket); err != nil {
+		resp.Diagnostics.AddError("Unable to create Storage Bucket", fmt.Sprintf("error validating Storage Bucket name: %s", err))
+		return
+	}
+	plan.ID = types.StringValue(bucket)
+	plan.Bucket = types.StringValue(bucket)
+
+	_, createdBySDK := stringSet(plan.FolderID)
+	if createdBySDK {
+		err = createBucketBySDK(ctx, r.providerConfig.SDK, &plan, &resp.Diagnostics)
+	} else {
+		err = createBucketByS3Client(ctx, s3Client, bucket, plan.ACL.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create Storage Bucket", fmt.Sprintf("error creating Storage S3 Bucket: %s", err))
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The bucket is kept in the state even if it is not configured completely, so that it is
+	// tainted and can be destroyed.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), plan.Bucket)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("access_key"), plan.AccessKey)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("secret_key"), plan.SecretKey)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), plan.ForceDestroy)...)
+
+	prior := bucketModel{}
+	if err := updateS3(ctx, s3Client, &plan, &prior, true, &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Unable to create Storage Bucket", err.Error())
+		return
+	}
+	if err := updateExtended(ctx, r.providerConfig.SDK, &plan, &prior, createdBySDK, &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Unable to create Storage Bucket", err.Error())
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setComputed(ctx, s3Client, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *bucketResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state bucketModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	s3Client, err := yandex_storage.NewS3Client(ctx, r.providerConfig, state.AccessKey, state.SecretKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read Storage Bucket", err.Error())
+		return
+	}
+
+	found, err := readS3(ctx, s3Client, r.providerConfig.ProviderState.StorageEndpoint.ValueString(), &state, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read Storage Bucket", err.Error())
+		return
+	}
+	if !found {
+		tflog.Warn(ctx, fmt.Sprintf("Storage Bucket (%s) not found, removing from state", state.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The properties of the extended API require an IAM token, so they are kept as they are
+	// if the API can not be used.
+	actual := state
+	if _, err := readExtended(ctx, r.providerConfig.SDK, &actual, &resp.Diagnostics); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Got an error reading Storage Bucket's extended properties: %s", err))
+	} else {
+		state = actual
+	}
+
+	// The default value is not set when the bucket is imported.
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *bucketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state bucketModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	s3Client, err := yandex_storage.NewS3Client(ctx, r.providerConfig, plan.AccessKey, plan.SecretKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to update Storage Bucket", err.Error())
+		return
+	}
+
+	if err := updateS3(ctx, s3Client, &plan, &state, false, &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Unable to update Storage Bucket", err.Error())
+		return
+	}
+	if err := updateExtended(ctx, r.providerConfig.SDK, &plan, &state, false, &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Unable to update Storage Bucket", err.Error())
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setComputed(ctx, s3Client, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *bucketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if utils.PreventDestroy(ctx, r.providerConfig.PreventDestroy, "yandex_storage_bucket", req.State, &resp.Diagnostics) {
+		return
+	}
+
+	var state bucketModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	s3Client, err := yandex_storage.NewS3Client(ctx, r.providerConfig, state.AccessKey, state.SecretKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to delete Storage Bucket", err.Error())
+		return
+	}
+
+	if err := deleteBucket(ctx, s3Client, state.Bucket.ValueString(), state.ForceDestroy.ValueBool()); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete Storage Bucket",
+			fmt.Sprintf("error deleting Storage Bucket (%s): %s", state.Bucket.ValueString(), err),
+		)
+	}
+}
+
+func (r *bucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *bucketResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(*provider_config.Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider_config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerConfig = providerConfig
+}
+
+// setComputed sets the values that are left unknown by the plan after the bucket is created or updated.
+func (r *bucketResource) setComputed(ctx context.Context, s3Client *s3.S3, plan *bucketModel, diags *diag.Diagnostics) {
+	domainName, err := yandex_storage.BucketDomainName(plan.Bucket.ValueString(), r.providerConfig.ProviderState.StorageEndpoint.ValueString())
+	if err != nil {
+		diags.AddError("Unable to get Storage Bucket domain name", err.Error())
+		return
+	}
+	plan.BucketDomainName = types.StringValue(domainName)
+	setWebsiteEndpoint(plan)
+
+	if plan.Versioning.IsUnknown() {
+		if err := readVersioning(ctx, s3Client, plan, diags); err != nil {
+			diags.AddError("Unable to read Storage Bucket versioning", err.Error())
+			return
+		}
+	}
+
+	if !plan.DefaultStorageClass.IsUnknown() && !plan.FolderID.IsUnknown() &&
+		!plan.MaxSize.IsUnknown() && !plan.AnonymousAccessFlags.IsUnknown() {
+		return
+	}
+
+	actual := *plan
+	found, err := readExtended(ctx, r.providerConfig.SDK, &actual, diags)
+	if err != nil || !found {
+		tflog.Warn(ctx, fmt.Sprintf("Got an error reading Storage Bucket's extended properties: %v", err))
+		actual.DefaultStorageClass = types.StringNull()
+		actual.FolderID = types.StringNull()
+		actual.MaxSize = types.Int64Null()
+		actual.AnonymousAccessFlags = types.ObjectNull(anonymousAccessFlagsAttrTypes)
+	}
+
+	if plan.DefaultStorageClass.IsUnknown() {
+		plan.DefaultStorageClass = actual.DefaultStorageClass
+	}
+	if plan.FolderID.IsUnknown() {
+		plan.FolderID = actual.FolderID
+	}
+	if plan.MaxSize.IsUnknown() {
+		plan.MaxSize = actual.MaxSize
+	}
+	if plan.AnonymousAccessFlags.IsUnknown() {
+		plan.AnonymousAccessFlags = actual.AnonymousAccessFlags
+	}
+}
@@ -0,0 +1,1515 @@
+package yandex_storage_bucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	awspolicy "github.com/jen20/awspolicyequivalence"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-storage"
+)
+
+// hasChange reports whether the planned value differs from the prior one. Null values of
+// different types, e.g. of the zero model of a new bucket, are considered equal.
+func hasChange(planned, prior attr.Value) bool {
+	if planned.IsNull() && prior.IsNull() {
+		return false
+	}
+	return !planned.Equal(prior)
+}
+
+func createBucketByS3Client(ctx context.Context, s3Client *s3.S3, bucket, acl string) error {
+	if acl == "" {
+		acl = bucketACLPrivate
+	}
+
+	return retry.RetryContext(ctx, 5*time.Minute, func() *retry.RetryError {
+		tflog.Info(ctx, fmt.Sprintf("Trying to create new Storage S3 Bucket: %q, ACL: %q", bucket, acl))
+
+		_, err := s3Client.CreateBucketWithContext(ctx, &s3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+			ACL:    aws.String(acl),
+		})
+		if yandex_storage.IsAWSErr(err, "OperationAborted", "") ||
+			yandex_storage.IsAWSErr(err, "AccessDenied", "") ||
+			yandex_storage.IsAWSErr(err, "Forbidden", "") {
+			tflog.Warn(ctx, fmt.Sprintf("Got an error while trying to create Storage S3 Bucket %s: %s", bucket, err))
+			return retry.RetryableError(
+				fmt.Errorf("error creating Storage S3 Bucket %s, retrying: %s", bucket, err))
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Created new Storage S3 Bucket: %q, ACL: %q", bucket, acl))
+		return nil
+	})
+}
+
+// updateS3 applies the changes of the bucket properties managed by the S3 API. For a new bucket
+// the prior model is the zero one, so that every configured property is applied.
+func updateS3(ctx context.Context, s3Client *s3.S3, plan, prior *bucketModel, isNew bool, diags *diag.Diagnostics) error {
+	type property struct {
+		name    string
+		changed bool
+		update  func() error
+	}
+	properties := []property{
+		{"policy", hasChange(plan.Policy, prior.Policy), func() error {
+			return updatePolicy(ctx, s3Client, plan)
+		}},
+		{"cors_rule", hasChange(plan.CORSRule, prior.CORSRule), func() error {
+			return updateCORS(ctx, s3Client, plan, diags)
+		}},
+		{"website", hasChange(plan.Website, prior.Website), func() error {
+			return updateWebsite(ctx, s3Client, plan, diags)
+		}},
+		{"versioning", !plan.Versioning.IsUnknown() && hasChange(plan.Versioning, prior.Versioning), func() error {
+			return updateVersioning(ctx, s3Client, plan, diags)
+		}},
+		// The ACL of a new bucket is set by its create request.
+		{"acl", !isNew && hasChange(plan.ACL, prior.ACL), func() error {
+			return updateACL(ctx, s3Client, plan)
+		}},
+		{"grant", hasChange(plan.Grant, prior.Grant), func() error {
+			return updateGrants(ctx, s3Client, plan, diags)
+		}},
+		{"logging", hasChange(plan.Logging, prior.Logging), func() error {
+			return updateLogging(ctx, s3Client, plan, diags)
+		}},
+		{"lifecycle_rule", hasChange(plan.LifecycleRule, prior.LifecycleRule), func() error {
+			return updateLifecycle(ctx, s3Client, plan, diags)
+		}},
+		{"server_side_encryption_configuration", hasChange(plan.ServerSideEncryptionConfiguration, prior.ServerSideEncryptionConfiguration), func() error {
+			return updateServerSideEncryption(ctx, s3Client, plan, diags)
+		}},
+		{"object_lock_configuration", hasChange(plan.ObjectLockConfiguration, prior.ObjectLockConfiguration), func() error {
+			return updateObjectLockConfiguration(ctx, s3Client, plan, diags)
+		}},
+		{"tags", hasChange(plan.Tags, prior.Tags), func() error {
+			return updateTags(ctx, s3Client, plan, diags)
+		}},
+	}
+
+	for _, property := range properties {
+		if !property.changed {
+			continue
+		}
+
+		if err := property.update(); err != nil {
+			return fmt.Errorf("handling %s: %w", property.name, err)
+		}
+		if diags.HasError() {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func updatePolicy(ctx context.Context, s3Client *s3.S3, plan *bucketModel) error {
+	bucket := plan.Bucket.ValueString()
+	policy := plan.Policy.ValueString()
+
+	if policy == "" {
+		tflog.Debug(ctx, fmt.Sprintf("S3 bucket: %s, delete policy", bucket))
+		_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.DeleteBucketPolicyOutput, error) {
+			return s3Client.DeleteBucketPolicyWithContext(ctx, &s3.DeleteBucketPolicyInput{
+				Bucket: aws.String(bucket),
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("error deleting S3 policy: %s", err)
+		}
+		return nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("S3 bucket: %s, put policy: %s", bucket, policy))
+	_, err := yandex_storage.RetryOnAWSCodes(ctx, []string{"MalformedPolicy", s3.ErrCodeNoSuchBucket}, func() (*s3.PutBucketPolicyOutput, error) {
+		return s3Client.PutBucketPolicyWithContext(ctx, &s3.PutBucketPolicyInput{
+			Bucket: aws.String(bucket),
+			Policy: aws.String(policy),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error putting S3 policy: %s", err)
+	}
+
+	return nil
+}
+
+func updateCORS(ctx context.Context, s3Client *s3.S3, plan *bucketModel, diags *diag.Diagnostics) error {
+	bucket := plan.Bucket.ValueString()
+	corsRules := listAs[corsRuleModel](ctx, plan.CORSRule, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	if len(corsRules) == 0 {
+		tflog.Debug(ctx, fmt.Sprintf("Storage Bucket: %s, delete CORS", bucket))
+
+		_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.DeleteBucketCorsOutput, error) {
+			return s3Client.DeleteBucketCorsWithContext(ctx, &s3.DeleteBucketCorsInput{
+				Bucket: aws.String(bucket),
+			})
+		})
+		if err == nil {
+			err = waitCORSDeleted(ctx, s3Client, bucket)
+		}
+		if err != nil {
+			return fmt.Errorf("error deleting storage CORS: %s", err)
+		}
+		return nil
+	}
+
+	rules := make([]*s3.CORSRule, 0, len(corsRules))
+	for _, rule := range corsRules {
+		r := &s3.CORSRule{
+			AllowedHeaders: stringsAs(ctx, rule.AllowedHeaders, diags),
+			AllowedMethods: stringsAs(ctx, rule.AllowedMethods, diags),
+			AllowedOrigins: stringsAs(ctx, rule.AllowedOrigins, diags),
+			ExposeHeaders:  stringsAs(ctx, rule.ExposeHeaders, diags),
+		}
+		if v, ok := int64Set(rule.MaxAgeSeconds); ok {
+			r.MaxAgeSeconds = aws.Int64(v)
+		}
+		rules = append(rules, r)
+	}
+
+	corsConfiguration := &s3.CORSConfiguration{
+		CORSRules: rules,
+	}
+	corsInput := &s3.PutBucketCorsInput{
+		Bucket:            aws.String(bucket),
+		CORSConfiguration: corsConfiguration,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Storage Bucket: %s, put CORS: %#v", bucket, corsInput))
+
+	_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutBucketCorsOutput, error) {
+		return s3Client.PutBucketCorsWithContext(ctx, corsInput)
+	})
+	if err == nil {
+		err = waitCORSPut(ctx, s3Client, bucket, corsConfiguration)
+	}
+	if err != nil {
+		return fmt.Errorf("error putting bucket CORS: %s", err)
+	}
+
+	return nil
+}
+
+func updateWebsite(ctx context.Context, s3Client *s3.S3, plan *bucketModel, diags *diag.Diagnostics) error {
+	bucket := plan.Bucket.ValueString()
+	website := objectAs[websiteModel](ctx, plan.Website, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	if website == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Storage delete bucket website: %s", bucket))
+
+		_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.DeleteBucketWebsiteOutput, error) {
+			return s3Client.DeleteBucketWebsiteWithContext(ctx, &s3.DeleteBucketWebsiteInput{
+				Bucket: aws.String(bucket),
+			})
+		})
+		if err == nil {
+			err = waitWebsiteDeleted(ctx, s3Client, bucket)
+		}
+		if err != nil {
+			return fmt.Errorf("error deleting storage website: %s", err)
+		}
+		return nil
+	}
+
+	indexDocument, hasIndexDocument := stringSet(website.IndexDocument)
+	redirectAllRequestsTo, hasRedirect := stringSet(website.RedirectAllRequestsTo)
+	if !hasIndexDocument && !hasRedirect {
+		return fmt.Errorf("must specify either index_document or redirect_all_requests_to")
+	}
+
+	websiteConfiguration := &s3.WebsiteConfiguration{}
+
+	if hasIndexDocument {
+		websiteConfiguration.IndexDocument = &s3.IndexDocument{Suffix: aws.String(indexDocument)}
+	}
+
+	if errorDocument, ok := stringSet(website.ErrorDocument); ok {
+		websiteConfiguration.ErrorDocument = &s3.ErrorDocument{Key: aws.String(errorDocument)}
+	}
+
+	if hasRedirect {
+		redirect, err := url.Parse(redirectAllRequestsTo)
+		if err == nil && redirect.Scheme != "" {
+			var redirectHostBuf bytes.Buffer
+			redirectHostBuf.WriteString(redirect.Host)
+			if redirect.Path != "" {
+				redirectHostBuf.WriteString(redirect.Path)
+			}
+			if redirect.RawQuery != "" {
+				redirectHostBuf.WriteString("?")
+				redirectHostBuf.WriteString(redirect.RawQuery)
+			}
+			websiteConfiguration.RedirectAllRequestsTo = &s3.RedirectAllRequestsTo{
+				HostName: aws.String(redirectHostBuf.String()),
+				Protocol: aws.String(redirect.Scheme),
+			}
+		} else {
+			websiteConfiguration.RedirectAllRequestsTo = &s3.RedirectAllRequestsTo{HostName: aws.String(redirectAllRequestsTo)}
+		}
+	}
+
+	if routingRules, ok := stringSet(website.RoutingRules); ok {
+		var unmarshaledRules []*s3.RoutingRule
+		if err := json.Unmarshal([]byte(routingRules), &unmarshaledRules); err != nil {
+			return err
+		}
+		websiteConfiguration.RoutingRules = unmarshaledRules
+	}
+
+	putInput := &s3.PutBucketWebsiteInput{
+		Bucket:               aws.String(bucket),
+		WebsiteConfiguration: websiteConfiguration,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Storage put bucket website: %#v", putInput))
+
+	_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutBucketWebsiteOutput, error) {
+		return s3Client.PutBucketWebsiteWithContext(ctx, putInput)
+	})
+	if err == nil {
+		err = waitWebsitePut(ctx, s3Client, bucket, websiteConfiguration)
+	}
+	if err != nil {
+		return fmt.Errorf("error putting storage website: %s", err)
+	}
+
+	return nil
+}
+
+func updateVersioning(ctx context.Context, s3Client *s3.S3, plan *bucketModel, diags *diag.Diagnostics) error {
+	versioning := objectAs[versioningModel](ctx, plan.Versioning, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	vc := &s3.VersioningConfiguration{
+		Status: aws.String(s3.BucketVersioningStatusSuspended),
+	}
+	if versioning != nil && versioning.Enabled.ValueBool() {
+		vc.Status = aws.String(s3.BucketVersioningStatusEnabled)
+	}
+
+	input := &s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(plan.Bucket.ValueString()),
+		VersioningConfiguration: vc,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("S3 put bucket versioning: %#v", input))
+
+	_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutBucketVersioningOutput, error) {
+		return s3Client.PutBucketVersioningWithContext(ctx, input)
+	})
+	if err != nil {
+		return fmt.Errorf("error putting S3 versioning: %s", err)
+	}
+
+	return nil
+}
+
+func updateACL(ctx context.Context, s3Client *s3.S3, plan *bucketModel) error {
+	acl := plan.ACL.ValueString()
+	if acl == "" {
+		acl = bucketACLPrivate
+	}
+
+	input := &s3.PutBucketAclInput{
+		Bucket: aws.String(plan.Bucket.ValueString()),
+		ACL:    aws.String(acl),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Storage put bucket ACL: %#v", input))
+
+	_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutBucketAclOutput, error) {
+		return s3Client.PutBucketAclWithContext(ctx, input)
+	})
+	if err != nil {
+		return fmt.Errorf("error putting Storage Bucket ACL: %s", err)
+	}
+
+	return nil
+}
+
+func updateGrants(ctx context.Context, s3Client *s3.S3, plan *bucketModel, diags *diag.Diagnostics) error {
+	bucket := plan.Bucket.ValueString()
+	grantModels := setAs[grantModel](ctx, plan.Grant, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	if len(grantModels) == 0 {
+		tflog.Debug(ctx, fmt.Sprintf("Storage Bucket: %s, Grants fallback to canned ACL", bucket))
+		if err := updateACL(ctx, s3Client, plan); err != nil {
+			return fmt.Errorf("error fallback to canned ACL, %s", err)
+		}
+		return nil
+	}
+
+	ap, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetBucketAclOutput, error) {
+		return s3Client.GetBucketAclWithContext(ctx, &s3.GetBucketAclInput{
+			Bucket: aws.String(bucket),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error getting Storage Bucket (%s) ACL: %s", bucket, err)
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Storage Bucket: %s, read ACL grants policy: %+v", bucket, ap))
+
+	grants := make([]*s3.Grant, 0, len(grantModels))
+	for _, grant := range grantModels {
+		permissions := stringsAs(ctx, grant.Permissions, diags)
+		if err := validateBucketPermissions(aws.StringValueSlice(permissions)); err != nil {
+			return err
+		}
+
+		for _, permission := range permissions {
+			grantee := &s3.Grantee{}
+			if v, ok := stringSet(grant.ID); ok {
+				grantee.SetID(v)
+			}
+			if v, ok := stringSet(grant.Type); ok {
+				grantee.SetType(v)
+			}
+			if v, ok := stringSet(grant.URI); ok {
+				grantee.SetURI(v)
+			}
+
+			grants = append(grants, &s3.Grant{
+				Grantee:    grantee,
+				Permission: permission,
+			})
+		}
+	}
+
+	input := &s3.PutBucketAclInput{
+		Bucket: aws.String(bucket),
+		AccessControlPolicy: &s3.AccessControlPolicy{
+			Grants: grants,
+			Owner:  ap.Owner,
+		},
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Bucket: %s, put Grants: %#v", bucket, input))
+
+	_, err = yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutBucketAclOutput, error) {
+		return s3Client.PutBucketAclWithContext(ctx, input)
+	})
+	if err != nil {
+		return fmt.Errorf("error putting Storage Bucket (%s) ACL: %s", bucket, err)
+	}
+
+	return nil
+}
+
+func updateLogging(ctx context.Context, s3Client *s3.S3, plan *bucketModel, diags *diag.Diagnostics) error {
+	logging := objectAs[loggingModel](ctx, plan.Logging, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	loggingStatus := &s3.BucketLoggingStatus{}
+	if logging != nil {
+		loggingStatus.LoggingEnabled = &s3.LoggingEnabled{
+			TargetBucket: aws.String(logging.TargetBucket.ValueString()),
+			TargetPrefix: aws.String(logging.TargetPrefix.ValueString()),
+		}
+	}
+
+	input := &s3.PutBucketLoggingInput{
+		Bucket:              aws.String(plan.Bucket.ValueString()),
+		BucketLoggingStatus: loggingStatus,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("S3 put bucket logging: %#v", input))
+
+	_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutBucketLoggingOutput, error) {
+		return s3Client.PutBucketLoggingWithContext(ctx, input)
+	})
+	if err != nil {
+		return fmt.Errorf("error putting S3 logging: %s", err)
+	}
+
+	return nil
+}
+
+// updateLifecycle puts the lifecycle rules of the plan. The IDs of the rules that have none
+// are generated and set to the plan.
+func updateLifecycle(ctx context.Context, s3Client *s3.S3, plan *bucketModel, diags *diag.Diagnostics) error {
+	bucket := plan.Bucket.ValueString()
+	lifecycleRules := listAs[lifecycleRuleModel](ctx, plan.LifecycleRule, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	if len(lifecycleRules) == 0 {
+		_, err := s3Client.DeleteBucketLifecycleWithContext(ctx, &s3.DeleteBucketLifecycleInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			return fmt.Errorf("error removing S3 lifecycle: %s", err)
+		}
+		return nil
+	}
+
+	rules := make([]*s3.LifecycleRule, 0, len(lifecycleRules))
+	for i := range lifecycleRules {
+		ruleModel := &lifecycleRuleModel{}
+		*ruleModel = lifecycleRules[i]
+
+		if _, ok := stringSet(ruleModel.ID); !ok {
+			ruleModel.ID = types.StringValue(id.PrefixedUniqueId("tf-s3-lifecycle-"))
+			lifecycleRules[i].ID = ruleModel.ID
+		}
+
+		rule, err := expandLifecycleRule(ctx, ruleModel, diags)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+	if diags.HasError() {
+		return nil
+	}
+
+	input := &s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{
+			Rules: rules,
+		},
+	}
+
+	_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutBucketLifecycleConfigurationOutput, error) {
+		return s3Client.PutBucketLifecycleConfigurationWithContext(ctx, input)
+	})
+	if err != nil {
+		return fmt.Errorf("error putting S3 lifecycle: %s", err)
+	}
+
+	plan.LifecycleRule = listFrom(ctx, lifecycleRuleAttrTypes, lifecycleRules, diags)
+	return nil
+}
+
+func expandLifecycleRule(ctx context.Context, r *lifecycleRuleModel, diags *diag.Diagnostics) (*s3.LifecycleRule, error) {
+	rule := &s3.LifecycleRule{
+		ID:     aws.String(r.ID.ValueString()),
+		Status: aws.String(s3.ExpirationStatusDisabled),
+	}
+
+	if tags := stringMapAs(ctx, r.Tags, diags); len(tags) > 0 {
+		rule.Filter = &s3.LifecycleRuleFilter{
+			And: &s3.LifecycleRuleAndOperator{
+				Prefix: aws.String(r.Prefix.ValueString()),
+				Tags:   yandex_storage.TagsFromMap(tags),
+			},
+		}
+	} else {
+		rule.Filter = &s3.LifecycleRuleFilter{
+			Prefix: aws.String(r.Prefix.ValueString()),
+		}
+	}
+
+	if r.Enabled.ValueBool() {
+		rule.Status = aws.String(s3.ExpirationStatusEnabled)
+	}
+
+	if v, ok := int64Set(r.AbortIncompleteMultipartUploadDays); ok && v > 0 {
+		rule.AbortIncompleteMultipartUpload = &s3.AbortIncompleteMultipartUpload{
+			DaysAfterInitiation: aws.Int64(v),
+		}
+	}
+
+	if e := objectAs[expirationModel](ctx, r.Expiration, diags); e != nil {
+		expiration := &s3.LifecycleExpiration{}
+		if v, ok := stringSet(e.Date); ok {
+			t, err := time.Parse(yandex_storage.LifecycleDateLayout, v)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing S3 Bucket Lifecycle Expiration Date: %s", err)
+			}
+			expiration.Date = aws.Time(t)
+		} else if v, ok := int64Set(e.Days); ok && v > 0 {
+			expiration.Days = aws.Int64(v)
+		} else if !e.ExpiredObjectDeleteMarker.IsNull() {
+			expiration.ExpiredObjectDeleteMarker = aws.Bool(e.ExpiredObjectDeleteMarker.ValueBool())
+		}
+		rule.Expiration = expiration
+	}
+
+	if e := objectAs[noncurrentVersionExpirationModel](ctx, r.NoncurrentVersionExpiration, diags); e != nil {
+		if v, ok := int64Set(e.Days); ok && v > 0 {
+			rule.NoncurrentVersionExpiration = &s3.NoncurrentVersionExpiration{
+				NoncurrentDays: aws.Int64(v),
+			}
+		}
+	}
+
+	for _, t := range setAs[transitionModel](ctx, r.Transition, diags) {
+		transition := &s3.Transition{
+			StorageClass: aws.String(t.StorageClass.ValueString()),
+		}
+		if v, ok := stringSet(t.Date); ok {
+			date, err := time.Parse(yandex_storage.LifecycleDateLayout, v)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing S3 Bucket Lifecycle Transition Date: %s", err)
+			}
+			transition.Date = aws.Time(date)
+		} else if v, ok := int64Set(t.Days); ok && v >= 0 {
+			transition.Days = aws.Int64(v)
+		}
+		rule.Transitions = append(rule.Transitions, transition)
+	}
+
+	for _, t := range setAs[noncurrentVersionTransitionModel](ctx, r.NoncurrentVersionTransition, diags) {
+		transition := &s3.NoncurrentVersionTransition{
+			StorageClass: aws.String(t.StorageClass.ValueString()),
+		}
+		if v, ok := int64Set(t.Days); ok && v >= 0 {
+			transition.NoncurrentDays = aws.Int64(v)
+		}
+		rule.NoncurrentVersionTransitions = append(rule.NoncurrentVersionTransitions, transition)
+	}
+
+	// As a lifecycle rule requires 1 or more transition/expiration actions,
+	// we explicitly pass a default ExpiredObjectDeleteMarker value to be able to create
+	// the rule while keeping the policy unaffected if the conditions are not met.
+	if rule.Expiration == nil && rule.NoncurrentVersionExpiration == nil &&
+		rule.Transitions == nil && rule.NoncurrentVersionTransitions == nil &&
+		rule.AbortIncompleteMultipartUpload == nil {
+		rule.Expiration = &s3.LifecycleExpiration{ExpiredObjectDeleteMarker: aws.Bool(false)}
+	}
+
+	return rule, nil
+}
+
+func updateServerSideEncryption(ctx context.Context, s3Client *s3.S3, plan *bucketModel, diags *diag.Diagnostics) error {
+	bucket := plan.Bucket.ValueString()
+	configuration := objectAs[serverSideEncryptionConfigurationModel](ctx, plan.ServerSideEncryptionConfiguration, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	if configuration == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Delete server side encryption configuration of bucket %s", bucket))
+		_, err := s3Client.DeleteBucketEncryptionWithContext(ctx, &s3.DeleteBucketEncryptionInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			return fmt.Errorf("error removing S3 bucket server side encryption: %s", err)
+		}
+		return nil
+	}
+
+	rule := objectAs[serverSideEncryptionRuleModel](ctx, configuration.Rule, diags)
+	if diags.HasError() || rule == nil {
+		return nil
+	}
+	byDefault := objectAs[serverSideEncryptionByDefaultModel](ctx, rule.ApplyServerSideEncryptionByDefault, diags)
+	if diags.HasError() || byDefault == nil {
+		return nil
+	}
+
+	defaultRule := &s3.ServerSideEncryptionByDefault{
+		SSEAlgorithm: aws.String(byDefault.SSEAlgorithm.ValueString()),
+	}
+	if v, ok := stringSet(byDefault.KMSMasterKeyID); ok {
+		defaultRule.KMSMasterKeyID = aws.String(v)
+	}
+
+	input := &s3.PutBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{{
+				ApplyServerSideEncryptionByDefault: defaultRule,
+			}},
+		},
+	}
+	tflog.Debug(ctx, fmt.Sprintf("S3 put bucket encryption configuration: %#v", input))
+
+	_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutBucketEncryptionOutput, error) {
+		return s3Client.PutBucketEncryptionWithContext(ctx, input)
+	})
+	if err != nil {
+		return fmt.Errorf("error putting S3 server side encryption configuration: %s", err)
+	}
+
+	return nil
+}
+
+func updateObjectLockConfiguration(ctx context.Context, s3Client *s3.S3, plan *bucketModel, diags *diag.Diagnostics) error {
+	configuration := objectAs[objectLockConfigurationModel](ctx, plan.ObjectLockConfiguration, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	olc := &s3.ObjectLockConfiguration{}
+	if configuration != nil {
+		olc.ObjectLockEnabled = aws.String(configuration.ObjectLockEnabled.ValueString())
+
+		if rule := objectAs[objectLockRuleModel](ctx, configuration.Rule, diags); rule != nil {
+			retention := objectAs[defaultRetentionModel](ctx, rule.DefaultRetention, diags)
+			if retention != nil {
+				r := &s3.ObjectLockRule{
+					DefaultRetention: &s3.DefaultRetention{
+						Mode: aws.String(retention.Mode.ValueString()),
+					},
+				}
+				if v, ok := int64Set(retention.Days); ok && v > 0 {
+					r.DefaultRetention.Days = aws.Int64(v)
+				}
+				if v, ok := int64Set(retention.Years); ok && v > 0 {
+					r.DefaultRetention.Years = aws.Int64(v)
+				}
+				olc.Rule = r
+			}
+		}
+	}
+	if diags.HasError() {
+		return nil
+	}
+
+	input := &s3.PutObjectLockConfigurationInput{
+		Bucket:                  aws.String(plan.Bucket.ValueString()),
+		ObjectLockConfiguration: olc,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("S3 put object lock configuration: %#v", input))
+
+	_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutObjectLockConfigurationOutput, error) {
+		return s3Client.PutObjectLockConfigurationWithContext(ctx, input)
+	})
+	if err != nil {
+		return fmt.Errorf("error putting S3 object lock configuration: %s", err)
+	}
+
+	return nil
+}
+
+func updateTags(ctx context.Context, s3Client *s3.S3, plan *bucketModel, diags *diag.Diagnostics) error {
+	bucket := aws.String(plan.Bucket.ValueString())
+	tags := stringMapAs(ctx, plan.Tags, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	if len(tags) == 0 {
+		tflog.Info(ctx, "Deleting Storage S3 bucket tags")
+
+		_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.DeleteBucketTaggingOutput, error) {
+			return s3Client.DeleteBucketTaggingWithContext(ctx, &s3.DeleteBucketTaggingInput{
+				Bucket: bucket,
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("error deleting Storage S3 bucket tags: %s", err)
+		}
+		return nil
+	}
+
+	tagSet := yandex_storage.TagsFromMap(tags)
+	tflog.Info(ctx, fmt.Sprintf("Updating Storage S3 bucket tags with %v", tagSet))
+
+	_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutBucketTaggingOutput, error) {
+		return s3Client.PutBucketTaggingWithContext(ctx, &s3.PutBucketTaggingInput{
+			Bucket: bucket,
+			Tagging: &s3.Tagging{
+				TagSet: tagSet,
+			},
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error updating Storage S3 bucket tags: %s", err)
+	}
+
+	return nil
+}
+
+// readS3 refreshes the bucket properties managed by the S3 API. It returns false if the bucket
+// does not exist.
+func readS3(ctx context.Context, s3Client *s3.S3, endpoint string, state *bucketModel, diags *diag.Diagnostics) (bool, error) {
+	bucket := aws.String(state.ID.ValueString())
+
+	_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.HeadBucketOutput, error) {
+		return s3Client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+			Bucket: bucket,
+		})
+	})
+	if err != nil {
+		if yandex_storage.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error reading Storage Bucket (%s): %s", state.ID.ValueString(), err)
+	}
+
+	state.Bucket = types.StringValue(state.ID.ValueString())
+
+	domainName, err := yandex_storage.BucketDomainName(state.Bucket.ValueString(), endpoint)
+	if err != nil {
+		return false, fmt.Errorf("error getting bucket domain name: %s", err)
+	}
+	state.BucketDomainName = types.StringValue(domainName)
+
+	readers := []func(context.Context, *s3.S3, *bucketModel, *diag.Diagnostics) error{
+		readPolicy,
+		readCORS,
+		readWebsite,
+		readGrants,
+		readVersioning,
+		readObjectLockConfiguration,
+		readLogging,
+		readLifecycle,
+		readServerSideEncryption,
+		readTags,
+	}
+	for _, read := range readers {
+		if err := read(ctx, s3Client, state, diags); err != nil {
+			if yandex_storage.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if diags.HasError() {
+			return true, nil
+		}
+	}
+
+	return true, nil
+}
+
+func readPolicy(ctx context.Context, s3Client *s3.S3, state *bucketModel, _ *diag.Diagnostics) error {
+	output, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetBucketPolicyOutput, error) {
+		return s3Client.GetBucketPolicyWithContext(ctx, &s3.GetBucketPolicyInput{
+			Bucket: aws.String(state.Bucket.ValueString()),
+		})
+	})
+	switch {
+	case err == nil:
+	case yandex_storage.IsAWSErr(err, "NoSuchBucketPolicy", ""):
+		state.Policy = types.StringNull()
+		return nil
+	case yandex_storage.IsAWSErr(err, "AccessDenied", ""):
+		tflog.Warn(ctx, fmt.Sprintf("Got an error while trying to read Storage Bucket (%s) Policy: %s", state.Bucket.ValueString(), err))
+		return nil
+	default:
+		return fmt.Errorf("error getting current policy: %s", err)
+	}
+
+	if aws.StringValue(output.Policy) == "" {
+		state.Policy = types.StringNull()
+		return nil
+	}
+
+	policy, err := yandex_storage.NormalizeJSON(aws.StringValue(output.Policy))
+	if err != nil {
+		return fmt.Errorf("policy contains an invalid JSON: %s", err)
+	}
+
+	// Keep the policy as it is written in the configuration if it is equivalent to the actual one.
+	if equivalent, err := awspolicy.PoliciesAreEquivalent(state.Policy.ValueString(), policy); err == nil && equivalent {
+		return nil
+	}
+
+	state.Policy = types.StringValue(policy)
+	return nil
+}
+
+func readCORS(ctx context.Context, s3Client *s3.S3, state *bucketModel, diags *diag.Diagnostics) error {
+	output, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetBucketCorsOutput, error) {
+		return s3Client.GetBucketCorsWithContext(ctx, &s3.GetBucketCorsInput{
+			Bucket: aws.String(state.Bucket.ValueString()),
+		})
+	})
+	if err != nil && !yandex_storage.IsAWSErr(err, "NoSuchCORSConfiguration", "") {
+		return fmt.Errorf("error getting Storage Bucket CORS configuration: %w", err)
+	}
+
+	var rules []corsRuleModel
+	if output != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Storage get bucket CORS output: %#v", output))
+
+		for _, rule := range output.CORSRules {
+			rules = append(rules, corsRuleModel{
+				AllowedHeaders: stringListFrom(rule.AllowedHeaders),
+				AllowedMethods: stringListFrom(rule.AllowedMethods),
+				AllowedOrigins: stringListFrom(rule.AllowedOrigins),
+				ExposeHeaders:  stringListFrom(rule.ExposeHeaders),
+				MaxAgeSeconds:  int64From(rule.MaxAgeSeconds),
+			})
+		}
+	}
+
+	state.CORSRule = listFrom(ctx, corsRuleAttrTypes, rules, diags)
+	return nil
+}
+
+func readWebsite(ctx context.Context, s3Client *s3.S3, state *bucketModel, diags *diag.Diagnostics) error {
+	output, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetBucketWebsiteOutput, error) {
+		return s3Client.GetBucketWebsiteWithContext(ctx, &s3.GetBucketWebsiteInput{
+			Bucket: aws.String(state.Bucket.ValueString()),
+		})
+	})
+	if err != nil && !yandex_storage.IsAWSErr(err, "NotImplemented", "") &&
+		!yandex_storage.IsAWSErr(err, "NoSuchWebsiteConfiguration", "") {
+		return fmt.Errorf("error getting Storage Bucket website configuration: %w", err)
+	}
+
+	prior := objectAs[websiteModel](ctx, state.Website, diags)
+
+	var website *websiteModel
+	if output != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Storage get bucket website output: %#v", output))
+
+		w := websiteModel{
+			IndexDocument:         types.StringNull(),
+			ErrorDocument:         types.StringNull(),
+			RedirectAllRequestsTo: types.StringNull(),
+			RoutingRules:          types.StringNull(),
+		}
+
+		if v := output.IndexDocument; v != nil {
+			w.IndexDocument = types.StringValue(aws.StringValue(v.Suffix))
+		}
+
+		if v := output.ErrorDocument; v != nil {
+			w.ErrorDocument = types.StringValue(aws.StringValue(v.Key))
+		}
+
+		if v := output.RedirectAllRequestsTo; v != nil {
+			if v.Protocol == nil {
+				w.RedirectAllRequestsTo = types.StringValue(aws.StringValue(v.HostName))
+			} else {
+				var host, path, query string
+				parsedHostName, err := url.Parse(aws.StringValue(v.HostName))
+				if err == nil {
+					host = parsedHostName.Host
+					path = parsedHostName.Path
+					query = parsedHostName.RawQuery
+				} else {
+					host = aws.StringValue(v.HostName)
+				}
+
+				w.RedirectAllRequestsTo = types.StringValue((&url.URL{
+					Host:     host,
+					Path:     path,
+					Scheme:   aws.StringValue(v.Protocol),
+					RawQuery: query,
+				}).String())
+			}
+		}
+
+		if v := output.RoutingRules; v != nil {
+			rules, err := normalizeRoutingRules(v)
+			if err != nil {
+				return fmt.Errorf("error while marshaling routing rules: %s", err)
+			}
+			w.RoutingRules = types.StringValue(rules)
+
+			// Keep the routing rules as they are written in the configuration if they are the same JSON.
+			if prior != nil {
+				priorRules, err := yandex_storage.NormalizeJSON(prior.RoutingRules.ValueString())
+				if err == nil && priorRules == rules {
+					w.RoutingRules = prior.RoutingRules
+				}
+			}
+		}
+
+		// We have special handling for the website configuration,
+		// so only add the configuration if there is any
+		if !w.IndexDocument.IsNull() || !w.ErrorDocument.IsNull() ||
+			!w.RedirectAllRequestsTo.IsNull() || !w.RoutingRules.IsNull() {
+			website = &w
+		}
+	}
+
+	state.Website = objectFrom(ctx, websiteAttrTypes, website, diags)
+	setWebsiteEndpoint(state)
+	return nil
+}
+
+// setWebsiteEndpoint sets the website endpoint and domain of the bucket, null if the bucket
+// is not configured with a website.
+func setWebsiteEndpoint(model *bucketModel) {
+	if model.Website.IsNull() {
+		model.WebsiteEndpoint = types.StringNull()
+		model.WebsiteDomain = types.StringNull()
+		return
+	}
+
+	if model.Website.IsUnknown() || model.Bucket.IsUnknown() {
+		model.WebsiteEndpoint = types.StringUnknown()
+		model.WebsiteDomain = types.StringUnknown()
+		return
+	}
+
+	model.WebsiteEndpoint = types.StringValue(common.StorageWebsiteEndpoint(model.Bucket.ValueString()))
+	model.WebsiteDomain = types.StringValue(common.StorageWebsiteDomain)
+}
+
+func readGrants(ctx context.Context, s3Client *s3.S3, state *bucketModel, diags *diag.Diagnostics) error {
+	// Grants are not refreshed for the canned ACL, the actual grants of which are not kept in the state.
+	if !state.ACL.IsNull() && state.ACL.ValueString() != "" {
+		state.Grant = types.SetNull(types.ObjectType{AttrTypes: grantAttrTypes})
+		return nil
+	}
+
+	output, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetBucketAclOutput, error) {
+		return s3Client.GetBucketAclWithContext(ctx, &s3.GetBucketAclInput{
+			Bucket: aws.String(state.Bucket.ValueString()),
+		})
+	})
+	if err != nil {
+		// Ignore access denied error, when reading ACL for bucket.
+		if yandex_storage.IsAWSErr(err, "AccessDenied", "") || yandex_storage.IsAWSErr(err, "Forbidden", "") {
+			tflog.Warn(ctx, fmt.Sprintf("Got an error while trying to read Storage Bucket (%s) ACL: %s", state.Bucket.ValueString(), err))
+			state.Grant = types.SetNull(types.ObjectType{AttrTypes: grantAttrTypes})
+			return nil
+		}
+		return fmt.Errorf("error getting Storage Bucket (%s) ACL: %w", state.Bucket.ValueString(), err)
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Storage Bucket: %s, read ACL grants policy: %+v", state.Bucket.ValueString(), output))
+
+	state.Grant = setFrom(ctx, grantAttrTypes, flattenGrants(output), diags)
+	return nil
+}
+
+func flattenGrants(ap *s3.GetBucketAclOutput) []grantModel {
+	// If ACL grants contains bucket owner FULL_CONTROL only - it is default "private" acl
+	if len(ap.Grants) == 1 && ap.Owner != nil &&
+		aws.StringValue(ap.Grants[0].Grantee.ID) == aws.StringValue(ap.Owner.ID) &&
+		aws.StringValue(ap.Grants[0].Permission) == s3.PermissionFullControl {
+		return nil
+	}
+
+	type grantee struct {
+		id, granteeType, uri string
+	}
+
+	var (
+		order       []grantee
+		permissions = make(map[grantee][]attr.Value)
+	)
+	for _, grant := range ap.Grants {
+		g := grantee{
+			id:          aws.StringValue(grant.Grantee.ID),
+			granteeType: aws.StringValue(grant.Grantee.Type),
+			uri:         aws.StringValue(grant.Grantee.URI),
+		}
+		if _, ok := permissions[g]; !ok {
+			order = append(order, g)
+		}
+		permissions[g] = append(permissions[g], types.StringValue(aws.StringValue(grant.Permission)))
+	}
+
+	grants := make([]grantModel, 0, len(order))
+	for _, g := range order {
+		grants = append(grants, grantModel{
+			ID:          stringFrom(aws.String(g.id)),
+			Type:        types.StringValue(g.granteeType),
+			URI:         stringFrom(aws.String(g.uri)),
+			Permissions: types.SetValueMust(types.StringType, permissions[g]),
+		})
+	}
+
+	return grants
+}
+
+func readVersioning(ctx context.Context, s3Client *s3.S3, state *bucketModel, diags *diag.Diagnostics) error {
+	output, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetBucketVersioningOutput, error) {
+		return s3Client.GetBucketVersioningWithContext(ctx, &s3.GetBucketVersioningInput{
+			Bucket: aws.String(state.Bucket.ValueString()),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error getting Storage Bucket versioning: %w", err)
+	}
+
+	state.Versioning = objectFrom(ctx, versioningAttrTypes, &versioningModel{
+		Enabled: types.BoolValue(aws.StringValue(output.Status) == s3.BucketVersioningStatusEnabled),
+	}, diags)
+	return nil
+}
+
+func readObjectLockConfiguration(ctx context.Context, s3Client *s3.S3, state *bucketModel, diags *diag.Diagnostics) error {
+	output, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetObjectLockConfigurationOutput, error) {
+		return s3Client.GetObjectLockConfigurationWithContext(ctx, &s3.GetObjectLockConfigurationInput{
+			Bucket: aws.String(state.Bucket.ValueString()),
+		})
+	})
+	switch {
+	case err == nil:
+	case yandex_storage.IsAWSErr(err, "ObjectLockConfigurationNotFoundError", ""),
+		yandex_storage.IsAWSErr(err, "AccessDenied", ""):
+		tflog.Debug(ctx, fmt.Sprintf("Got an error while trying to read Storage Bucket (%s) ObjectLockConfiguration: %s", state.Bucket.ValueString(), err))
+		state.ObjectLockConfiguration = types.ObjectNull(objectLockConfigurationAttrTypes)
+		return nil
+	default:
+		return fmt.Errorf("error getting Storage Bucket object lock configuration: %w", err)
+	}
+
+	config := output.ObjectLockConfiguration
+	if config == nil {
+		state.ObjectLockConfiguration = types.ObjectNull(objectLockConfigurationAttrTypes)
+		return nil
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Storage get bucket object lock config output: %#v", output))
+
+	olc := &objectLockConfigurationModel{
+		ObjectLockEnabled: stringFrom(config.ObjectLockEnabled),
+		Rule:              types.ObjectNull(objectLockRuleAttrTypes),
+	}
+	if config.Rule != nil && config.Rule.DefaultRetention != nil {
+		retention := config.Rule.DefaultRetention
+		olc.Rule = objectFrom(ctx, objectLockRuleAttrTypes, &objectLockRuleModel{
+			DefaultRetention: objectFrom(ctx, defaultRetentionAttrTypes, &defaultRetentionModel{
+				Mode:  types.StringValue(aws.StringValue(retention.Mode)),
+				Days:  int64From(retention.Days),
+				Years: int64From(retention.Years),
+			}, diags),
+		}, diags)
+	}
+
+	state.ObjectLockConfiguration = objectFrom(ctx, objectLockConfigurationAttrTypes, olc, diags)
+	return nil
+}
+
+func readLogging(ctx context.Context, s3Client *s3.S3, state *bucketModel, diags *diag.Diagnostics) error {
+	output, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetBucketLoggingOutput, error) {
+		return s3Client.GetBucketLoggingWithContext(ctx, &s3.GetBucketLoggingInput{
+			Bucket: aws.String(state.Bucket.ValueString()),
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error getting S3 Bucket logging: %w", err)
+	}
+
+	var logging *loggingModel
+	if v := output.LoggingEnabled; v != nil {
+		logging = &loggingModel{
+			TargetBucket: types.StringValue(aws.StringValue(v.TargetBucket)),
+			TargetPrefix: stringFrom(v.TargetPrefix),
+		}
+	}
+
+	state.Logging = objectFrom(ctx, loggingAttrTypes, logging, diags)
+	return nil
+}
+
+func readLifecycle(ctx context.Context, s3Client *s3.S3, state *bucketModel, diags *diag.Diagnostics) error {
+	output, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetBucketLifecycleConfigurationOutput, error) {
+		return s3Client.GetBucketLifecycleConfigurationWithContext(ctx, &s3.GetBucketLifecycleConfigurationInput{
+			Bucket: aws.String(state.Bucket.ValueString()),
+		})
+	})
+	if err != nil && !yandex_storage.IsAWSErr(err, "NoSuchLifecycleConfiguration", "") {
+		return fmt.Errorf("error getting S3 Bucket lifecycle configuration: %w", err)
+	}
+
+	priorRules := listAs[lifecycleRuleModel](ctx, state.LifecycleRule, diags)
+
+	var rules []lifecycleRuleModel
+	if output != nil {
+		for i, lifecycleRule := range output.Rules {
+			tflog.Debug(ctx, fmt.Sprintf("S3 bucket: %s, read lifecycle rule: %v", state.Bucket.ValueString(), lifecycleRule))
+
+			var prior *lifecycleRuleModel
+			if i < len(priorRules) {
+				prior = &priorRules[i]
+			}
+			rules = append(rules, flattenLifecycleRule(ctx, lifecycleRule, prior, diags))
+		}
+	}
+
+	state.LifecycleRule = listFrom(ctx, lifecycleRuleAttrTypes, rules, diags)
+	return nil
+}
+
+func flattenLifecycleRule(ctx context.Context, lifecycleRule *s3.LifecycleRule, prior *lifecycleRuleModel, diags *diag.Diagnostics) lifecycleRuleModel {
+	rule := lifecycleRuleModel{
+		ID:                                 stringFrom(lifecycleRule.ID),
+		Prefix:                             types.StringNull(),
+		Tags:                               types.MapNull(types.StringType),
+		Enabled:                            types.BoolValue(aws.StringValue(lifecycleRule.Status) == s3.ExpirationStatusEnabled),
+		AbortIncompleteMultipartUploadDays: types.Int64Null(),
+		Expiration:                         types.ObjectNull(expirationAttrTypes),
+		NoncurrentVersionExpiration:        types.ObjectNull(noncurrentVersionExpirationAttrTypes),
+		Transition:                         types.SetNull(types.ObjectType{AttrTypes: transitionAttrTypes}),
+		NoncurrentVersionTransition:        types.SetNull(types.ObjectType{AttrTypes: noncurrentVersionTransitionAttrTypes}),
+	}
+
+	if filter := lifecycleRule.Filter; filter != nil {
+		if filter.And != nil {
+			rule.Prefix = stringFrom(filter.And.Prefix)
+			rule.Tags = stringMapFrom(yandex_storage.TagsToMap(filter.And.Tags))
+		} else {
+			rule.Prefix = stringFrom(filter.Prefix)
+		}
+	}
+
+	if v := lifecycleRule.AbortIncompleteMultipartUpload; v != nil {
+		rule.AbortIncompleteMultipartUploadDays = int64From(v.DaysAfterInitiation)
+	}
+
+	if v := lifecycleRule.Expiration; v != nil {
+		expiration := &expirationModel{
+			Date:                      types.StringNull(),
+			Days:                      int64From(v.Days),
+			ExpiredObjectDeleteMarker: types.BoolNull(),
+		}
+		if v.Date != nil {
+			expiration.Date = types.StringValue(aws.TimeValue(v.Date).Format(yandex_storage.LifecycleDateLayout))
+		}
+		if v.ExpiredObjectDeleteMarker != nil {
+			expiration.ExpiredObjectDeleteMarker = types.BoolValue(aws.BoolValue(v.ExpiredObjectDeleteMarker))
+		}
+
+		// The expiration that only keeps the delete markers is the one put for the rules without actions,
+		// so it is not shown unless it is configured.
+		onlyDefault := expiration.Date.IsNull() && expiration.Days.IsNull() && !aws.BoolValue(v.ExpiredObjectDeleteMarker)
+		if !onlyDefault || (prior != nil && !prior.Expiration.IsNull()) {
+			rule.Expiration = objectFrom(ctx, expirationAttrTypes, expiration, diags)
+		}
+	}
+
+	if v := lifecycleRule.NoncurrentVersionExpiration; v != nil {
+		rule.NoncurrentVersionExpiration = objectFrom(ctx, noncurrentVersionExpirationAttrTypes, &noncurrentVersionExpirationModel{
+			Days: int64From(v.NoncurrentDays),
+		}, diags)
+	}
+
+	var transitions []transitionModel
+	for _, v := range lifecycleRule.Transitions {
+		t := transitionModel{
+			Date:         types.StringNull(),
+			Days:         int64From(v.Days),
+			StorageClass: stringFrom(v.StorageClass),
+		}
+		if v.Date != nil {
+			t.Date = types.StringValue(aws.TimeValue(v.Date).Format(yandex_storage.LifecycleDateLayout))
+		}
+		transitions = append(transitions, t)
+	}
+	rule.Transition = setFrom(ctx, transitionAttrTypes, transitions, diags)
+
+	var noncurrentTransitions []noncurrentVersionTransitionModel
+	for _, v := range lifecycleRule.NoncurrentVersionTransitions {
+		noncurrentTransitions = append(noncurrentTransitions, noncurrentVersionTransitionModel{
+			Days:         int64From(v.NoncurrentDays),
+			StorageClass: stringFrom(v.StorageClass),
+		})
+	}
+	rule.NoncurrentVersionTransition = setFrom(ctx, noncurrentVersionTransitionAttrTypes, noncurrentTransitions, diags)
+
+	return rule
+}
+
+func readServerSideEncryption(ctx context.Context, s3Client *s3.S3, state *bucketModel, diags *diag.Diagnostics) error {
+	output, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetBucketEncryptionOutput, error) {
+		return s3Client.GetBucketEncryptionWithContext(ctx, &s3.GetBucketEncryptionInput{
+			Bucket: aws.String(state.Bucket.ValueString()),
+		})
+	})
+	if err != nil && !yandex_storage.IsAWSErr(err, "ServerSideEncryptionConfigurationNotFoundError", "encryption configuration was not found") {
+		return fmt.Errorf("error getting S3 Bucket encryption: %w", err)
+	}
+
+	var configuration *serverSideEncryptionConfigurationModel
+	if output != nil && output.ServerSideEncryptionConfiguration != nil {
+		for _, rule := range output.ServerSideEncryptionConfiguration.Rules {
+			byDefault := rule.ApplyServerSideEncryptionByDefault
+			if byDefault == nil {
+				continue
+			}
+
+			configuration = &serverSideEncryptionConfigurationModel{
+				Rule: objectFrom(ctx, serverSideEncryptionRuleAttrTypes, &serverSideEncryptionRuleModel{
+					ApplyServerSideEncryptionByDefault: objectFrom(ctx, serverSideEncryptionByDefaultAttrTypes, &serverSideEncryptionByDefaultModel{
+						KMSMasterKeyID: types.StringValue(aws.StringValue(byDefault.KMSMasterKeyID)),
+						SSEAlgorithm:   types.StringValue(aws.StringValue(byDefault.SSEAlgorithm)),
+					}, diags),
+				}, diags),
+			}
+			break
+		}
+	}
+
+	state.ServerSideEncryptionConfiguration = objectFrom(ctx, serverSideEncryptionConfigurationAttrTypes, configuration, diags)
+	return nil
+}
+
+func readTags(ctx context.Context, s3Client *s3.S3, state *bucketModel, _ *diag.Diagnostics) error {
+	output, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetBucketTaggingOutput, error) {
+		return s3Client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{
+			Bucket: aws.String(state.Bucket.ValueString()),
+		})
+	})
+	if err != nil && !yandex_storage.IsAWSErr(err, "NoSuchTagSet", "") {
+		return fmt.Errorf("error getting S3 Bucket tags: %w", err)
+	}
+
+	var tags []*s3.Tag
+	if output != nil {
+		tags = output.TagSet
+	}
+
+	state.Tags = stringMapFrom(yandex_storage.TagsToMap(tags))
+	return nil
+}
+
+func deleteBucket(ctx context.Context, s3Client *s3.S3, bucket string, forceDestroy bool) error {
+	tflog.Debug(ctx, fmt.Sprintf("Storage Delete Bucket: %s", bucket))
+
+	for {
+		_, err := yandex_storage.RetryOnAWSCodes(ctx, []string{"AccessDenied", "Forbidden"}, func() (*s3.DeleteBucketOutput, error) {
+			return s3Client.DeleteBucketWithContext(ctx, &s3.DeleteBucketInput{
+				Bucket: aws.String(bucket),
+			})
+		})
+		if yandex_storage.IsAWSErr(err, s3.ErrCodeNoSuchBucket, "") {
+			return nil
+		}
+		if err == nil {
+			break
+		}
+		if !yandex_storage.IsAWSErr(err, "BucketNotEmpty", "") || !forceDestroy {
+			return err
+		}
+
+		// bucket may have things delete them
+		tflog.Debug(ctx, fmt.Sprintf("Storage Bucket attempting to forceDestroy %+v", err))
+		if err := deleteObjectVersions(ctx, s3Client, bucket); err != nil {
+			return err
+		}
+	}
+
+	return yandex_storage.WaitConsistent(ctx, func() (bool, error) {
+		_, err := s3Client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		if yandex_storage.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+}
+
+func deleteObjectVersions(ctx context.Context, s3Client *s3.S3, bucket string) error {
+	output, err := s3Client.ListObjectVersionsWithContext(ctx, &s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return fmt.Errorf("error listing Storage Bucket object versions: %s", err)
+	}
+
+	objectsToDelete := make([]*s3.ObjectIdentifier, 0, len(output.DeleteMarkers)+len(output.Versions))
+	for _, v := range output.DeleteMarkers {
+		objectsToDelete = append(objectsToDelete, &s3.ObjectIdentifier{
+			Key:       v.Key,
+			VersionId: v.VersionId,
+		})
+	}
+	for _, v := range output.Versions {
+		objectsToDelete = append(objectsToDelete, &s3.ObjectIdentifier{
+			Key:       v.Key,
+			VersionId: v.VersionId,
+		})
+	}
+	if len(objectsToDelete) == 0 {
+		return nil
+	}
+
+	_, err = s3Client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &s3.Delete{
+			Objects: objectsToDelete,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error force_destroy deleting Storage Bucket (%s): %s", bucket, err)
+	}
+
+	return nil
+}
+
+func waitWebsitePut(ctx context.Context, s3Client *s3.S3, bucket string, configuration *s3.WebsiteConfiguration) error {
+	input := &s3.GetBucketWebsiteInput{Bucket: aws.String(bucket)}
+
+	err := yandex_storage.WaitConsistent(ctx, func() (bool, error) {
+		output, err := s3Client.GetBucketWebsiteWithContext(ctx, input)
+		if yandex_storage.IsAWSErr(err, "NoSuchWebsiteConfiguration", "") {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		outputConfiguration := &s3.WebsiteConfiguration{
+			ErrorDocument:         output.ErrorDocument,
+			IndexDocument:         output.IndexDocument,
+			RedirectAllRequestsTo: output.RedirectAllRequestsTo,
+			RoutingRules:          output.RoutingRules,
+		}
+		return reflect.DeepEqual(outputConfiguration, configuration), nil
+	})
+	if err != nil {
+		return fmt.Errorf("error assuring bucket %q website updated: %s", bucket, err)
+	}
+	return nil
+}
+
+func waitWebsiteDeleted(ctx context.Context, s3Client *s3.S3, bucket string) error {
+	input := &s3.GetBucketWebsiteInput{Bucket: aws.String(bucket)}
+
+	err := yandex_storage.WaitConsistent(ctx, func() (bool, error) {
+		_, err := s3Client.GetBucketWebsiteWithContext(ctx, input)
+		if yandex_storage.IsAWSErr(err, "NoSuchWebsiteConfiguration", "") {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("error assuring bucket %q website deleted: %s", bucket, err)
+	}
+	return nil
+}
+
+func waitCORSPut(ctx context.Context, s3Client *s3.S3, bucket string, configuration *s3.CORSConfiguration) error {
+	input := &s3.GetBucketCorsInput{Bucket: aws.String(bucket)}
+
+	expected := make([]*s3.CORSRule, 0, len(configuration.CORSRules))
+	for _, rule := range configuration.CORSRules {
+		r := *rule
+		if r.ExposeHeaders == nil {
+			r.ExposeHeaders = make([]*string, 0)
+		}
+		if r.AllowedHeaders == nil {
+			r.AllowedHeaders = make([]*string, 0)
+		}
+		expected = append(expected, &r)
+	}
+
+	err := yandex_storage.WaitConsistent(ctx, func() (bool, error) {
+		output, err := s3Client.GetBucketCorsWithContext(ctx, input)
+		if yandex_storage.IsAWSErr(err, "NoSuchCORSConfiguration", "") {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		for _, rule := range output.CORSRules {
+			if rule.ExposeHeaders == nil {
+				rule.ExposeHeaders = make([]*string, 0)
+			}
+			if rule.AllowedHeaders == nil {
+				rule.AllowedHeaders = make([]*string, 0)
+			}
+		}
+		return reflect.DeepEqual(output.CORSRules, expected), nil
+	})
+	if err != nil {
+		return fmt.Errorf("error assuring bucket %q CORS updated: %s", bucket, err)
+	}
+	return nil
+}
+
+func waitCORSDeleted(ctx context.Context, s3Client *s3.S3, bucket string) error {
+	input := &s3.GetBucketCorsInput{Bucket: aws.String(bucket)}
+
+	err := yandex_storage.WaitConsistent(ctx, func() (bool, error) {
+		_, err := s3Client.GetBucketCorsWithContext(ctx, input)
+		if yandex_storage.IsAWSErr(err, "NoSuchCORSConfiguration", "") {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("error assuring bucket %q CORS deleted: %s", bucket, err)
+	}
+	return nil
+}
+
+func normalizeRoutingRules(w []*s3.RoutingRule) (string, error) {
+	withNulls, err := json.Marshal(w)
+	if err != nil {
+		return "", err
+	}
+
+	var rules []map[string]interface{}
+	if err := json.Unmarshal(withNulls, &rules); err != nil {
+		return "", err
+	}
+
+	cleanRules := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		cleanRules = append(cleanRules, removeNil(rule))
+	}
+
+	withoutNulls, err := json.Marshal(cleanRules)
+	if err != nil {
+		return "", err
+	}
+
+	return string(withoutNulls), nil
+}
+
+func removeNil(data map[string]interface{}) map[string]interface{} {
+	withoutNil := make(map[string]interface{})
+
+	for k, v := range data {
+		if v == nil {
+			continue
+		}
+
+		switch v := v.(type) {
+		case map[string]interface{}:
+			withoutNil[k] = removeNil(v)
+		default:
+			withoutNil[k] = v
+		}
+	}
+
+	return withoutNil
+}
+
+func validateBucketPermissions(permissions []string) error {
+	var (
+		fullControl     bool
+		permissionRead  bool
+		permissionWrite bool
+	)
+
+	for _, p := range permissions {
+		switch p {
+		case s3.PermissionFullControl:
+			fullControl = true
+		case s3.PermissionRead:
+			permissionRead = true
+		case s3.PermissionWrite:
+			permissionWrite = true
+		}
+	}
+
+	if fullControl && len(permissions) > 1 {
+		return fmt.Errorf("do not use other ACP permissions along with `FULL_CONTROL` permission for Storage Bucket")
+	}
+
+	if permissionWrite && !permissionRead {
+		return fmt.Errorf("should always provide `READ` permission, when granting `WRITE` for Storage Bucket")
+	}
+
+	return nil
+}
@@ -0,0 +1,528 @@
+package yandex_storage_bucket
+
+import (
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-storage"
+)
+
+const (
+	storageClassStandard = s3.StorageClassStandardIa
+	storageClassCold     = "COLD"
+	storageClassIce      = "ICE"
+)
+
+var storageClassSet = []string{
+	storageClassStandard,
+	storageClassCold,
+	storageClassIce,
+}
+
+const (
+	bucketACLOwnerFullControl = "bucket-owner-full-control"
+	bucketACLPublicRead       = s3.BucketCannedACLPublicRead
+	bucketACLPublicReadWrite  = s3.BucketCannedACLPublicReadWrite
+	bucketACLAuthRead         = s3.BucketCannedACLAuthenticatedRead
+	bucketACLPrivate          = s3.BucketCannedACLPrivate
+)
+
+var bucketACLAllowedValues = []string{
+	bucketACLOwnerFullControl,
+	bucketACLPublicRead,
+	bucketACLPublicReadWrite,
+	bucketACLAuthRead,
+	bucketACLPrivate,
+}
+
+func bucketSchema() schema.Schema {
+	return schema.Schema{
+		Version:     1,
+		Description: "Allows management of a Yandex Cloud Object Storage bucket.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The name of the bucket.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket": schema.StringAttribute{
+				Description: "The name of the bucket. If omitted, Terraform will assign a random, unique name.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("bucket_prefix")),
+					yandex_storage.BucketName(),
+				},
+			},
+			"bucket_prefix": schema.StringAttribute{
+				Description: "Creates a unique bucket name beginning with the specified prefix. Conflicts with `bucket`.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"bucket_domain_name": schema.StringAttribute{
+				Description: "The bucket domain name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"access_key": schema.StringAttribute{
+				Description: "The access key to use when applying changes. If omitted, `storage_access_key` specified in provider config is used.",
+				Optional:    true,
+			},
+			"secret_key": schema.StringAttribute{
+				Description: "The secret key to use when applying changes. If omitted, `storage_secret_key` specified in provider config is used.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"acl": schema.StringAttribute{
+				Description: "The predefined ACL to apply. Conflicts with `grant`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(bucketACLAllowedValues...),
+					stringvalidator.ConflictsWith(path.MatchRoot("grant")),
+				},
+			},
+			"grant": schema.SetNestedAttribute{
+				Description: "An ACL policy grant. Conflicts with `acl`.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Canonical user id to grant for. Used only when type is `CanonicalUser`.",
+							Optional:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of grantee to apply for. Valid values are `CanonicalUser` and `Group`.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(s3.TypeCanonicalUser, s3.TypeGroup),
+							},
+						},
+						"uri": schema.StringAttribute{
+							Description: "URI address to grant for. Used only when type is `Group`.",
+							Optional:    true,
+						},
+						"permissions": schema.SetAttribute{
+							Description: "List of permissions to apply for grantee. Valid values are `READ`, `WRITE`, `FULL_CONTROL`.",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(
+									stringvalidator.OneOf(s3.PermissionFullControl, s3.PermissionRead, s3.PermissionWrite),
+								),
+							},
+						},
+					},
+				},
+			},
+			"policy": schema.StringAttribute{
+				Description: "A valid bucket policy JSON document.",
+				Optional:    true,
+				Validators: []validator.String{
+					yandex_storage.JSON(),
+				},
+			},
+			"cors_rule": schema.ListNestedAttribute{
+				Description: "A rule of Cross-Origin Resource Sharing.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"allowed_headers": schema.ListAttribute{
+							Description: "Specifies which headers are allowed.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"allowed_methods": schema.ListAttribute{
+							Description: "Specifies which methods are allowed. Can be `GET`, `PUT`, `POST`, `DELETE` or `HEAD`.",
+							Required:    true,
+							ElementType: types.StringType,
+						},
+						"allowed_origins": schema.ListAttribute{
+							Description: "Specifies which origins are allowed.",
+							Required:    true,
+							ElementType: types.StringType,
+						},
+						"expose_headers": schema.ListAttribute{
+							Description: "Specifies expose header in the response.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"max_age_seconds": schema.Int64Attribute{
+							Description: "Specifies time in seconds that browser can cache the response for a preflight request.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"website": schema.SingleNestedAttribute{
+				Description: "A website object.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"index_document": schema.StringAttribute{
+						Description: "Storage returns this index document when requests are made to the root domain or any of the subfolders.",
+						Optional:    true,
+					},
+					"error_document": schema.StringAttribute{
+						Description: "An absolute path to the document to return in case of a 4XX error.",
+						Optional:    true,
+					},
+					"redirect_all_requests_to": schema.StringAttribute{
+						Description: "A hostname to redirect all website requests for this bucket to. Hostname can optionally be prefixed with a protocol (`http://` or `https://`) to use when redirecting requests. The default is the protocol that is used in the original request.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(
+								path.MatchRelative().AtParent().AtName("index_document"),
+								path.MatchRelative().AtParent().AtName("error_document"),
+								path.MatchRelative().AtParent().AtName("routing_rules"),
+							),
+						},
+					},
+					"routing_rules": schema.StringAttribute{
+						Description: "A JSON array containing routing rules describing redirect behavior and when redirects are applied.",
+						Optional:    true,
+						Validators: []validator.String{
+							yandex_storage.JSON(),
+						},
+					},
+				},
+			},
+			"website_endpoint": schema.StringAttribute{
+				Description: "The website endpoint, if the bucket is configured with a website.",
+				Computed:    true,
+			},
+			"website_domain": schema.StringAttribute{
+				Description: "The domain of the website endpoint, if the bucket is configured with a website.",
+				Computed:    true,
+			},
+			"versioning": schema.SingleNestedAttribute{
+				Description: "A state of versioning.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Description: "Enable versioning. Once you version-enable a bucket, it can never return to an unversioned state. You can, however, suspend versioning on that bucket.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+				},
+			},
+			"object_lock_configuration": schema.SingleNestedAttribute{
+				Description: "A configuration of object lock management.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"object_lock_enabled": schema.StringAttribute{
+						Description: "Enable object locking in a bucket. Require versioning to be enabled.",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString(s3.ObjectLockEnabledEnabled),
+						Validators: []validator.String{
+							stringvalidator.OneOf(s3.ObjectLockEnabled_Values()...),
+						},
+					},
+					"rule": schema.SingleNestedAttribute{
+						Description: "Specifies a default locking configuration for added objects. Require object_lock_enabled to be enabled.",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"default_retention": schema.SingleNestedAttribute{
+								Description: "Default retention of the added objects.",
+								Required:    true,
+								Attributes: map[string]schema.Attribute{
+									"mode": schema.StringAttribute{
+										Description: "Specifies a type of object lock. One of `GOVERNANCE` or `COMPLIANCE`.",
+										Required:    true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(s3.ObjectLockRetentionMode_Values()...),
+										},
+									},
+									"days": schema.Int64Attribute{
+										Description: "Specifies a retention period in days after uploading an object version. It must be a positive integer. You can't set it simultaneously with `years`.",
+										Optional:    true,
+										Validators: []validator.Int64{
+											int64validator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("years")),
+										},
+									},
+									"years": schema.Int64Attribute{
+										Description: "Specifies a retention period in years after uploading an object version. It must be a positive integer. You can't set it simultaneously with `days`.",
+										Optional:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"logging": schema.SingleNestedAttribute{
+				Description: "A settings of bucket logging.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"target_bucket": schema.StringAttribute{
+						Description: "The name of the bucket that will receive the log objects.",
+						Required:    true,
+					},
+					"target_prefix": schema.StringAttribute{
+						Description: "To specify a key prefix for log objects.",
+						Optional:    true,
+					},
+				},
+			},
+			"lifecycle_rule": schema.ListNestedAttribute{
+				Description: "A configuration of object lifecycle management.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: lifecycleRuleAttributes(),
+				},
+			},
+			"force_destroy": schema.BoolAttribute{
+				Description: "A boolean that indicates all objects should be deleted from the bucket so that the bucket can be destroyed without error. These objects are *not* recoverable.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"server_side_encryption_configuration": schema.SingleNestedAttribute{
+				Description: "A configuration of server-side encryption for the bucket.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"rule": schema.SingleNestedAttribute{
+						Description: "A single object for server-side encryption by default configuration.",
+						Required:    true,
+						Attributes: map[string]schema.Attribute{
+							"apply_server_side_encryption_by_default": schema.SingleNestedAttribute{
+								Description: "A single object for setting server-side encryption by default.",
+								Required:    true,
+								Attributes: map[string]schema.Attribute{
+									"kms_master_key_id": schema.StringAttribute{
+										Description: "The KMS master key ID used for the SSE-KMS encryption.",
+										Required:    true,
+									},
+									"sse_algorithm": schema.StringAttribute{
+										Description: "The server-side encryption algorithm to use. Single valid value is `aws:kms`.",
+										Required:    true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(s3.ServerSideEncryptionAwsKms),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			// These attributes use extended API and requires IAM token
+			// to be set in order to operate.
+			"default_storage_class": schema.StringAttribute{
+				Description: "Storage class which is used for storing objects by default. Available values are: \"STANDARD\", \"COLD\", \"ICE\".",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"folder_id": schema.StringAttribute{
+				Description: "Allow to create bucket in different folder.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_size": schema.Int64Attribute{
+				Description: "The size of bucket, in bytes.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"anonymous_access_flags": schema.SingleNestedAttribute{
+				Description: "Provides various access to objects.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"list": schema.BoolAttribute{
+						Description: "Allows to list object in bucket anonymously.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+					"read": schema.BoolAttribute{
+						Description: "Allows to read objects in bucket anonymously.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+					"config_read": schema.BoolAttribute{
+						Description: "Allows to read bucket configuration anonymously.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+				},
+			},
+			"https": schema.SingleNestedAttribute{
+				Description: "Manages https certificates for bucket.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"certificate_id": schema.StringAttribute{
+						Description: "Id of the certificate in Certificate Manager, that will be used for bucket.",
+						Required:    true,
+					},
+				},
+			},
+			"tags": schema.MapAttribute{
+				Description: "The tags object.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func lifecycleRuleAttributes() map[string]schema.Attribute {
+	storageClassValidators := []validator.String{
+		stringvalidator.OneOf(storageClassSet...),
+	}
+
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "Unique identifier for the rule. Must be less than or equal to 255 characters in length.",
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+			Validators: []validator.String{
+				stringvalidator.LengthBetween(0, 255),
+			},
+		},
+		"prefix": schema.StringAttribute{
+			Description: "Object key prefix identifying one or more objects to which the rule applies.",
+			Optional:    true,
+		},
+		"tags": schema.MapAttribute{
+			Description: "Object tags identifying one or more objects to which the rule applies.",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"enabled": schema.BoolAttribute{
+			Description: "Specifies lifecycle rule status.",
+			Required:    true,
+		},
+		"abort_incomplete_multipart_upload_days": schema.Int64Attribute{
+			Description: "Specifies the number of days after initiating a multipart upload when the multipart upload must be completed.",
+			Optional:    true,
+		},
+		"expiration": schema.SingleNestedAttribute{
+			Description: "Specifies a period in the object's expire.",
+			Optional:    true,
+			Attributes: map[string]schema.Attribute{
+				"date": schema.StringAttribute{
+					Description: "Specifies the date after which you want the corresponding action to take effect.",
+					Optional:    true,
+					Validators: []validator.String{
+						yandex_storage.LifecycleDate(),
+					},
+				},
+				"days": schema.Int64Attribute{
+					Description: "Specifies the number of days after object creation when the specific rule action takes effect.",
+					Optional:    true,
+					Validators: []validator.Int64{
+						int64validator.AtLeast(0),
+					},
+				},
+				"expired_object_delete_marker": schema.BoolAttribute{
+					Description: "On a versioned bucket, you can specify whether Object Storage will delete object delete markers with no noncurrent versions.",
+					Optional:    true,
+				},
+			},
+		},
+		"noncurrent_version_expiration": schema.SingleNestedAttribute{
+			Description: "Specifies when noncurrent object versions expire.",
+			Optional:    true,
+			Attributes: map[string]schema.Attribute{
+				"days": schema.Int64Attribute{
+					Description: "Specifies the number of days noncurrent object versions expire.",
+					Optional:    true,
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+			},
+		},
+		"transition": schema.SetNestedAttribute{
+			Description: "Specifies a period in the object's transitions.",
+			Optional:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"date": schema.StringAttribute{
+						Description: "Specifies the date after which you want the corresponding action to take effect.",
+						Optional:    true,
+						Validators: []validator.String{
+							yandex_storage.LifecycleDate(),
+						},
+					},
+					"days": schema.Int64Attribute{
+						Description: "Specifies the number of days after object creation when the specific rule action takes effect.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"storage_class": schema.StringAttribute{
+						Description: "Specifies the storage class to which you want the object to transition.",
+						Required:    true,
+						Validators:  storageClassValidators,
+					},
+				},
+			},
+		},
+		"noncurrent_version_transition": schema.SetNestedAttribute{
+			Description: "Specifies when noncurrent object versions transitions.",
+			Optional:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"days": schema.Int64Attribute{
+						Description: "Specifies the number of days noncurrent object versions transition.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"storage_class": schema.StringAttribute{
+						Description: "Specifies the storage class to which you want the noncurrent object versions to transition.",
+						Required:    true,
+						Validators:  storageClassValidators,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,369 @@
+package yandex_storage_bucket
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The state of the bucket written by the SDKv2 implementation of the resource. Nested blocks are
+// kept as lists, and the optional attributes that are not set have zero values.
+
+type bucketStateV0 struct {
+	ID                                string                                     `json:"id"`
+	Bucket                            string                                     `json:"bucket"`
+	BucketPrefix                      *string                                    `json:"bucket_prefix"`
+	BucketDomainName                  *string                                    `json:"bucket_domain_name"`
+	AccessKey                         *string                                    `json:"access_key"`
+	SecretKey                         *string                                    `json:"secret_key"`
+	ACL                               *string                                    `json:"acl"`
+	Grant                             []grantStateV0                             `json:"grant"`
+	Policy                            *string                                    `json:"policy"`
+	CORSRule                          []corsRuleStateV0                          `json:"cors_rule"`
+	Website                           []websiteStateV0                           `json:"website"`
+	WebsiteEndpoint                   *string                                    `json:"website_endpoint"`
+	WebsiteDomain                     *string                                    `json:"website_domain"`
+	Versioning                        []versioningStateV0                        `json:"versioning"`
+	ObjectLockConfiguration           []objectLockConfigurationStateV0           `json:"object_lock_configuration"`
+	Logging                           []loggingStateV0                           `json:"logging"`
+	LifecycleRule                     []lifecycleRuleStateV0                     `json:"lifecycle_rule"`
+	ForceDestroy                      *bool                                      `json:"force_destroy"`
+	ServerSideEncryptionConfiguration []serverSideEncryptionConfigurationStateV0 `json:"server_side_encryption_configuration"`
+	DefaultStorageClass               *string                                    `json:"default_storage_class"`
+	FolderID                          *string                                    `json:"folder_id"`
+	MaxSize                           *int64                                     `json:"max_size"`
+	AnonymousAccessFlags              []anonymousAccessFlagsStateV0              `json:"anonymous_access_flags"`
+	HTTPS                             []httpsStateV0                             `json:"https"`
+	Tags                              map[string]string                          `json:"tags"`
+}
+
+type grantStateV0 struct {
+	ID          *string  `json:"id"`
+	Type        *string  `json:"type"`
+	URI         *string  `json:"uri"`
+	Permissions []string `json:"permissions"`
+}
+
+type corsRuleStateV0 struct {
+	AllowedHeaders []string `json:"allowed_headers"`
+	AllowedMethods []string `json:"allowed_methods"`
+	AllowedOrigins []string `json:"allowed_origins"`
+	ExposeHeaders  []string `json:"expose_headers"`
+	MaxAgeSeconds  *int64   `json:"max_age_seconds"`
+}
+
+type websiteStateV0 struct {
+	IndexDocument         *string `json:"index_document"`
+	ErrorDocument         *string `json:"error_document"`
+	RedirectAllRequestsTo *string `json:"redirect_all_requests_to"`
+	RoutingRules          *string `json:"routing_rules"`
+}
+
+type versioningStateV0 struct {
+	Enabled *bool `json:"enabled"`
+}
+
+type objectLockConfigurationStateV0 struct {
+	ObjectLockEnabled *string `json:"object_lock_enabled"`
+	Rule              []struct {
+		DefaultRetention []struct {
+			Mode  *string `json:"mode"`
+			Days  *int64  `json:"days"`
+			Years *int64  `json:"years"`
+		} `json:"default_retention"`
+	} `json:"rule"`
+}
+
+type loggingStateV0 struct {
+	TargetBucket *string `json:"target_bucket"`
+	TargetPrefix *string `json:"target_prefix"`
+}
+
+type lifecycleRuleStateV0 struct {
+	ID                                 *string           `json:"id"`
+	Prefix                             *string           `json:"prefix"`
+	Tags                               map[string]string `json:"tags"`
+	Enabled                            *bool             `json:"enabled"`
+	AbortIncompleteMultipartUploadDays *int64            `json:"abort_incomplete_multipart_upload_days"`
+	Expiration                         []struct {
+		Date                      *string `json:"date"`
+		Days                      *int64  `json:"days"`
+		ExpiredObjectDeleteMarker *bool   `json:"expired_object_delete_marker"`
+	} `json:"expiration"`
+	NoncurrentVersionExpiration []struct {
+		Days *int64 `json:"days"`
+	} `json:"noncurrent_version_expiration"`
+	Transition []struct {
+		Date         *string `json:"date"`
+		Days         *int64  `json:"days"`
+		StorageClass *string `json:"storage_class"`
+	} `json:"transition"`
+	NoncurrentVersionTransition []struct {
+		Days         *int64  `json:"days"`
+		StorageClass *string `json:"storage_class"`
+	} `json:"noncurrent_version_transition"`
+}
+
+type serverSideEncryptionConfigurationStateV0 struct {
+	Rule []struct {
+		ApplyServerSideEncryptionByDefault []struct {
+			KMSMasterKeyID *string `json:"kms_master_key_id"`
+			SSEAlgorithm   *string `json:"sse_algorithm"`
+		} `json:"apply_server_side_encryption_by_default"`
+	} `json:"rule"`
+}
+
+type anonymousAccessFlagsStateV0 struct {
+	List       *bool `json:"list"`
+	Read       *bool `json:"read"`
+	ConfigRead *bool `json:"config_read"`
+}
+
+type httpsStateV0 struct {
+	CertificateID *string `json:"certificate_id"`
+}
+
+// upgradeBucketStateV0 converts the SDKv2 state of the bucket to the nested attributes. The values
+// that are computed by the API are refreshed by the next read.
+func upgradeBucketStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The prior state of the bucket is empty.")
+		return
+	}
+
+	var prior bucketStateV0
+	if err := json.Unmarshal(req.RawState.JSON, &prior); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			"There was an error reading the prior state of the bucket:\n\n"+err.Error(),
+		)
+		return
+	}
+
+	diags := &resp.Diagnostics
+	state := bucketModel{
+		ID:               types.StringValue(prior.ID),
+		Bucket:           types.StringValue(prior.Bucket),
+		BucketPrefix:     stringFrom(prior.BucketPrefix),
+		BucketDomainName: stringFrom(prior.BucketDomainName),
+		AccessKey:        stringFrom(prior.AccessKey),
+		SecretKey:        stringFrom(prior.SecretKey),
+		ACL:              stringFrom(prior.ACL),
+		Policy:           stringFrom(prior.Policy),
+		ForceDestroy:     types.BoolValue(prior.ForceDestroy != nil && *prior.ForceDestroy),
+		// The properties of the extended API are kept as the SDKv2 implementation read them.
+		DefaultStorageClass: stringFrom(prior.DefaultStorageClass),
+		FolderID:            stringFrom(prior.FolderID),
+		MaxSize:             types.Int64Null(),
+		Tags:                stringMapFrom(prior.Tags),
+	}
+	if prior.MaxSize != nil {
+		state.MaxSize = types.Int64Value(*prior.MaxSize)
+	}
+	if state.Bucket.ValueString() == "" {
+		state.Bucket = state.ID
+	}
+
+	var grants []grantModel
+	for _, g := range prior.Grant {
+		grants = append(grants, grantModel{
+			ID:          stringFrom(g.ID),
+			Type:        stringFrom(g.Type),
+			URI:         stringFrom(g.URI),
+			Permissions: stringSetV0(g.Permissions),
+		})
+	}
+	state.Grant = setFrom(ctx, grantAttrTypes, grants, diags)
+
+	var corsRules []corsRuleModel
+	for _, r := range prior.CORSRule {
+		corsRules = append(corsRules, corsRuleModel{
+			AllowedHeaders: stringListFrom(aws.StringSlice(r.AllowedHeaders)),
+			AllowedMethods: stringListFrom(aws.StringSlice(r.AllowedMethods)),
+			AllowedOrigins: stringListFrom(aws.StringSlice(r.AllowedOrigins)),
+			ExposeHeaders:  stringListFrom(aws.StringSlice(r.ExposeHeaders)),
+			MaxAgeSeconds:  int64V0(r.MaxAgeSeconds),
+		})
+	}
+	state.CORSRule = listFrom(ctx, corsRuleAttrTypes, corsRules, diags)
+
+	var website *websiteModel
+	if len(prior.Website) > 0 {
+		w := prior.Website[0]
+		website = &websiteModel{
+			IndexDocument:         stringFrom(w.IndexDocument),
+			ErrorDocument:         stringFrom(w.ErrorDocument),
+			RedirectAllRequestsTo: stringFrom(w.RedirectAllRequestsTo),
+			RoutingRules:          stringFrom(w.RoutingRules),
+		}
+	}
+	state.Website = objectFrom(ctx, websiteAttrTypes, website, diags)
+	setWebsiteEndpoint(&state)
+
+	versioning := &versioningModel{Enabled: types.BoolValue(false)}
+	if len(prior.Versioning) > 0 && prior.Versioning[0].Enabled != nil {
+		versioning.Enabled = types.BoolValue(*prior.Versioning[0].Enabled)
+	}
+	state.Versioning = objectFrom(ctx, versioningAttrTypes, versioning, diags)
+
+	var objectLock *objectLockConfigurationModel
+	if len(prior.ObjectLockConfiguration) > 0 {
+		olc := prior.ObjectLockConfiguration[0]
+		objectLock = &objectLockConfigurationModel{
+			ObjectLockEnabled: stringFrom(olc.ObjectLockEnabled),
+			Rule:              types.ObjectNull(objectLockRuleAttrTypes),
+		}
+		if len(olc.Rule) > 0 && len(olc.Rule[0].DefaultRetention) > 0 {
+			retention := olc.Rule[0].DefaultRetention[0]
+			objectLock.Rule = objectFrom(ctx, objectLockRuleAttrTypes, &objectLockRuleModel{
+				DefaultRetention: objectFrom(ctx, defaultRetentionAttrTypes, &defaultRetentionModel{
+					Mode:  stringFrom(retention.Mode),
+					Days:  int64V0(retention.Days),
+					Years: int64V0(retention.Years),
+				}, diags),
+			}, diags)
+		}
+	}
+	state.ObjectLockConfiguration = objectFrom(ctx, objectLockConfigurationAttrTypes, objectLock, diags)
+
+	var logging *loggingModel
+	if len(prior.Logging) > 0 {
+		logging = &loggingModel{
+			TargetBucket: stringFrom(prior.Logging[0].TargetBucket),
+			TargetPrefix: stringFrom(prior.Logging[0].TargetPrefix),
+		}
+	}
+	state.Logging = objectFrom(ctx, loggingAttrTypes, logging, diags)
+
+	var lifecycleRules []lifecycleRuleModel
+	for _, r := range prior.LifecycleRule {
+		lifecycleRules = append(lifecycleRules, upgradeLifecycleRuleStateV0(ctx, r, diags))
+	}
+	state.LifecycleRule = listFrom(ctx, lifecycleRuleAttrTypes, lifecycleRules, diags)
+
+	var sse *serverSideEncryptionConfigurationModel
+	if len(prior.ServerSideEncryptionConfiguration) > 0 {
+		c := prior.ServerSideEncryptionConfiguration[0]
+		if len(c.Rule) > 0 && len(c.Rule[0].ApplyServerSideEncryptionByDefault) > 0 {
+			byDefault := c.Rule[0].ApplyServerSideEncryptionByDefault[0]
+			sse = &serverSideEncryptionConfigurationModel{
+				Rule: objectFrom(ctx, serverSideEncryptionRuleAttrTypes, &serverSideEncryptionRuleModel{
+					ApplyServerSideEncryptionByDefault: objectFrom(ctx, serverSideEncryptionByDefaultAttrTypes, &serverSideEncryptionByDefaultModel{
+						KMSMasterKeyID: stringFrom(byDefault.KMSMasterKeyID),
+						SSEAlgorithm:   stringFrom(byDefault.SSEAlgorithm),
+					}, diags),
+				}, diags),
+			}
+		}
+	}
+	state.ServerSideEncryptionConfiguration = objectFrom(ctx, serverSideEncryptionConfigurationAttrTypes, sse, diags)
+
+	var flags *anonymousAccessFlagsModel
+	if len(prior.AnonymousAccessFlags) > 0 {
+		f := prior.AnonymousAccessFlags[0]
+		flags = &anonymousAccessFlagsModel{
+			List:       types.BoolValue(f.List != nil && *f.List),
+			Read:       types.BoolValue(f.Read != nil && *f.Read),
+			ConfigRead: types.BoolValue(f.ConfigRead != nil && *f.ConfigRead),
+		}
+	}
+	state.AnonymousAccessFlags = objectFrom(ctx, anonymousAccessFlagsAttrTypes, flags, diags)
+
+	var https *httpsModel
+	if len(prior.HTTPS) > 0 {
+		https = &httpsModel{CertificateID: stringFrom(prior.HTTPS[0].CertificateID)}
+	}
+	state.HTTPS = objectFrom(ctx, httpsAttrTypes, https, diags)
+
+	if diags.HasError() {
+		return
+	}
+
+	diags.Append(resp.State.Set(ctx, &state)...)
+}
+
+func upgradeLifecycleRuleStateV0(ctx context.Context, r lifecycleRuleStateV0, diags *diag.Diagnostics) lifecycleRuleModel {
+	rule := lifecycleRuleModel{
+		ID:                                 stringFrom(r.ID),
+		Prefix:                             stringFrom(r.Prefix),
+		Tags:                               stringMapFrom(r.Tags),
+		Enabled:                            types.BoolValue(r.Enabled != nil && *r.Enabled),
+		AbortIncompleteMultipartUploadDays: int64V0(r.AbortIncompleteMultipartUploadDays),
+		Expiration:                         types.ObjectNull(expirationAttrTypes),
+		NoncurrentVersionExpiration:        types.ObjectNull(noncurrentVersionExpirationAttrTypes),
+	}
+
+	if len(r.Expiration) > 0 {
+		e := r.Expiration[0]
+		expiration := &expirationModel{
+			Date:                      stringFrom(e.Date),
+			Days:                      int64V0(e.Days),
+			ExpiredObjectDeleteMarker: types.BoolNull(),
+		}
+		if e.ExpiredObjectDeleteMarker != nil && *e.ExpiredObjectDeleteMarker {
+			expiration.ExpiredObjectDeleteMarker = types.BoolValue(true)
+		}
+		rule.Expiration = objectFrom(ctx, expirationAttrTypes, expiration, diags)
+	}
+
+	if len(r.NoncurrentVersionExpiration) > 0 {
+		rule.NoncurrentVersionExpiration = objectFrom(ctx, noncurrentVersionExpirationAttrTypes, &noncurrentVersionExpirationModel{
+			Days: int64V0(r.NoncurrentVersionExpiration[0].Days),
+		}, diags)
+	}
+
+	var transitions []transitionModel
+	for _, t := range r.Transition {
+		transition := transitionModel{
+			Date:         stringFrom(t.Date),
+			Days:         types.Int64Null(),
+			StorageClass: stringFrom(t.StorageClass),
+		}
+		// Zero days are a valid transition, so the days are kept unless the date is set.
+		if transition.Date.IsNull() && t.Days != nil {
+			transition.Days = types.Int64Value(*t.Days)
+		}
+		transitions = append(transitions, transition)
+	}
+	rule.Transition = setFrom(ctx, transitionAttrTypes, transitions, diags)
+
+	var noncurrentTransitions []noncurrentVersionTransitionModel
+	for _, t := range r.NoncurrentVersionTransition {
+		transition := noncurrentVersionTransitionModel{
+			Days:         types.Int64Null(),
+			StorageClass: stringFrom(t.StorageClass),
+		}
+		if t.Days != nil {
+			transition.Days = types.Int64Value(*t.Days)
+		}
+		noncurrentTransitions = append(noncurrentTransitions, transition)
+	}
+	rule.NoncurrentVersionTransition = setFrom(ctx, noncurrentVersionTransitionAttrTypes, noncurrentTransitions, diags)
+
+	return rule
+}
+
+// int64V0 returns the value of the prior integer, null if it is not set or zero.
+func int64V0(value *int64) types.Int64 {
+	if value == nil || *value == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(*value)
+}
+
+func stringSetV0(values []string) types.Set {
+	if len(values) == 0 {
+		return types.SetNull(types.StringType)
+	}
+
+	elements := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, types.StringValue(v))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
@@ -0,0 +1,210 @@
+package yandex_storage_bucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBucketStateV0 is the state of a bucket written by the SDKv2 implementation of the resource,
+// with the empty blocks, empty strings and zero numbers it writes for the unset attributes.
+const testBucketStateV0 = `{
+	"id": "tf-bucket",
+	"bucket": "tf-bucket",
+	"bucket_prefix": null,
+	"bucket_domain_name": "tf-bucket.storage.yandexcloud.net",
+	"access_key": "",
+	"secret_key": null,
+	"acl": "private",
+	"grant": [],
+	"policy": "",
+	"cors_rule": [{
+		"allowed_headers": ["*"],
+		"allowed_methods": ["GET", "PUT"],
+		"allowed_origins": ["https://example.com"],
+		"expose_headers": [],
+		"max_age_seconds": 3000
+	}],
+	"website": [{
+		"index_document": "index.html",
+		"error_document": "",
+		"redirect_all_requests_to": "",
+		"routing_rules": ""
+	}],
+	"website_endpoint": "tf-bucket.website.yandexcloud.net",
+	"website_domain": "website.yandexcloud.net",
+	"versioning": [{"enabled": false}],
+	"object_lock_configuration": [{
+		"object_lock_enabled": "Enabled",
+		"rule": [{"default_retention": [{"mode": "GOVERNANCE", "days": 0, "years": 1}]}]
+	}],
+	"logging": [],
+	"lifecycle_rule": [{
+		"id": "cleanup",
+		"prefix": "logs/",
+		"tags": {},
+		"enabled": true,
+		"abort_incomplete_multipart_upload_days": 0,
+		"expiration": [{"date": "", "days": 30, "expired_object_delete_marker": false}],
+		"noncurrent_version_expiration": [],
+		"transition": [{"date": "", "days": 0, "storage_class": "COLD"}],
+		"noncurrent_version_transition": [{"days": 0, "storage_class": "COLD"}]
+	}],
+	"force_destroy": false,
+	"server_side_encryption_configuration": [],
+	"default_storage_class": "STANDARD",
+	"folder_id": "tf-folder",
+	"max_size": 0,
+	"anonymous_access_flags": [{"list": false, "read": true, "config_read": false}],
+	"https": [],
+	"tags": {}
+}`
+
+func upgradeBucketState(t *testing.T, priorJSON string) (bucketModel, *resource.UpgradeStateResponse) {
+	ctx := context.Background()
+	s := bucketSchema()
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}}
+	req := resource.UpgradeStateRequest{}
+	if priorJSON != "" {
+		req.RawState = &tfprotov6.RawState{JSON: []byte(priorJSON)}
+	}
+	upgradeBucketStateV0(ctx, req, resp)
+
+	var model bucketModel
+	if !resp.Diagnostics.HasError() {
+		require.False(t, resp.State.Get(ctx, &model).HasError())
+	}
+	return model, resp
+}
+
+func requireObjectAs[T any](t *testing.T, value types.Object) T {
+	var diags diag.Diagnostics
+	model := objectAs[T](context.Background(), value, &diags)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.NotNil(t, model, "the object must be set")
+	return *model
+}
+
+func TestUpgradeBucketStateV0(t *testing.T) {
+	ctx := context.Background()
+	state, resp := upgradeBucketState(t, testBucketStateV0)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	assert.Equal(t, "tf-bucket", state.ID.ValueString())
+	assert.Equal(t, "tf-bucket", state.Bucket.ValueString())
+	assert.True(t, state.BucketPrefix.IsNull())
+	assert.Equal(t, "tf-bucket.storage.yandexcloud.net", state.BucketDomainName.ValueString())
+	assert.True(t, state.AccessKey.IsNull(), "empty access key must be null")
+	assert.True(t, state.SecretKey.IsNull())
+	assert.Equal(t, "private", state.ACL.ValueString())
+	assert.True(t, state.Grant.IsNull(), "empty grants must be null")
+	assert.True(t, state.Policy.IsNull(), "empty policy must be null")
+	assert.Equal(t, types.BoolValue(false), state.ForceDestroy)
+	assert.Equal(t, "STANDARD", state.DefaultStorageClass.ValueString())
+	assert.Equal(t, "tf-folder", state.FolderID.ValueString())
+	assert.Equal(t, types.Int64Value(0), state.MaxSize, "max size must be kept from the prior state")
+	assert.True(t, state.Tags.IsNull(), "empty tags must be null")
+
+	var corsRules []corsRuleModel
+	require.False(t, state.CORSRule.ElementsAs(ctx, &corsRules, false).HasError())
+	require.Len(t, corsRules, 1)
+	assert.Len(t, corsRules[0].AllowedMethods.Elements(), 2)
+	assert.True(t, corsRules[0].ExposeHeaders.IsNull(), "empty expose headers must be null")
+	assert.Equal(t, int64(3000), corsRules[0].MaxAgeSeconds.ValueInt64())
+
+	website := requireObjectAs[websiteModel](t, state.Website)
+	assert.Equal(t, "index.html", website.IndexDocument.ValueString())
+	assert.True(t, website.ErrorDocument.IsNull())
+	assert.True(t, website.RoutingRules.IsNull())
+	assert.Equal(t, "tf-bucket.website.yandexcloud.net", state.WebsiteEndpoint.ValueString())
+	assert.Equal(t, "website.yandexcloud.net", state.WebsiteDomain.ValueString())
+
+	versioning := requireObjectAs[versioningModel](t, state.Versioning)
+	assert.Equal(t, types.BoolValue(false), versioning.Enabled)
+
+	objectLock := requireObjectAs[objectLockConfigurationModel](t, state.ObjectLockConfiguration)
+	assert.Equal(t, "Enabled", objectLock.ObjectLockEnabled.ValueString())
+	rule := requireObjectAs[objectLockRuleModel](t, objectLock.Rule)
+	retention := requireObjectAs[defaultRetentionModel](t, rule.DefaultRetention)
+	assert.Equal(t, "GOVERNANCE", retention.Mode.ValueString())
+	assert.True(t, retention.Days.IsNull(), "zero days of the retention must be null")
+	assert.Equal(t, int64(1), retention.Years.ValueInt64())
+
+	assert.True(t, state.Logging.IsNull(), "empty logging block must be null")
+	assert.True(t, state.ServerSideEncryptionConfiguration.IsNull())
+	assert.True(t, state.HTTPS.IsNull())
+
+	var lifecycleRules []lifecycleRuleModel
+	require.False(t, state.LifecycleRule.ElementsAs(ctx, &lifecycleRules, false).HasError())
+	require.Len(t, lifecycleRules, 1)
+	lifecycleRule := lifecycleRules[0]
+	assert.Equal(t, "cleanup", lifecycleRule.ID.ValueString())
+	assert.Equal(t, "logs/", lifecycleRule.Prefix.ValueString())
+	assert.True(t, lifecycleRule.Tags.IsNull())
+	assert.Equal(t, types.BoolValue(true), lifecycleRule.Enabled)
+	assert.True(t, lifecycleRule.AbortIncompleteMultipartUploadDays.IsNull())
+	assert.True(t, lifecycleRule.NoncurrentVersionExpiration.IsNull())
+
+	expiration := requireObjectAs[expirationModel](t, lifecycleRule.Expiration)
+	assert.True(t, expiration.Date.IsNull())
+	assert.Equal(t, int64(30), expiration.Days.ValueInt64())
+	assert.True(t, expiration.ExpiredObjectDeleteMarker.IsNull(), "unset delete marker must be null")
+
+	var transitions []transitionModel
+	require.False(t, lifecycleRule.Transition.ElementsAs(ctx, &transitions, false).HasError())
+	require.Len(t, transitions, 1)
+	assert.True(t, transitions[0].Date.IsNull())
+	assert.Equal(t, types.Int64Value(0), transitions[0].Days, "zero days of a transition must be kept")
+	assert.Equal(t, "COLD", transitions[0].StorageClass.ValueString())
+
+	var noncurrentTransitions []noncurrentVersionTransitionModel
+	require.False(t, lifecycleRule.NoncurrentVersionTransition.ElementsAs(ctx, &noncurrentTransitions, false).HasError())
+	require.Len(t, noncurrentTransitions, 1)
+	assert.Equal(t, types.Int64Value(0), noncurrentTransitions[0].Days)
+
+	flags := requireObjectAs[anonymousAccessFlagsModel](t, state.AnonymousAccessFlags)
+	assert.Equal(t, types.BoolValue(false), flags.List)
+	assert.Equal(t, types.BoolValue(true), flags.Read)
+	assert.Equal(t, types.BoolValue(false), flags.ConfigRead)
+}
+
+func TestUpgradeBucketStateV0_minimal(t *testing.T) {
+	// The state written by the old versions of the resource misses the attributes added later.
+	state, resp := upgradeBucketState(t, `{"id": "tf-bucket", "bucket": "", "acl": "private", "force_destroy": true}`)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	assert.Equal(t, "tf-bucket", state.Bucket.ValueString(), "empty bucket must be taken from the ID")
+	assert.Equal(t, types.BoolValue(true), state.ForceDestroy)
+	assert.True(t, state.DefaultStorageClass.IsNull())
+	assert.True(t, state.FolderID.IsNull())
+	assert.True(t, state.MaxSize.IsNull(), "missing max size must be null")
+	assert.True(t, state.CORSRule.IsNull())
+	assert.True(t, state.Website.IsNull())
+	assert.True(t, state.WebsiteEndpoint.IsNull())
+	assert.True(t, state.WebsiteDomain.IsNull())
+	assert.True(t, state.ObjectLockConfiguration.IsNull())
+	assert.True(t, state.LifecycleRule.IsNull())
+	assert.True(t, state.AnonymousAccessFlags.IsNull())
+
+	versioning := requireObjectAs[versioningModel](t, state.Versioning)
+	assert.Equal(t, types.BoolValue(false), versioning.Enabled, "missing versioning must be disabled")
+}
+
+func TestUpgradeBucketStateV0_empty(t *testing.T) {
+	_, resp := upgradeBucketState(t, "")
+	assert.True(t, resp.Diagnostics.HasError(), "empty prior state must be reported")
+
+	_, resp = upgradeBucketState(t, `{"id": 1}`)
+	assert.True(t, resp.Diagnostics.HasError(), "invalid prior state must be reported")
+}
@@ -0,0 +1,480 @@
+package yandex_storage_object
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/utils"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-storage"
+)
+
+type objectResource struct {
+	providerConfig *provider_config.Config
+}
+
+func NewResource() resource.Resource {
+	return &objectResource{}
+}
+
+var (
+	_ resource.ResourceWithConfigValidators = &objectResource{}
+	_ resource.ResourceWithUpgradeState     = &objectResource{}
+)
+
+func (r *objectResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_object"
+}
+
+func (r *objectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = objectSchema()
+}
+
+func (r *objectResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("access_key"),
+			path.MatchRoot("secret_key"),
+		),
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("source"),
+			path.MatchRoot("content"),
+			path.MatchRoot("content_base64"),
+		),
+	}
+}
+
+func (r *objectResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeObjectStateV0},
+	}
+}
+
+func (r *objectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan objectModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	s3Client, err := yandex_storage.NewS3Client(ctx, r.providerConfig, plan.AccessKey, plan.SecretKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create Storage Object", err.Error())
+		return
+	}
+
+	if err := putObject(ctx, s3Client, &plan); err != nil {
+		resp.Diagnostics.AddError("Unable to create Storage Object", err.Error())
+		return
+	}
+
+	plan.ID = plan.Key
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+
+	if err := r.refresh(ctx, s3Client, &plan); err != nil {
+		resp.Diagnostics.AddError("Unable to read Storage Object", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *objectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state objectModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	s3Client, err := yandex_storage.NewS3Client(ctx, r.providerConfig, state.AccessKey, state.SecretKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read Storage Object", err.Error())
+		return
+	}
+
+	if err := r.refresh(ctx, s3Client, &state); err != nil {
+		if yandex_storage.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Storage Object (%s) not found, removing from state", state.Key.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Unable to read Storage Object", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *objectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state objectModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	s3Client, err := yandex_storage.NewS3Client(ctx, r.providerConfig, plan.AccessKey, plan.SecretKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to update Storage Object", err.Error())
+		return
+	}
+
+	if hasContentChanged(&plan, &state) {
+		// The object is uploaded again, along with all its properties.
+		err = putObject(ctx, s3Client, &plan)
+	} else {
+		err = updateObject(ctx, s3Client, &plan, &state)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to update Storage Object", err.Error())
+		return
+	}
+
+	if err := r.refresh(ctx, s3Client, &plan); err != nil {
+		resp.Diagnostics.AddError("Unable to read Storage Object", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *objectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if utils.PreventDestroy(ctx, r.providerConfig.PreventDestroy, "yandex_storage_object", req.State, &resp.Diagnostics) {
+		return
+	}
+
+	var state objectModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	s3Client, err := yandex_storage.NewS3Client(ctx, r.providerConfig, state.AccessKey, state.SecretKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to delete Storage Object", err.Error())
+		return
+	}
+
+	bucket := state.Bucket.ValueString()
+	// We are effectively ignoring any leading '/' in the key name as aws.Config.DisableRestProtocolURICleaning is false
+	key := strings.TrimPrefix(state.Key.ValueString(), "/")
+
+	tflog.Debug(ctx, fmt.Sprintf("Storage Delete Object: %s/%s", bucket, key))
+
+	versionOutput, err := s3Client.ListObjectVersionsWithContext(ctx, &s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(key),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete Storage Object",
+			fmt.Sprintf("error getting version id for deleting storage object %q in bucket %s: %s", key, bucket, err),
+		)
+		return
+	}
+
+	input := &s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	// The prefix matches the objects with longer keys too, so the version of the very object is looked for.
+	for _, version := range versionOutput.Versions {
+		if aws.StringValue(version.Key) == key && aws.BoolValue(version.IsLatest) {
+			input.VersionId = version.VersionId
+			break
+		}
+	}
+
+	if _, err := s3Client.DeleteObjectWithContext(ctx, input); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete Storage Object",
+			fmt.Sprintf("error deleting storage object %q in bucket %q: %s", key, bucket, err),
+		)
+	}
+}
+
+func (r *objectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(*provider_config.Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider_config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerConfig = providerConfig
+}
+
+// refresh reads the properties of the object kept by Object Storage.
+func (r *objectResource) refresh(ctx context.Context, s3Client *s3.S3, model *objectModel) error {
+	bucket := aws.String(model.Bucket.ValueString())
+	key := aws.String(model.Key.ValueString())
+
+	output, err := s3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: bucket,
+		Key:    key,
+	})
+	if err != nil {
+		return err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Reading storage object meta: %s", output))
+
+	model.ID = model.Key
+	model.ContentType = types.StringValue(aws.StringValue(output.ContentType))
+
+	if output.ObjectLockLegalHoldStatus != nil {
+		model.ObjectLockLegalHoldStatus = types.StringValue(aws.StringValue(output.ObjectLockLegalHoldStatus))
+	}
+
+	if output.ObjectLockMode != nil {
+		model.ObjectLockMode = types.StringValue(aws.StringValue(output.ObjectLockMode))
+
+		untilDate := aws.TimeValue(output.ObjectLockRetainUntilDate)
+		// Keep the date as it is written in the configuration if it is the same time.
+		priorDate, err := time.Parse(time.RFC3339, model.ObjectLockRetainUntilDate.ValueString())
+		if err != nil || !priorDate.Equal(untilDate) {
+			model.ObjectLockRetainUntilDate = types.StringValue(untilDate.Format(time.RFC3339))
+		}
+	} else {
+		model.ObjectLockMode = types.StringNull()
+		model.ObjectLockRetainUntilDate = types.StringNull()
+	}
+
+	tags, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.GetObjectTaggingOutput, error) {
+		return s3Client.GetObjectTaggingWithContext(ctx, &s3.GetObjectTaggingInput{
+			Bucket:    bucket,
+			Key:       key,
+			VersionId: output.VersionId,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("error getting S3 Storage Object Tagging: %w", err)
+	}
+
+	model.Tags = tagsFrom(yandex_storage.TagsToMap(tags.TagSet))
+	return nil
+}
+
+func hasContentChanged(plan, state *objectModel) bool {
+	return !plan.Source.Equal(state.Source) ||
+		!plan.SourceHash.Equal(state.SourceHash) ||
+		!plan.Content.Equal(state.Content) ||
+		!plan.ContentBase64.Equal(state.ContentBase64) ||
+		!plan.ContentType.Equal(state.ContentType)
+}
+
+func putObject(ctx context.Context, s3Client *s3.S3, plan *objectModel) error {
+	var body io.ReadSeeker
+
+	switch {
+	case !plan.Source.IsNull():
+		source := plan.Source.ValueString()
+		path, err := homedir.Expand(source)
+		if err != nil {
+			return fmt.Errorf("error expanding homedir in source (%s): %s", source, err)
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("error opening storage bucket object source (%s): %s", path, err)
+		}
+
+		body = file
+		defer func() {
+			if err := file.Close(); err != nil {
+				tflog.Warn(ctx, fmt.Sprintf("Error closing storage bucket object source (%s): %s", path, err))
+			}
+		}()
+	case !plan.Content.IsNull():
+		body = bytes.NewReader([]byte(plan.Content.ValueString()))
+	case !plan.ContentBase64.IsNull():
+		// We can't do streaming decoding here (with base64.NewDecoder) because
+		// the AWS SDK requires an io.ReadSeeker but a base64 decoder can't seek.
+		contentRaw, err := base64.StdEncoding.DecodeString(plan.ContentBase64.ValueString())
+		if err != nil {
+			return fmt.Errorf("error decoding content_base64: %s", err)
+		}
+		body = bytes.NewReader(contentRaw)
+	default:
+		return fmt.Errorf("\"source\", \"content\", or \"content_base64\" field must be specified")
+	}
+
+	bucket := aws.String(plan.Bucket.ValueString())
+	key := aws.String(plan.Key.ValueString())
+
+	tflog.Debug(ctx, fmt.Sprintf("Trying to create new storage object %q in bucket %q", *key, *bucket))
+
+	putObjectInput := &s3.PutObjectInput{
+		Bucket: bucket,
+		Key:    key,
+		ACL:    aws.String(plan.ACL.ValueString()),
+		Body:   body,
+	}
+
+	if v := plan.ContentType.ValueString(); v != "" {
+		putObjectInput.ContentType = aws.String(v)
+	}
+
+	if v := plan.ObjectLockLegalHoldStatus.ValueString(); v != "" {
+		putObjectInput.SetObjectLockLegalHoldStatus(v)
+	}
+
+	if v := plan.ObjectLockMode.ValueString(); v != "" {
+		// ignore error because the schema has validated the string already
+		untilDate, _ := time.Parse(time.RFC3339, plan.ObjectLockRetainUntilDate.ValueString())
+		putObjectInput.SetObjectLockMode(v)
+		putObjectInput.SetObjectLockRetainUntilDate(untilDate)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Sending putObjectInput %s", putObjectInput.String()))
+
+	if _, err := s3Client.PutObjectWithContext(ctx, putObjectInput); err != nil {
+		return fmt.Errorf("error putting object in bucket %q: %s", *bucket, err)
+	}
+
+	// Use separate request to set tags since it allows to caught
+	// NotImplemented error.
+	if tags := tagsAs(plan.Tags); len(tags) > 0 {
+		tflog.Debug(ctx, "Trying to set tags for object")
+
+		_, err := s3Client.PutObjectTaggingWithContext(ctx, &s3.PutObjectTaggingInput{
+			Bucket: bucket,
+			Key:    key,
+			Tagging: &s3.Tagging{
+				TagSet: yandex_storage.TagsFromMap(tags),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("error putting S3 Storage Object tags: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func updateObject(ctx context.Context, s3Client *s3.S3, plan, state *objectModel) error {
+	bucket := aws.String(plan.Bucket.ValueString())
+	key := aws.String(plan.Key.ValueString())
+
+	if !plan.ACL.Equal(state.ACL) {
+		_, err := s3Client.PutObjectAclWithContext(ctx, &s3.PutObjectAclInput{
+			Bucket: bucket,
+			Key:    key,
+			ACL:    aws.String(plan.ACL.ValueString()),
+		})
+		if err != nil {
+			return fmt.Errorf("error putting storage object ACL: %s", err)
+		}
+	}
+
+	if !plan.ObjectLockLegalHoldStatus.Equal(state.ObjectLockLegalHoldStatus) {
+		_, err := s3Client.PutObjectLegalHoldWithContext(ctx, &s3.PutObjectLegalHoldInput{
+			Bucket: bucket,
+			Key:    key,
+			LegalHold: &s3.ObjectLockLegalHold{
+				Status: aws.String(plan.ObjectLockLegalHoldStatus.ValueString()),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("error putting storage object LegalHoldStatus: %s", err)
+		}
+	}
+
+	if !plan.ObjectLockMode.Equal(state.ObjectLockMode) ||
+		!plan.ObjectLockRetainUntilDate.Equal(state.ObjectLockRetainUntilDate) {
+		retention := &s3.ObjectLockRetention{}
+		if v := plan.ObjectLockMode.ValueString(); v != "" {
+			untilDate, _ := time.Parse(time.RFC3339, plan.ObjectLockRetainUntilDate.ValueString())
+			retention.Mode = aws.String(v)
+			retention.RetainUntilDate = aws.Time(untilDate)
+		}
+
+		_, err := s3Client.PutObjectRetentionWithContext(ctx, &s3.PutObjectRetentionInput{
+			Bucket:                    bucket,
+			Key:                       key,
+			Retention:                 retention,
+			BypassGovernanceRetention: aws.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("error putting storage object Retention: %s", err)
+		}
+	}
+
+	if !plan.Tags.Equal(state.Tags) {
+		tags := tagsAs(plan.Tags)
+		if len(tags) == 0 {
+			tflog.Info(ctx, "Deleting Storage S3 object tags")
+
+			_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.DeleteObjectTaggingOutput, error) {
+				return s3Client.DeleteObjectTaggingWithContext(ctx, &s3.DeleteObjectTaggingInput{
+					Bucket: bucket,
+					Key:    key,
+				})
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting Storage S3 object tags: %s", err)
+			}
+			return nil
+		}
+
+		tagSet := yandex_storage.TagsFromMap(tags)
+		tflog.Info(ctx, fmt.Sprintf("Updating Storage S3 object tags with %v", tagSet))
+
+		_, err := yandex_storage.RetryFlakyS3Responses(ctx, func() (*s3.PutObjectTaggingOutput, error) {
+			return s3Client.PutObjectTaggingWithContext(ctx, &s3.PutObjectTaggingInput{
+				Bucket: bucket,
+				Key:    key,
+				Tagging: &s3.Tagging{
+					TagSet: tagSet,
+				},
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("error updating Storage S3 object tags: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func tagsAs(value types.Map) map[string]string {
+	tags := make(map[string]string, len(value.Elements()))
+	for k, v := range value.Elements() {
+		if s, ok := v.(types.String); ok {
+			tags[k] = s.ValueString()
+		}
+	}
+	return tags
+}
+
+func tagsFrom(tags map[string]string) types.Map {
+	if len(tags) == 0 {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(tags))
+	for k, v := range tags {
+		elements[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
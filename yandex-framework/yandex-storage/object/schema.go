@@ -0,0 +1,141 @@
+package yandex_storage_object
+
+import (
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-storage"
+)
+
+type objectModel struct {
+	ID                        types.String `tfsdk:"id"`
+	Bucket                    types.String `tfsdk:"bucket"`
+	AccessKey                 types.String `tfsdk:"access_key"`
+	SecretKey                 types.String `tfsdk:"secret_key"`
+	ACL                       types.String `tfsdk:"acl"`
+	Key                       types.String `tfsdk:"key"`
+	Source                    types.String `tfsdk:"source"`
+	SourceHash                types.String `tfsdk:"source_hash"`
+	Content                   types.String `tfsdk:"content"`
+	ContentBase64             types.String `tfsdk:"content_base64"`
+	ContentType               types.String `tfsdk:"content_type"`
+	ObjectLockLegalHoldStatus types.String `tfsdk:"object_lock_legal_hold_status"`
+	ObjectLockMode            types.String `tfsdk:"object_lock_mode"`
+	ObjectLockRetainUntilDate types.String `tfsdk:"object_lock_retain_until_date"`
+	Tags                      types.Map    `tfsdk:"tags"`
+}
+
+func objectSchema() schema.Schema {
+	return schema.Schema{
+		Version:     1,
+		Description: "Allows management of a Yandex Cloud Object Storage object.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The `key` of the object.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket": schema.StringAttribute{
+				Description: "The name of the containing bucket.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_key": schema.StringAttribute{
+				Description: "The access key to use when applying changes. If omitted, `storage_access_key` specified in provider config is used.",
+				Optional:    true,
+			},
+			"secret_key": schema.StringAttribute{
+				Description: "The secret key to use when applying changes. If omitted, `storage_secret_key` specified in provider config is used.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"acl": schema.StringAttribute{
+				Description: "The predefined ACL to apply. Defaults to `private`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(s3.ObjectCannedACLPrivate),
+			},
+			"key": schema.StringAttribute{
+				Description: "The name of the object once it is in the bucket.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				Description: "The path to a file that will be read and uploaded as raw bytes for the object content.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("content"), path.MatchRoot("content_base64")),
+				},
+			},
+			"source_hash": schema.StringAttribute{
+				Description: "Used to trigger object update when the source content changes. The value is only stored in state and not saved by Object Storage.",
+				Optional:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "Literal string value to use as the object content, which will be uploaded as UTF-8-encoded text.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("source"), path.MatchRoot("content_base64")),
+				},
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "Base64-encoded data that will be decoded and uploaded as raw bytes for the object content.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("source"), path.MatchRoot("content")),
+				},
+			},
+			"content_type": schema.StringAttribute{
+				Description: "A standard MIME type describing the format of the object data.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"object_lock_legal_hold_status": schema.StringAttribute{
+				Description: "Specifies a legal hold status of the object. Requires `object_lock_configuration` to be enabled on the bucket.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(s3.ObjectLockLegalHoldStatusOff),
+				Validators: []validator.String{
+					stringvalidator.OneOf(s3.ObjectLockLegalHoldStatus_Values()...),
+				},
+			},
+			"object_lock_mode": schema.StringAttribute{
+				Description: "Specifies a type of object lock. It must be set simultaneously with `object_lock_retain_until_date`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(s3.ObjectLockRetentionMode_Values()...),
+					stringvalidator.AlsoRequires(path.MatchRoot("object_lock_retain_until_date")),
+				},
+			},
+			"object_lock_retain_until_date": schema.StringAttribute{
+				Description: "Specifies date and time in RFC3339 format until which the object is to be locked. It must be set simultaneously with `object_lock_mode`.",
+				Optional:    true,
+				Validators: []validator.String{
+					yandex_storage.RFC3339Time(),
+					stringvalidator.AlsoRequires(path.MatchRoot("object_lock_mode")),
+				},
+			},
+			"tags": schema.MapAttribute{
+				Description: "Specifies the object tags.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
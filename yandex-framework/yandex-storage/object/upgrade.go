@@ -0,0 +1,80 @@
+package yandex_storage_object
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// objectStateV0 is the state of the object written by the SDKv2 implementation of the resource,
+// where the optional attributes that are not set are empty strings.
+type objectStateV0 struct {
+	ID                        string            `json:"id"`
+	Bucket                    string            `json:"bucket"`
+	AccessKey                 string            `json:"access_key"`
+	SecretKey                 string            `json:"secret_key"`
+	ACL                       string            `json:"acl"`
+	Key                       string            `json:"key"`
+	Source                    string            `json:"source"`
+	SourceHash                string            `json:"source_hash"`
+	Content                   string            `json:"content"`
+	ContentBase64             string            `json:"content_base64"`
+	ContentType               string            `json:"content_type"`
+	ObjectLockLegalHoldStatus string            `json:"object_lock_legal_hold_status"`
+	ObjectLockMode            string            `json:"object_lock_mode"`
+	ObjectLockRetainUntilDate string            `json:"object_lock_retain_until_date"`
+	Tags                      map[string]string `json:"tags"`
+}
+
+func upgradeObjectStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The prior state of the object is empty.")
+		return
+	}
+
+	var prior objectStateV0
+	if err := json.Unmarshal(req.RawState.JSON, &prior); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			"There was an error reading the prior state of the object:\n\n"+err.Error(),
+		)
+		return
+	}
+
+	state := objectModel{
+		ID:                        types.StringValue(prior.ID),
+		Bucket:                    types.StringValue(prior.Bucket),
+		AccessKey:                 stringV0(prior.AccessKey),
+		SecretKey:                 stringV0(prior.SecretKey),
+		ACL:                       types.StringValue(prior.ACL),
+		Key:                       types.StringValue(prior.Key),
+		Source:                    stringV0(prior.Source),
+		SourceHash:                stringV0(prior.SourceHash),
+		Content:                   stringV0(prior.Content),
+		ContentBase64:             stringV0(prior.ContentBase64),
+		ContentType:               types.StringValue(prior.ContentType),
+		ObjectLockLegalHoldStatus: types.StringValue(prior.ObjectLockLegalHoldStatus),
+		ObjectLockMode:            stringV0(prior.ObjectLockMode),
+		ObjectLockRetainUntilDate: stringV0(prior.ObjectLockRetainUntilDate),
+		Tags:                      tagsFrom(prior.Tags),
+	}
+	if prior.ACL == "" {
+		state.ACL = types.StringValue(s3.ObjectCannedACLPrivate)
+	}
+	if prior.ObjectLockLegalHoldStatus == "" {
+		state.ObjectLockLegalHoldStatus = types.StringValue(s3.ObjectLockLegalHoldStatusOff)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// stringV0 returns the value of the prior string, null if it is empty.
+func stringV0(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
@@ -0,0 +1,103 @@
+package yandex_storage_object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func upgradeObjectState(t *testing.T, priorJSON string) (objectModel, *resource.UpgradeStateResponse) {
+	ctx := context.Background()
+	s := objectSchema()
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(ctx), nil),
+	}}
+	req := resource.UpgradeStateRequest{}
+	if priorJSON != "" {
+		req.RawState = &tfprotov6.RawState{JSON: []byte(priorJSON)}
+	}
+	upgradeObjectStateV0(ctx, req, resp)
+
+	var model objectModel
+	if !resp.Diagnostics.HasError() {
+		require.False(t, resp.State.Get(ctx, &model).HasError())
+	}
+	return model, resp
+}
+
+func TestUpgradeObjectStateV0(t *testing.T) {
+	// The state written by the SDKv2 implementation of the resource, with the empty strings
+	// it writes for the unset attributes.
+	state, resp := upgradeObjectState(t, `{
+		"id": "index.html",
+		"bucket": "tf-bucket",
+		"access_key": "",
+		"secret_key": "",
+		"acl": "public-read",
+		"key": "index.html",
+		"source": "",
+		"source_hash": "",
+		"content": "<html></html>",
+		"content_base64": "",
+		"content_type": "text/html",
+		"object_lock_legal_hold_status": "ON",
+		"object_lock_mode": "GOVERNANCE",
+		"object_lock_retain_until_date": "2030-01-01T00:00:00Z",
+		"tags": {"env": "test"}
+	}`)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	assert.Equal(t, "index.html", state.ID.ValueString())
+	assert.Equal(t, "tf-bucket", state.Bucket.ValueString())
+	assert.True(t, state.AccessKey.IsNull(), "empty access key must be null")
+	assert.True(t, state.SecretKey.IsNull())
+	assert.Equal(t, "public-read", state.ACL.ValueString())
+	assert.Equal(t, "index.html", state.Key.ValueString())
+	assert.True(t, state.Source.IsNull())
+	assert.True(t, state.SourceHash.IsNull())
+	assert.Equal(t, "<html></html>", state.Content.ValueString())
+	assert.True(t, state.ContentBase64.IsNull())
+	assert.Equal(t, "text/html", state.ContentType.ValueString())
+	assert.Equal(t, "ON", state.ObjectLockLegalHoldStatus.ValueString())
+	assert.Equal(t, "GOVERNANCE", state.ObjectLockMode.ValueString())
+	assert.Equal(t, "2030-01-01T00:00:00Z", state.ObjectLockRetainUntilDate.ValueString())
+
+	tags := map[string]string{}
+	require.False(t, state.Tags.ElementsAs(context.Background(), &tags, false).HasError())
+	assert.Equal(t, map[string]string{"env": "test"}, tags)
+}
+
+func TestUpgradeObjectStateV0_defaults(t *testing.T) {
+	// The state written by the old versions of the resource misses the attributes added later.
+	state, resp := upgradeObjectState(t, `{
+		"id": "data.bin",
+		"bucket": "tf-bucket",
+		"key": "data.bin",
+		"source": "data.bin",
+		"content_type": "application/octet-stream"
+	}`)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	assert.Equal(t, "private", state.ACL.ValueString(), "missing ACL must be private")
+	assert.Equal(t, "OFF", state.ObjectLockLegalHoldStatus.ValueString(), "missing legal hold must be off")
+	assert.Equal(t, "data.bin", state.Source.ValueString())
+	assert.True(t, state.Content.IsNull())
+	assert.True(t, state.ObjectLockMode.IsNull())
+	assert.True(t, state.ObjectLockRetainUntilDate.IsNull())
+	assert.True(t, state.Tags.IsNull(), "missing tags must be null")
+}
+
+func TestUpgradeObjectStateV0_empty(t *testing.T) {
+	_, resp := upgradeObjectState(t, "")
+	assert.True(t, resp.Diagnostics.HasError(), "empty prior state must be reported")
+
+	_, resp = upgradeObjectState(t, `{"tags": ["env"]}`)
+	assert.True(t, resp.Diagnostics.HasError(), "invalid prior state must be reported")
+}
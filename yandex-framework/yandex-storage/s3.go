@@ -0,0 +1,163 @@
+package yandex_storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common/client"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
+)
+
+// NewS3Client returns the Object Storage client of the resource access keys, or of the provider
+// storage access keys if the resource has none.
+func NewS3Client(ctx context.Context, config *provider_config.Config, accessKey, secretKey types.String) (*s3.S3, error) {
+	return client.NewS3Client(
+		ctx,
+		config.ProviderState.StorageEndpoint.ValueString(),
+		accessKey.ValueString(),
+		secretKey.ValueString(),
+		config.DefaultS3Session,
+	)
+}
+
+// IsAWSErr returns true if the error matches all these conditions:
+//   - err is of type awserr.Error
+//   - Error.Code() matches code
+//   - Error.Message() contains message
+func IsAWSErr(err error, code string, message string) bool {
+	if err, ok := err.(awserr.Error); ok {
+		return err.Code() == code && strings.Contains(err.Message(), message)
+	}
+	return false
+}
+
+// IsNotFound returns true if Object Storage responded with 404 Not Found.
+func IsNotFound(err error) bool {
+	awsErr, ok := err.(awserr.RequestFailure)
+	return ok && awsErr.StatusCode() == 404
+}
+
+// RetryOnAWSCodes calls f until it succeeds or fails with an error code other than the codes.
+func RetryOnAWSCodes[T any](ctx context.Context, codes []string, f func() (T, error)) (T, error) {
+	var resp T
+	err := retry.RetryContext(ctx, time.Minute, func() *retry.RetryError {
+		var err error
+		resp, err = f()
+		if err == nil {
+			return nil
+		}
+		if awsErr, ok := err.(awserr.Error); ok {
+			for _, code := range codes {
+				if awsErr.Code() == code {
+					return retry.RetryableError(err)
+				}
+			}
+		}
+		return retry.NonRetryableError(err)
+	})
+	return resp, err
+}
+
+// RetryFlakyS3Responses retries the errors Object Storage responds with while the changes
+// of a bucket, e.g. its creation or access bindings, are propagating.
+func RetryFlakyS3Responses[T any](ctx context.Context, f func() (T, error)) (T, error) {
+	return RetryOnAWSCodes(ctx, []string{"NoSuchBucket", "AccessDenied", "Forbidden"}, f)
+}
+
+// WaitConsistent waits until the check keeps returning true for several consecutive calls, so that
+// a change is seen by every Object Storage replica the following requests may get to.
+func WaitConsistent(ctx context.Context, check func() (bool, error)) error {
+	const (
+		pending = "pending"
+		done    = "done"
+	)
+
+	conf := &retry.StateChangeConf{
+		Pending: []string{pending},
+		Target:  []string{done},
+		Refresh: func() (interface{}, string, error) {
+			ok, err := check()
+			if err != nil {
+				return nil, "", err
+			}
+			if !ok {
+				return false, pending, nil
+			}
+			return true, done, nil
+		},
+		Timeout:                   2 * time.Minute,
+		PollInterval:              time.Second,
+		ContinuousTargetOccurence: 10,
+	}
+
+	_, err := conf.WaitForStateContext(ctx)
+	return err
+}
+
+// ValidateBucketName checks the bucket name has the length and the characters Object Storage allows.
+func ValidateBucketName(value string) error {
+	if len(value) > 63 {
+		return fmt.Errorf("%q must contain 63 characters at most", value)
+	}
+	if len(value) < 3 {
+		return fmt.Errorf("%q must contain at least 3 characters", value)
+	}
+	if !regexp.MustCompile(`^[0-9a-zA-Z-.]+$`).MatchString(value) {
+		return fmt.Errorf("only alphanumeric characters, hyphens, and periods allowed in %q", value)
+	}
+
+	return nil
+}
+
+// BucketDomainName returns the domain name of the bucket on the Object Storage endpoint.
+func BucketDomainName(bucket string, endpointURL string) (string, error) {
+	// Without a scheme the url will not be parsed as we expect
+	// See https://github.com/golang/go/issues/19779
+	if !strings.Contains(endpointURL, "//") {
+		endpointURL = "//" + endpointURL
+	}
+
+	parse, err := url.Parse(endpointURL)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s.%s", bucket, parse.Hostname()), nil
+}
+
+// TagsToMap returns the tags as a map, nil if there are no tags.
+func TagsToMap(tags []*s3.Tag) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		out[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return out
+}
+
+// TagsFromMap returns the tags of the map.
+func TagsFromMap(tags map[string]string) []*s3.Tag {
+	out := make([]*s3.Tag, 0, len(tags))
+	for k, v := range tags {
+		out = append(out, &s3.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+
+	return out
+}
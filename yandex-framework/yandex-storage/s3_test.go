@@ -0,0 +1,77 @@
+package yandex_storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBucketName(t *testing.T) {
+	validNames := []string{
+		"foobar",
+		"127.0.0.1",
+		"foo..bar",
+		"foo.bar.baz",
+		"Foo.Bar",
+		strings.Repeat("x", 63),
+	}
+
+	for _, v := range validNames {
+		if err := ValidateBucketName(v); err != nil {
+			t.Fatalf("%q should be a valid storage bucket name", v)
+		}
+	}
+
+	invalidNames := []string{
+		"foo_bar",
+		"foo_bar_baz",
+		"foo;bar",
+		strings.Repeat("x", 64),
+	}
+
+	for _, v := range invalidNames {
+		if err := ValidateBucketName(v); err == nil {
+			t.Fatalf("%q should not be a valid storage bucket name", v)
+		}
+	}
+}
+
+func TestBucketDomainName(t *testing.T) {
+	for _, endpoint := range []string{
+		"storage.yandexcloud.net",
+		"https://storage.yandexcloud.net",
+		"https://storage.yandexcloud.net:443/",
+	} {
+		name, err := BucketDomainName("my-bucket", endpoint)
+		require.NoError(t, err)
+		assert.Equal(t, "my-bucket.storage.yandexcloud.net", name, endpoint)
+	}
+}
+
+func TestTagsMapRoundTrip(t *testing.T) {
+	assert.Nil(t, TagsToMap(nil))
+
+	tags := map[string]string{"some": "value", "other": ""}
+	assert.Equal(t, tags, TagsToMap(TagsFromMap(tags)))
+	assert.ElementsMatch(t, []*s3.Tag{
+		{Key: aws.String("some"), Value: aws.String("value")},
+		{Key: aws.String("other"), Value: aws.String("")},
+	}, TagsFromMap(tags))
+}
+
+func TestNormalizeJSON(t *testing.T) {
+	normalized, err := NormalizeJSON(`{ "b": [1, 2],  "a": "x" }`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":"x","b":[1,2]}`, normalized)
+
+	normalized, err = NormalizeJSON("")
+	require.NoError(t, err)
+	assert.Empty(t, normalized)
+
+	_, err = NormalizeJSON(`{"a":`)
+	assert.Error(t, err)
+}
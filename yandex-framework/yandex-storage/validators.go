@@ -0,0 +1,143 @@
+package yandex_storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// LifecycleDateLayout is the layout of the dates in the bucket lifecycle rules.
+const LifecycleDateLayout = "2006-01-02"
+
+// NormalizeJSON returns the compact JSON with sorted object keys, the empty string for the empty string.
+func NormalizeJSON(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	var j interface{}
+	if err := json.Unmarshal([]byte(value), &j); err != nil {
+		return "", err
+	}
+
+	normalized, err := json.Marshal(j)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+type jsonValidator struct{}
+
+// JSON returns a validator that checks the string is a valid JSON document.
+func JSON() validator.String {
+	return jsonValidator{}
+}
+
+func (v jsonValidator) Description(_ context.Context) string {
+	return "value must be a valid JSON"
+}
+
+func (v jsonValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := NormalizeJSON(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON",
+			fmt.Sprintf("%q contains an invalid JSON: %s", req.Path, err),
+		)
+	}
+}
+
+type lifecycleDateValidator struct{}
+
+// LifecycleDate returns a validator that checks the string is a date of the YYYY-MM-DD format.
+func LifecycleDate() validator.String {
+	return lifecycleDateValidator{}
+}
+
+func (v lifecycleDateValidator) Description(_ context.Context) string {
+	return "value must be a date in the YYYY-MM-DD format"
+}
+
+func (v lifecycleDateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v lifecycleDateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(LifecycleDateLayout, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Date",
+			fmt.Sprintf("%q cannot be parsed as RFC3339 Timestamp Format", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+type bucketNameValidator struct{}
+
+// BucketName returns a validator that checks the string is a valid bucket name.
+func BucketName() validator.String {
+	return bucketNameValidator{}
+}
+
+func (v bucketNameValidator) Description(_ context.Context) string {
+	return "value must be a valid bucket name"
+}
+
+func (v bucketNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v bucketNameValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := ValidateBucketName(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Bucket Name", err.Error())
+	}
+}
+
+type rfc3339TimeValidator struct{}
+
+// RFC3339Time returns a validator that checks the string is a time of the RFC3339 format.
+func RFC3339Time() validator.String {
+	return rfc3339TimeValidator{}
+}
+
+func (v rfc3339TimeValidator) Description(_ context.Context) string {
+	return "value must be a time in the RFC3339 format"
+}
+
+func (v rfc3339TimeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339TimeValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time",
+			fmt.Sprintf("%q cannot be parsed as RFC3339 Timestamp Format", req.ConfigValue.ValueString()),
+		)
+	}
+}
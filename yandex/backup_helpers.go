@@ -369,7 +369,8 @@ func expandYandexBackupPolicyRetention(v any) (rts *backuppb.PolicySettings_Rete
 
 	value := vscheme.List()[0].(map[string]any)
 	rts = new(backuppb.PolicySettings_Retention)
-	rts.AfterBackup = value["after_backup"].(bool)
+	// The API applies the retention either before or after a backup.
+	rts.BeforeBackup = !value["after_backup"].(bool)
 	rules := value["rules"].(*schema.Set).List()
 	for _, rule := range rules {
 		rulepb := expandYandexBackupPolicyRetentionRule(rule)
@@ -499,7 +500,7 @@ func flattenBackupPolicySettingsRetention(d *schema.ResourceData, retention *bac
 	}
 
 	result := make(map[string]any, 2)
-	result["after_backup"] = retention.AfterBackup
+	result["after_backup"] = !retention.BeforeBackup
 
 	resultRules := make([]any, 0, len(retention.Rules))
 	for _, rule := range retention.Rules {
@@ -520,7 +521,7 @@ func flattenBackupPolicySettingsRetention(d *schema.ResourceData, retention *bac
 		resultRules = append(resultRules, resultRule)
 	}
 
-	result["rules"] = schema.NewSet(storageBucketS3SetFunc("max_age", "max_count", "repeat_period"), resultRules)
+	result["rules"] = schema.NewSet(keysSetFunc("max_age", "max_count", "repeat_period"), resultRules)
 
 	return d.Set("retention", []any{result})
 }
@@ -555,7 +556,7 @@ func flattenYandexBackupPolicyScheduling(d *schema.ResourceData, scheduling *bac
 			repeatAt = append(repeatAt, flattenBackupPolicySettingsTimeOfDay(repeatAtValue))
 		}
 
-		schemaSetFunc := storageBucketS3SetFunc("weekdays", "repeat_at", "repeat_every", "monthdays", "include_last_day_of_month", "months", "type")
+		schemaSetFunc := keysSetFunc("weekdays", "repeat_at", "repeat_every", "monthdays", "include_last_day_of_month", "months", "type")
 		item := schema.NewSet(schemaSetFunc, []any{
 			map[string]any{
 				"repeat_at":                 repeatAt,
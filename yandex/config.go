@@ -2,37 +2,17 @@ package yandex
 
 import (
 	"context"
-	"crypto/tls"
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"log"
-	"math"
-	"math/rand"
-	"net"
-	"os"
-	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/google/uuid"
-	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	"github.com/mitchellh/go-homedir"
 	ycsdk "github.com/yandex-cloud/go-sdk"
-	"github.com/yandex-cloud/go-sdk/iamkey"
-	"github.com/yandex-cloud/go-sdk/pkg/requestid"
-	"github.com/yandex-cloud/go-sdk/pkg/retry"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 
-	"github.com/yandex-cloud/terraform-provider-yandex/pkg/logging"
-)
-
-const (
-	defaultExponentialBackoffBase = 50 * time.Millisecond
-	defaultExponentialBackoffCap  = 1 * time.Minute
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/common/client"
+	"github.com/yandex-cloud/terraform-provider-yandex/common/mutexkv"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ratelimit"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/workloadidentity"
 )
 
 type Config struct {
@@ -63,14 +43,41 @@ type Config struct {
 	SharedCredentialsFile string
 	Profile               string
 
+	// DefaultLabels are merged into the labels of every resource that has them.
+	// Labels set on the resource itself take precedence.
+	DefaultLabels map[string]string
+
+	// PreventDestroy rejects the Delete of resources of the listed types or with the listed labels.
+	PreventDestroy common.PreventDestroyRules
+
+	// IAMCompareBeforeSet makes the authoritative IAM resources fail instead of overwriting access bindings
+	// changed since they were read to plan the change, unless the resource overrides it.
+	IAMCompareBeforeSet bool
+
+	// RetryPolicy replaces the default retries of calls failed with codes.Unavailable if set.
+	RetryPolicy *retrypolicy.Policy
+
+	// RateLimits limit the rate and concurrency of API requests per service.
+	RateLimits []ratelimit.Rule
+
+	// WorkloadIdentity exchanges an external OIDC token for an IAM token of a service account.
+	WorkloadIdentity *workloadidentity.Config
+
+	// ImpersonateServiceAccountID is the service account whose IAM tokens, minted with
+	// the configured credentials, authenticate the API calls.
+	ImpersonateServiceAccountID string
+
+	// LockBackend holds the locks of IAM and cloud-wide changes outside of the provider process if set.
+	LockBackend *mutexkv.Config
+
 	// contextWithClientTraceID is a context that has client-trace-id in its metadata
 	// It is initialized from stopContext at the same time as ycsdk.SDK
 	contextWithClientTraceID context.Context
 
-	userAgent         string
-	sdk               *ycsdk.SDK
-	sharedCredentials *SharedCredentials
-	defaultS3Session  *session.Session
+	userAgent        string
+	sdk              *ycsdk.SDK
+	ycClient         *client.Client
+	defaultS3Session *session.Session
 }
 
 // this function return context with added client trace id
@@ -85,213 +92,74 @@ func (c *Config) ContextWithTimeout(timeout time.Duration) (context.Context, con
 
 // Client configures and returns a fully initialized Yandex.Cloud sdk
 func (c *Config) initAndValidate(stopContext context.Context, terraformVersion string, sweeper bool) error {
-	c.contextWithClientTraceID = requestid.ContextWithClientTraceID(stopContext, uuid.New().String())
-
-	credentials, err := c.credentials()
-	if err != nil {
-		return err
-	}
-
-	yandexSDKConfig := &ycsdk.Config{
-		Credentials: credentials,
-		Endpoint:    c.Endpoint,
-		Plaintext:   c.Plaintext,
-		TLSConfig: &tls.Config{
-			InsecureSkipVerify: c.Insecure,
-		},
-	}
-
-	providerNameAndVersion := getProviderNameAndVersion()
-	terraformURL := "https://www.terraform.io"
-
-	if sweeper {
-		c.userAgent = "Terraform Sweeper"
-	} else {
-		c.userAgent = fmt.Sprintf("Terraform/%s (%s) %s", terraformVersion, terraformURL, providerNameAndVersion)
-	}
-
-	headerMD := metadata.Pairs("user-agent", c.userAgent)
-
-	requestIDInterceptor := requestid.Interceptor()
-
-	retryInterceptor := retry.Interceptor(
-		retry.WithMax(c.MaxRetries),
-		retry.WithCodes(codes.Unavailable),
-		retry.WithAttemptHeader(true),
-		retry.WithBackoff(backoffExponentialWithJitter(defaultExponentialBackoffBase, defaultExponentialBackoffCap)))
-
-	var interceptors = []grpc.UnaryClientInterceptor{
-		retryInterceptor,
-		requestIDInterceptor,
-	}
-
-	// Support deep API logging in case user has requested it.
-	if os.Getenv("TF_ENABLE_API_LOGGING") != "" {
-		log.Print("[INFO] API logging has been requested, turning on")
-		interceptors = append(interceptors, logging.NewAPILoggingUnaryInterceptor())
-	}
-
-	// Make sure retry interceptor is above id interceptor.
-	// Now we will have new request id for every retry attempt.
-	interceptorChain := grpc_middleware.ChainUnaryClient(interceptors...)
-
-	c.sdk, err = ycsdk.Build(c.contextWithClientTraceID, *yandexSDKConfig,
-		grpc.WithUserAgent(c.userAgent),
-		grpc.WithDefaultCallOptions(grpc.Header(&headerMD)),
-		grpc.WithUnaryInterceptor(interceptorChain))
-	if err != nil {
-		return err
-	}
-
-	err = c.initSharedCredentials()
+	ycClient, err := client.Shared(stopContext, c.settings(), terraformVersion, sweeper)
 	if err != nil {
 		return err
 	}
 
-	return c.initializeDefaultS3Client()
-}
-
-func (c *Config) initSharedCredentials() error {
-	if c.SharedCredentialsFile == "" {
-		return nil
-	}
-
-	sharedCredentialsProvider := SharedCredentialsProvider{c.SharedCredentialsFile, c.Profile}
-	sharedCredentials, err := sharedCredentialsProvider.Retrieve()
-	if err != nil {
-		return err
-	}
-	c.sharedCredentials = sharedCredentials
+	c.setSettings(ycClient.Settings)
+	c.contextWithClientTraceID = ycClient.ContextWithClientTraceID(stopContext)
+	c.userAgent = ycClient.UserAgent
+	c.sdk = ycClient.SDK
+	c.ycClient = ycClient
+	c.defaultS3Session = ycClient.S3Session
+	ycClient.ConfigureLocks(mutexKV)
 	return nil
 }
 
-func (c *Config) resolveStorageAccessKeys() (string, string) {
-	if c.sharedCredentials == nil || (c.StorageAccessKey != "" && c.StorageSecretKey != "") {
-		return c.StorageAccessKey, c.StorageSecretKey
+func (c *Config) settings() client.Settings {
+	return client.Settings{
+		Endpoint:                       c.Endpoint,
+		FolderID:                       c.FolderID,
+		CloudID:                        c.CloudID,
+		OrganizationID:                 c.OrganizationID,
+		Zone:                           c.Zone,
+		Region:                         c.Region,
+		Token:                          c.Token,
+		ServiceAccountKeyFileOrContent: c.ServiceAccountKeyFileOrContent,
+		Plaintext:                      c.Plaintext,
+		Insecure:                       c.Insecure,
+		MaxRetries:                     c.MaxRetries,
+		StorageEndpoint:                c.StorageEndpoint,
+		YMQEndpoint:                    c.YMQEndpoint,
+		StorageAccessKey:               c.StorageAccessKey,
+		StorageSecretKey:               c.StorageSecretKey,
+		YMQAccessKey:                   c.YMQAccessKey,
+		YMQSecretKey:                   c.YMQSecretKey,
+		SharedCredentialsFile:          c.SharedCredentialsFile,
+		Profile:                        c.Profile,
+		RetryPolicy:                    c.RetryPolicy,
+		RateLimits:                     c.RateLimits,
+		WorkloadIdentity:               c.WorkloadIdentity,
+		LockBackend:                    c.LockBackend,
+		ImpersonateServiceAccountID:    c.ImpersonateServiceAccountID,
 	}
-
-	return c.sharedCredentials.StorageAccessKey, c.sharedCredentials.StorageSecretKey
 }
 
-func (c *Config) initializeDefaultS3Client() (err error) {
-	accessKey, secretKey := c.resolveStorageAccessKeys()
-	if c.StorageEndpoint == "" || (accessKey == "" && secretKey == "") {
-		return nil
-	}
-
-	if accessKey == "" || secretKey == "" {
-		return fmt.Errorf("both storage access key and storage secret key should be specified or not specified")
-	}
-
-	c.defaultS3Session, err = newS3Session(c.StorageEndpoint, accessKey, secretKey)
-
-	return err
-}
-
-func (c *Config) credentials() (ycsdk.Credentials, error) {
-	if c.ServiceAccountKeyFileOrContent != "" {
-		contents, _, err := pathOrContents(c.ServiceAccountKeyFileOrContent)
-		if err != nil {
-			return nil, fmt.Errorf("Error loading credentials: %s", err)
-		}
-
-		key, err := iamKeyFromJSONContent(contents)
-		if err != nil {
-			return nil, err
-		}
-		return ycsdk.ServiceAccountKey(key)
-	}
-
-	if c.Token != "" {
-		if strings.HasPrefix(c.Token, "t1.") && strings.Count(c.Token, ".") == 2 {
-			return ycsdk.NewIAMTokenCredentials(c.Token), nil
-		}
-		return ycsdk.OAuthToken(c.Token), nil
-	}
-
-	if sa := ycsdk.InstanceServiceAccount(); checkServiceAccountAvailable(c.Context(), sa) {
-		return sa, nil
-	}
-
-	return nil, fmt.Errorf("one of 'token' or 'service_account_key_file' should be specified; if you are inside compute instance, you can attach service account to it in order to authenticate via instance service account")
-}
-
-func iamKeyFromJSONContent(content string) (*iamkey.Key, error) {
-	key := &iamkey.Key{}
-	err := json.Unmarshal([]byte(content), key)
-	if err != nil {
-		return nil, fmt.Errorf("key unmarshal fail: %s", err)
-	}
-	return key, nil
-}
-
-func backoffExponentialWithJitter(base time.Duration, cap time.Duration) retry.BackoffFunc {
-	return func(attempt int) time.Duration {
-		// First call of BackoffFunc would be with attempt arq equal 0
-		log.Printf("[DEBUG] API call retry attempt %d", attempt+1)
-
-		to := getExponentialTimeout(attempt, base)
-		// Using float types here, because exponential time can be really big, and converting it to time.Duration may
-		// result in undefined behaviour. Its safe conversion, when we have compared it to our 'cap' value.
-		if to > float64(cap) {
-			to = float64(cap)
-		}
-
-		return time.Duration(to * rand.Float64())
-	}
-}
-
-func getExponentialTimeout(attempt int, base time.Duration) float64 {
-	mult := math.Pow(2, float64(attempt))
-	return float64(base) * mult
-}
-
-func getProviderNameAndVersion() string {
-	// version is part of binary name
-	// https://www.terraform.io/docs/configuration/providers.html#plugin-names-and-versions
-	fullBinaryPath := os.Args[0]
-	binaryName := filepath.Base(fullBinaryPath)
-	parts := strings.Split(binaryName, "_")
-
-	if len(parts) < 2 {
-		return "unknown/unknown"
-	}
-
-	parts[1] = strings.TrimPrefix(parts[1], "v")
-
-	return strings.Join(parts[:2], "/")
-}
-
-func checkServiceAccountAvailable(ctx context.Context, sa ycsdk.NonExchangeableCredentials) bool {
-	dialer := net.Dialer{Timeout: 50 * time.Millisecond}
-	conn, err := dialer.Dial("tcp", net.JoinHostPort(ycsdk.InstanceMetadataAddr, "80"))
-	if err != nil {
-		return false
-	}
-	_ = conn.Close()
-	_, err = sa.IAMToken(ctx)
-	return err == nil
-}
-
-// copy of github.com/hashicorp/terraform-plugin-sdk/helper/pathorcontents.Read()
-func pathOrContents(poc string) (string, bool, error) {
-	if len(poc) == 0 {
-		return poc, false, nil
-	}
-
-	path := poc
-	if path[0] == '~' {
-		var err error
-		path, err = homedir.Expand(path)
-		if err != nil {
-			return path, true, err
-		}
-	}
-
-	if _, err := os.Stat(path); err == nil {
-		contents, err := ioutil.ReadFile(path)
-		return string(contents), true, err
-	}
-
-	return poc, false, nil
+// setSettings sets the settings resolved by the client, e.g. from the shared credentials profile.
+func (c *Config) setSettings(s client.Settings) {
+	c.Endpoint = s.Endpoint
+	c.FolderID = s.FolderID
+	c.CloudID = s.CloudID
+	c.OrganizationID = s.OrganizationID
+	c.Zone = s.Zone
+	c.Region = s.Region
+	c.Token = s.Token
+	c.ServiceAccountKeyFileOrContent = s.ServiceAccountKeyFileOrContent
+	c.Plaintext = s.Plaintext
+	c.Insecure = s.Insecure
+	c.MaxRetries = s.MaxRetries
+	c.StorageEndpoint = s.StorageEndpoint
+	c.YMQEndpoint = s.YMQEndpoint
+	c.StorageAccessKey = s.StorageAccessKey
+	c.StorageSecretKey = s.StorageSecretKey
+	c.YMQAccessKey = s.YMQAccessKey
+	c.YMQSecretKey = s.YMQSecretKey
+	c.SharedCredentialsFile = s.SharedCredentialsFile
+	c.Profile = s.Profile
+	c.RetryPolicy = s.RetryPolicy
+	c.RateLimits = s.RateLimits
+	c.WorkloadIdentity = s.WorkloadIdentity
+	c.LockBackend = s.LockBackend
+	c.ImpersonateServiceAccountID = s.ImpersonateServiceAccountID
 }
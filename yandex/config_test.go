@@ -1,10 +1,11 @@
 package yandex
 
 import (
-	"io/ioutil"
 	"net"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
@@ -12,8 +13,12 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/endpoint"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/resourcemanager/v1"
 
 	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/fakecloud"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/provider-config"
 )
 
 const testConfigToken = "some_special_secured_token"
@@ -25,6 +30,9 @@ const testTerraformVersion = "test-terraform"
 
 const fakeSAKeyFile = "test-fixtures/fake_service_account_key.json"
 
+const fakeCloudID = "fake-cloud-id"
+const fakeFolderID = "fake-folder-id"
+
 func TestConfigInitAndValidate(t *testing.T) {
 	config := Config{
 		Endpoint:  testConfigEndpoint,
@@ -120,6 +128,104 @@ func (s *userAgentMockServerAPIEndpoint) List(ctx context.Context, r *endpoint.L
 	}, nil
 }
 
+// newFakeCloudConfig returns a config initialized against an in-memory fake
+// of Yandex Cloud API, so resources can be tested without credentials and network.
+func newFakeCloudConfig(t *testing.T) (*Config, *fakecloud.Server) {
+	server, err := fakecloud.NewServer()
+	require.NoError(t, err, "failed to start fake cloud server")
+	t.Cleanup(server.Stop)
+
+	server.AddCloud(fakeCloudID, "fake-cloud")
+	server.AddFolder(fakeCloudID, fakeFolderID, "fake-folder")
+
+	config := &Config{
+		Endpoint:   server.Addr(),
+		FolderID:   fakeFolderID,
+		CloudID:    fakeCloudID,
+		Zone:       testConfigZone,
+		Token:      fakecloud.Token,
+		Plaintext:  true,
+		MaxRetries: common.DefaultMaxRetries,
+	}
+
+	err = config.initAndValidate(context.Background(), testTerraformVersion, false)
+	require.NoError(t, err, "failed to init config against fake cloud server")
+
+	return config, server
+}
+
+func TestConfigInitAgainstFakeCloud(t *testing.T) {
+	config, _ := newFakeCloudConfig(t)
+
+	folder, err := config.sdk.ResourceManager().Folder().Get(config.Context(), &resourcemanager.GetFolderRequest{
+		FolderId: fakeFolderID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fakeCloudID, folder.CloudId)
+}
+
+func TestConfigImpersonateServiceAccountAgainstFakeCloud(t *testing.T) {
+	config, server := newFakeCloudConfig(t)
+
+	op, err := config.sdk.WrapOperation(config.sdk.IAM().ServiceAccount().Create(config.Context(), &iam.CreateServiceAccountRequest{
+		FolderId: fakeFolderID,
+		Name:     "terraform",
+	}))
+	require.NoError(t, err)
+	md, err := op.Metadata()
+	require.NoError(t, err)
+	saID := md.(*iam.CreateServiceAccountMetadata).ServiceAccountId
+
+	impersonating := func(serviceAccountID string) *Config {
+		c := &Config{
+			Endpoint:                    server.Addr(),
+			FolderID:                    fakeFolderID,
+			Token:                       fakecloud.Token,
+			Plaintext:                   true,
+			MaxRetries:                  common.DefaultMaxRetries,
+			ImpersonateServiceAccountID: serviceAccountID,
+		}
+		require.NoError(t, c.initAndValidate(context.Background(), testTerraformVersion, false))
+		return c
+	}
+
+	c := impersonating(saID)
+	_, err = c.sdk.ResourceManager().Folder().Get(c.Context(), &resourcemanager.GetFolderRequest{FolderId: fakeFolderID})
+	assert.NoError(t, err)
+
+	c = impersonating("missing-sa")
+	_, err = c.sdk.ResourceManager().Folder().Get(c.Context(), &resourcemanager.GetFolderRequest{FolderId: fakeFolderID})
+	assert.ErrorContains(t, err, "missing-sa")
+}
+
+func TestConfigSharedWithFrameworkProvider(t *testing.T) {
+	p := NewSDKProvider()
+	d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"endpoint":  testConfigEndpoint,
+		"folder_id": testConfigFolder,
+		"token":     testConfigToken,
+		"retry_policy": []interface{}{
+			map[string]interface{}{"base_backoff": "1s"},
+		},
+	})
+	meta, diags := providerConfigure(context.Background(), d, p, false, true)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	// The framework provider decodes the same provider block into its state.
+	frameworkConfig := provider_config.Config{ProviderState: provider_config.State{
+		Endpoint: types.StringValue(testConfigEndpoint),
+		FolderID: types.StringValue(testConfigFolder),
+		Token:    types.StringValue(testConfigToken),
+		RetryPolicy: []provider_config.RetryPolicy{
+			{BaseBackoff: types.StringValue("1s")},
+		},
+		RateLimit: []provider_config.RateLimit{},
+	}}
+	require.NoError(t, frameworkConfig.InitAndValidate(context.Background(), p.TerraformVersion, false))
+
+	assert.Same(t, meta.(*Config).sdk, frameworkConfig.SDK, "the SDK and the framework provider must share the client")
+}
+
 func localListener(t *testing.T) net.Listener {
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -129,13 +235,6 @@ func localListener(t *testing.T) net.Listener {
 	return l
 }
 
-func Test_iamKeyFromJSONContent(t *testing.T) {
-	content, err := ioutil.ReadFile(fakeSAKeyFile)
-	require.NoError(t, err, "fail on file read %s", fakeSAKeyFile)
-	_, err = iamKeyFromJSONContent(string(content))
-	require.NoError(t, err)
-}
-
 func TestConfigInitDefaultS3ClientFromSharedCredentials(t *testing.T) {
 	config := Config{
 		Endpoint:              testConfigEndpoint,
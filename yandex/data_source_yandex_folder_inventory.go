@@ -0,0 +1,328 @@
+package yandex
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/apploadbalancer/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1/instancegroup"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/containerregistry/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/k8s/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/kms/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/loadbalancer/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/clickhouse/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/greenplum/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/kafka/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/mongodb/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/mysql/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/opensearch/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/postgresql/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/redis/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/sqlserver/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/serverless/containers/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/serverless/functions/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/serverless/triggers/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
+)
+
+// inventoryObject is the part of an API object listed by the folder inventory.
+type inventoryObject interface {
+	GetId() string
+	GetName() string
+	GetLabels() map[string]string
+}
+
+type inventoryIterator[T inventoryObject] interface {
+	Next() bool
+	Value() T
+	Error() error
+}
+
+type inventoryListFunc = func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error)
+
+// folderInventorySources are the listers of the resource types supported by the folder inventory.
+// Every listed resource type is imported by its ID.
+var folderInventorySources = map[string]inventoryListFunc{
+	"yandex_compute_instance": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*compute.Instance](config.sdk.Compute().Instance().InstanceIterator(ctx, &compute.ListInstancesRequest{FolderId: folderID}))
+	},
+	"yandex_compute_disk": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*compute.Disk](config.sdk.Compute().Disk().DiskIterator(ctx, &compute.ListDisksRequest{FolderId: folderID}))
+	},
+	"yandex_compute_filesystem": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*compute.Filesystem](config.sdk.Compute().Filesystem().FilesystemIterator(ctx, &compute.ListFilesystemsRequest{FolderId: folderID}))
+	},
+	"yandex_compute_placement_group": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*compute.PlacementGroup](config.sdk.Compute().PlacementGroup().PlacementGroupIterator(ctx, &compute.ListPlacementGroupsRequest{FolderId: folderID}))
+	},
+	"yandex_compute_disk_placement_group": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*compute.DiskPlacementGroup](config.sdk.Compute().DiskPlacementGroup().DiskPlacementGroupIterator(ctx, &compute.ListDiskPlacementGroupsRequest{FolderId: folderID}))
+	},
+	"yandex_compute_gpu_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*compute.GpuCluster](config.sdk.Compute().GpuCluster().GpuClusterIterator(ctx, &compute.ListGpuClustersRequest{FolderId: folderID}))
+	},
+	"yandex_compute_instance_group": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*instancegroup.InstanceGroup](config.sdk.InstanceGroup().InstanceGroup().InstanceGroupIterator(ctx, &instancegroup.ListInstanceGroupsRequest{FolderId: folderID}))
+	},
+	"yandex_vpc_network": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*vpc.Network](config.sdk.VPC().Network().NetworkIterator(ctx, &vpc.ListNetworksRequest{FolderId: folderID}))
+	},
+	"yandex_vpc_subnet": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*vpc.Subnet](config.sdk.VPC().Subnet().SubnetIterator(ctx, &vpc.ListSubnetsRequest{FolderId: folderID}))
+	},
+	"yandex_vpc_security_group": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*vpc.SecurityGroup](config.sdk.VPC().SecurityGroup().SecurityGroupIterator(ctx, &vpc.ListSecurityGroupsRequest{FolderId: folderID}))
+	},
+	"yandex_vpc_route_table": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*vpc.RouteTable](config.sdk.VPC().RouteTable().RouteTableIterator(ctx, &vpc.ListRouteTablesRequest{FolderId: folderID}))
+	},
+	"yandex_vpc_gateway": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*vpc.Gateway](config.sdk.VPC().Gateway().GatewayIterator(ctx, &vpc.ListGatewaysRequest{FolderId: folderID}))
+	},
+	"yandex_lb_network_load_balancer": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*loadbalancer.NetworkLoadBalancer](config.sdk.LoadBalancer().NetworkLoadBalancer().NetworkLoadBalancerIterator(ctx, &loadbalancer.ListNetworkLoadBalancersRequest{FolderId: folderID}))
+	},
+	"yandex_lb_target_group": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*loadbalancer.TargetGroup](config.sdk.LoadBalancer().TargetGroup().TargetGroupIterator(ctx, &loadbalancer.ListTargetGroupsRequest{FolderId: folderID}))
+	},
+	"yandex_alb_load_balancer": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*apploadbalancer.LoadBalancer](config.sdk.ApplicationLoadBalancer().LoadBalancer().LoadBalancerIterator(ctx, &apploadbalancer.ListLoadBalancersRequest{FolderId: folderID}))
+	},
+	"yandex_alb_http_router": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*apploadbalancer.HttpRouter](config.sdk.ApplicationLoadBalancer().HttpRouter().HttpRouterIterator(ctx, &apploadbalancer.ListHttpRoutersRequest{FolderId: folderID}))
+	},
+	"yandex_alb_backend_group": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*apploadbalancer.BackendGroup](config.sdk.ApplicationLoadBalancer().BackendGroup().BackendGroupIterator(ctx, &apploadbalancer.ListBackendGroupsRequest{FolderId: folderID}))
+	},
+	"yandex_alb_target_group": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*apploadbalancer.TargetGroup](config.sdk.ApplicationLoadBalancer().TargetGroup().TargetGroupIterator(ctx, &apploadbalancer.ListTargetGroupsRequest{FolderId: folderID}))
+	},
+	"yandex_kubernetes_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*k8s.Cluster](config.sdk.Kubernetes().Cluster().ClusterIterator(ctx, &k8s.ListClustersRequest{FolderId: folderID}))
+	},
+	"yandex_kubernetes_node_group": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*k8s.NodeGroup](config.sdk.Kubernetes().NodeGroup().NodeGroupIterator(ctx, &k8s.ListNodeGroupsRequest{FolderId: folderID}))
+	},
+	"yandex_mdb_postgresql_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventoryPages(func(pageToken string) ([]*postgresql.Cluster, string, error) {
+			resp, err := config.sdk.MDB().PostgreSQL().Cluster().List(ctx, &postgresql.ListClustersRequest{FolderId: folderID, PageSize: defaultMDBPageSize, PageToken: pageToken})
+			return resp.GetClusters(), resp.GetNextPageToken(), err
+		})
+	},
+	"yandex_mdb_mysql_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventoryPages(func(pageToken string) ([]*mysql.Cluster, string, error) {
+			resp, err := config.sdk.MDB().MySQL().Cluster().List(ctx, &mysql.ListClustersRequest{FolderId: folderID, PageSize: defaultMDBPageSize, PageToken: pageToken})
+			return resp.GetClusters(), resp.GetNextPageToken(), err
+		})
+	},
+	"yandex_mdb_clickhouse_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventoryPages(func(pageToken string) ([]*clickhouse.Cluster, string, error) {
+			resp, err := config.sdk.MDB().Clickhouse().Cluster().List(ctx, &clickhouse.ListClustersRequest{FolderId: folderID, PageSize: defaultMDBPageSize, PageToken: pageToken})
+			return resp.GetClusters(), resp.GetNextPageToken(), err
+		})
+	},
+	"yandex_mdb_kafka_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventoryPages(func(pageToken string) ([]*kafka.Cluster, string, error) {
+			resp, err := config.sdk.MDB().Kafka().Cluster().List(ctx, &kafka.ListClustersRequest{FolderId: folderID, PageSize: defaultMDBPageSize, PageToken: pageToken})
+			return resp.GetClusters(), resp.GetNextPageToken(), err
+		})
+	},
+	"yandex_mdb_mongodb_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventoryPages(func(pageToken string) ([]*mongodb.Cluster, string, error) {
+			resp, err := config.sdk.MDB().MongoDB().Cluster().List(ctx, &mongodb.ListClustersRequest{FolderId: folderID, PageSize: defaultMDBPageSize, PageToken: pageToken})
+			return resp.GetClusters(), resp.GetNextPageToken(), err
+		})
+	},
+	"yandex_mdb_redis_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventoryPages(func(pageToken string) ([]*redis.Cluster, string, error) {
+			resp, err := config.sdk.MDB().Redis().Cluster().List(ctx, &redis.ListClustersRequest{FolderId: folderID, PageSize: defaultMDBPageSize, PageToken: pageToken})
+			return resp.GetClusters(), resp.GetNextPageToken(), err
+		})
+	},
+	"yandex_mdb_greenplum_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventoryPages(func(pageToken string) ([]*greenplum.Cluster, string, error) {
+			resp, err := config.sdk.MDB().Greenplum().Cluster().List(ctx, &greenplum.ListClustersRequest{FolderId: folderID, PageSize: defaultMDBPageSize, PageToken: pageToken})
+			return resp.GetClusters(), resp.GetNextPageToken(), err
+		})
+	},
+	"yandex_mdb_opensearch_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventoryPages(func(pageToken string) ([]*opensearch.Cluster, string, error) {
+			resp, err := config.sdk.MDB().OpenSearch().Cluster().List(ctx, &opensearch.ListClustersRequest{FolderId: folderID, PageSize: defaultMDBPageSize, PageToken: pageToken})
+			return resp.GetClusters(), resp.GetNextPageToken(), err
+		})
+	},
+	"yandex_mdb_sqlserver_cluster": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventoryPages(func(pageToken string) ([]*sqlserver.Cluster, string, error) {
+			resp, err := config.sdk.MDB().SQLServer().Cluster().List(ctx, &sqlserver.ListClustersRequest{FolderId: folderID, PageSize: defaultMDBPageSize, PageToken: pageToken})
+			return resp.GetClusters(), resp.GetNextPageToken(), err
+		})
+	},
+	"yandex_function": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*functions.Function](config.sdk.Serverless().Functions().Function().FunctionIterator(ctx, &functions.ListFunctionsRequest{FolderId: folderID}))
+	},
+	"yandex_function_trigger": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*triggers.Trigger](config.sdk.Serverless().Triggers().Trigger().TriggerIterator(ctx, &triggers.ListTriggersRequest{FolderId: folderID}))
+	},
+	"yandex_serverless_container": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*containers.Container](config.sdk.Serverless().Containers().Container().ContainerIterator(ctx, &containers.ListContainersRequest{FolderId: folderID}))
+	},
+	"yandex_kms_symmetric_key": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*kms.SymmetricKey](config.sdk.KMS().SymmetricKey().SymmetricKeyIterator(ctx, &kms.ListSymmetricKeysRequest{FolderId: folderID}))
+	},
+	"yandex_container_registry": func(ctx context.Context, config *Config, folderID string) ([]inventoryObject, error) {
+		return collectInventory[*containerregistry.Registry](config.sdk.ContainerRegistry().Registry().RegistryIterator(ctx, &containerregistry.ListRegistriesRequest{FolderId: folderID}))
+	},
+}
+
+func collectInventory[T inventoryObject](it inventoryIterator[T]) ([]inventoryObject, error) {
+	var objects []inventoryObject
+	for it.Next() {
+		objects = append(objects, it.Value())
+	}
+	return objects, it.Error()
+}
+
+func collectInventoryPages[T inventoryObject](list func(pageToken string) ([]T, string, error)) ([]inventoryObject, error) {
+	var objects []inventoryObject
+	pageToken := ""
+	for {
+		page, nextPageToken, err := list(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range page {
+			objects = append(objects, obj)
+		}
+		if nextPageToken == "" {
+			return objects, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func folderInventoryResourceTypes() []string {
+	resourceTypes := make([]string, 0, len(folderInventorySources))
+	for resourceType := range folderInventorySources {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+func sortInventoryObjects(objects []inventoryObject) {
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].GetName() != objects[j].GetName() {
+			return objects[i].GetName() < objects[j].GetName()
+		}
+		return objects[i].GetId() < objects[j].GetId()
+	})
+}
+
+func dataSourceYandexFolderInventory() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the resources of the supported types in a folder, together with the IDs to import them by.",
+		ReadContext: dataSourceYandexFolderInventoryRead,
+		Schema: map[string]*schema.Schema{
+			"folder_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the folder to list the resources of. If omitted, the provider `folder_id` is used.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"resource_types": {
+				Type:        schema.TypeSet,
+				Description: "Terraform resource types to list. If omitted, all the supported types are listed.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(folderInventoryResourceTypes(), false),
+				},
+				Set: schema.HashString,
+			},
+			"resources": {
+				Type:        schema.TypeList,
+				Description: "Resources of the folder, ordered by type and name.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Description: "Terraform resource type, e.g. `yandex_compute_instance`.",
+							Computed:    true,
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Description: "ID of the resource.",
+							Computed:    true,
+						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Description: "ID to import the resource by, i.e. the `id` of an `import` block.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the resource.",
+							Computed:    true,
+						},
+						"labels": {
+							Type:        schema.TypeMap,
+							Description: "Labels of the resource.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceYandexFolderInventoryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	folderID, err := getFolderID(d, config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceTypes := folderInventoryResourceTypes()
+	if v, ok := d.GetOk("resource_types"); ok {
+		resourceTypes = convertStringSet(v.(*schema.Set))
+		sort.Strings(resourceTypes)
+	}
+
+	var resources []map[string]interface{}
+	for _, resourceType := range resourceTypes {
+		objects, err := folderInventorySources[resourceType](ctx, config, folderID)
+		if err != nil {
+			return diag.Errorf("error while listing %s in folder %q: %s", resourceType, folderID, err)
+		}
+
+		sortInventoryObjects(objects)
+		for _, obj := range objects {
+			resources = append(resources, map[string]interface{}{
+				"type":      resourceType,
+				"id":        obj.GetId(),
+				"import_id": obj.GetId(),
+				"name":      obj.GetName(),
+				"labels":    obj.GetLabels(),
+			})
+		}
+	}
+
+	if err := d.Set("folder_id", folderID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("resources", resources); err != nil {
+		return diag.Errorf("error setting resources of folder inventory: %s", err)
+	}
+	d.SetId(folderID)
+
+	return nil
+}
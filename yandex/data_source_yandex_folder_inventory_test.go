@@ -0,0 +1,56 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFolderInventorySources_importable(t *testing.T) {
+	for _, resourceType := range folderInventoryResourceTypes() {
+		r, ok := testAccProvider.ResourcesMap[resourceType]
+		if assert.True(t, ok, "%s is not a resource of the provider", resourceType) {
+			assert.NotNil(t, r.Importer, "%s can't be imported", resourceType)
+		}
+	}
+}
+
+func TestDataSourceYandexFolderInventory_offline(t *testing.T) {
+	config, server := newFakeCloudConfig(t)
+	server.AddFolder(fakeCloudID, "other-folder", "other-folder")
+
+	network := resourceYandexVPCNetwork()
+	for _, raw := range []map[string]interface{}{
+		{"name": "tf-network-b"},
+		{"name": "tf-network-a", "labels": map[string]interface{}{"env": "prod"}},
+		{"name": "tf-network-other", "folder_id": "other-folder"},
+	} {
+		d := schema.TestResourceDataRaw(t, network.Schema, raw)
+		require.NoError(t, network.Create(d, config))
+	}
+
+	ds := dataSourceYandexFolderInventory()
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"resource_types": []interface{}{"yandex_vpc_subnet", "yandex_vpc_network", "yandex_compute_disk"},
+	})
+	require.False(t, ds.ReadContext(config.Context(), d, config).HasError())
+
+	assert.Equal(t, fakeFolderID, d.Id())
+	assert.Equal(t, fakeFolderID, d.Get("folder_id"))
+
+	resources := d.Get("resources").([]interface{})
+	require.Len(t, resources, 2, "only the networks of the provider folder must be listed")
+
+	first := resources[0].(map[string]interface{})
+	assert.Equal(t, "yandex_vpc_network", first["type"])
+	assert.Equal(t, "tf-network-a", first["name"])
+	assert.Equal(t, first["id"], first["import_id"])
+	assert.Equal(t, map[string]interface{}{"env": "prod"}, first["labels"])
+	assert.NotNil(t, server.Get(first["id"].(string)))
+
+	second := resources[1].(map[string]interface{})
+	assert.Equal(t, "tf-network-b", second["name"])
+	assert.Empty(t, second["labels"])
+}
@@ -21,9 +21,9 @@ func TestAccDataSourceYandexFunction_byID(t *testing.T) {
 	zipFilename := "test-fixtures/serverless/main.zip"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionByID(functionName, functionDesc, zipFilename),
@@ -49,9 +49,9 @@ func TestAccDataSourceYandexFunction_byName(t *testing.T) {
 	zipFilename := "test-fixtures/serverless/main.zip"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionByName(functionName, functionDesc, zipFilename),
@@ -105,9 +105,9 @@ func TestAccDataSourceYandexFunction_full(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionDataSource(params),
@@ -0,0 +1,224 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/organizationmanager/v1"
+)
+
+func iamAccessBindingsResourceTypes() []string {
+	resourceTypes := make([]string, 0, len(iamResources))
+	for prefix := range iamResources {
+		resourceTypes = append(resourceTypes, strings.TrimPrefix(prefix, "yandex_"))
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+func dataSourceYandexIAMAccessBindings() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the access bindings of a resource with the names of their subjects.",
+		ReadContext: dataSourceYandexIAMAccessBindingsRead,
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:         schema.TypeString,
+				Description:  "Type of the resource, the prefix of its `_iam_member` resource without `yandex_`, e.g. `resourcemanager_folder`.",
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(iamAccessBindingsResourceTypes(), false),
+			},
+			"resource_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the resource.",
+				Required:    true,
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Description: "Patterns of the roles to list. A pattern matches a role equal to it or prefixed by it and a dot, `*` matches any characters. If omitted, all the roles are listed.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Set: schema.HashString,
+			},
+			"access_bindings": {
+				Type:        schema.TypeList,
+				Description: "Access bindings of the resource, ordered by role and member.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": {
+							Type:        schema.TypeString,
+							Description: "ID of the role.",
+							Computed:    true,
+						},
+						"member": {
+							Type:        schema.TypeString,
+							Description: "Subject of the binding in the `<type>:<id>` form of the `member` of `_iam_member` resources.",
+							Computed:    true,
+						},
+						"subject_type": {
+							Type:        schema.TypeString,
+							Description: "Type of the subject, e.g. `userAccount`, `serviceAccount`, `federatedUser`, `group` or `system`.",
+							Computed:    true,
+						},
+						"subject_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the subject.",
+							Computed:    true,
+						},
+						"subject_name": {
+							Type:        schema.TypeString,
+							Description: "Login of a user, name of a service account or a group, ID of a system group. Empty if the subject can't be read.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceYandexIAMAccessBindingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	resourceType := d.Get("resource_type").(string)
+	resourceID := d.Get("resource_id").(string)
+
+	r, ok := iamResources["yandex_"+resourceType]
+	if !ok {
+		return diag.Errorf("unsupported resource type %q", resourceType)
+	}
+
+	var patterns []*regexp.Regexp
+	if v, ok := d.GetOk("roles"); ok {
+		for _, pattern := range convertStringSet(v.(*schema.Set)) {
+			patterns = append(patterns, rolePatternRegexp(pattern))
+		}
+	}
+
+	updater, err := r.updaterByID(resourceID, config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policy, err := updater.GetResourceIamPolicy(ctx)
+	if err != nil {
+		return diag.Errorf("error reading access bindings of %s: %s", updater.DescribeResource(), err)
+	}
+
+	var bindings []*access.AccessBinding
+	for _, b := range policy.Bindings {
+		if matchesRolePatterns(b.RoleId, patterns) {
+			bindings = append(bindings, b)
+		}
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		if bindings[i].RoleId != bindings[j].RoleId {
+			return bindings[i].RoleId < bindings[j].RoleId
+		}
+		return canonicalMember(bindings[i]) < canonicalMember(bindings[j])
+	})
+
+	names := make(map[string]string)
+	result := make([]map[string]interface{}, 0, len(bindings))
+	for _, b := range bindings {
+		member := canonicalMember(b)
+		name, ok := names[member]
+		if !ok {
+			name = resolveSubjectName(ctx, config, b.Subject)
+			names[member] = name
+		}
+		result = append(result, map[string]interface{}{
+			"role":         b.RoleId,
+			"member":       member,
+			"subject_type": b.Subject.Type,
+			"subject_id":   b.Subject.Id,
+			"subject_name": name,
+		})
+	}
+
+	if err := d.Set("access_bindings", result); err != nil {
+		return diag.Errorf("error setting access bindings of %s: %s", updater.DescribeResource(), err)
+	}
+	d.SetId(resourceType + "/" + resourceID)
+
+	return nil
+}
+
+// rolePatternRegexp compiles a role pattern, which matches the roles equal to it
+// or prefixed by it and a dot. "*" matches any characters.
+func rolePatternRegexp(pattern string) *regexp.Regexp {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `.*`)
+	return regexp.MustCompile(`^` + expr + `(\..*)?$`)
+}
+
+func matchesRolePatterns(role string, patterns []*regexp.Regexp) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if p.MatchString(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSubjectName returns the login of a user, the name of a service account or
+// a group, and the ID of other subjects. A subject that can't be read, e.g. one of
+// another organization, is logged and gets an empty name.
+func resolveSubjectName(ctx context.Context, config *Config, subject *access.Subject) string {
+	name, err := subjectName(ctx, config, subject)
+	if err != nil {
+		log.Printf("[WARN] Can't resolve the name of %s:%s: %s", subject.Type, subject.Id, err)
+		return ""
+	}
+	return name
+}
+
+func subjectName(ctx context.Context, config *Config, subject *access.Subject) (string, error) {
+	switch subject.Type {
+	case "userAccount", "federatedUser":
+		user, err := config.sdk.IAM().UserAccount().Get(ctx, &iam.GetUserAccountRequest{
+			UserAccountId: subject.Id,
+		})
+		if err != nil {
+			return "", err
+		}
+		if passport := user.GetYandexPassportUserAccount(); passport != nil {
+			return passport.Login, nil
+		}
+		if saml := user.GetSamlUserAccount(); saml != nil {
+			return saml.NameId, nil
+		}
+		return "", fmt.Errorf("user account %q has neither login nor name ID", subject.Id)
+	case "serviceAccount":
+		sa, err := config.sdk.IAM().ServiceAccount().Get(ctx, &iam.GetServiceAccountRequest{
+			ServiceAccountId: subject.Id,
+		})
+		if err != nil {
+			return "", err
+		}
+		return sa.Name, nil
+	case "group":
+		group, err := config.sdk.OrganizationManager().Group().Get(ctx, &organizationmanager.GetGroupRequest{
+			GroupId: subject.Id,
+		})
+		if err != nil {
+			return "", err
+		}
+		return group.Name, nil
+	default:
+		return subject.Id, nil
+	}
+}
@@ -0,0 +1,80 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+)
+
+func TestRolePatternRegexp(t *testing.T) {
+	cases := []struct {
+		pattern string
+		role    string
+		match   bool
+	}{
+		{"admin", "admin", true},
+		{"admin", "administrator", false},
+		{"admin", "storage.admin", false},
+		{"storage", "storage.editor", true},
+		{"storage", "storage.buckets.admin", true},
+		{"storage", "storage-admin", false},
+		{"*.editor", "storage.editor", true},
+		{"*.editor", "k8s.cluster-api.editor", true},
+		{"*.editor", "editor", false},
+		{"*.editor", "storage.editor.extra", true},
+		{"resource-manager.*", "resource-manager.clouds.owner", true},
+		{"*", "viewer", true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.match, rolePatternRegexp(c.pattern).MatchString(c.role), "pattern %q, role %q", c.pattern, c.role)
+	}
+}
+
+func TestDataSourceYandexIAMAccessBindings_offline(t *testing.T) {
+	config, _ := newFakeCloudConfig(t)
+
+	sa := resourceYandexIAMServiceAccount()
+	saData := schema.TestResourceDataRaw(t, sa.Schema, map[string]interface{}{"name": "tf-auditor"})
+	require.NoError(t, sa.Create(saData, config))
+
+	updater, err := iamResources["yandex_resourcemanager_folder"].updaterByID(fakeFolderID, config)
+	require.NoError(t, err)
+	require.NoError(t, updater.SetResourceIamPolicy(config.Context(), &Policy{Bindings: []*access.AccessBinding{
+		roleMemberToAccessBinding("viewer", "serviceAccount:"+saData.Id()),
+		roleMemberToAccessBinding("storage.editor", "serviceAccount:"+saData.Id()),
+		roleMemberToAccessBinding("storage.viewer", "system:allAuthenticatedUsers"),
+		roleMemberToAccessBinding("editor", "serviceAccount:"+saData.Id()),
+	}}))
+
+	ds := dataSourceYandexIAMAccessBindings()
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"resource_type": "resourcemanager_folder",
+		"resource_id":   fakeFolderID,
+		"roles":         []interface{}{"storage", "viewer"},
+	})
+	require.False(t, ds.ReadContext(config.Context(), d, config).HasError())
+
+	assert.Equal(t, "resourcemanager_folder/"+fakeFolderID, d.Id())
+
+	bindings := d.Get("access_bindings").([]interface{})
+	require.Len(t, bindings, 3, "only the bindings of the matching roles must be listed")
+	assert.Equal(t, map[string]interface{}{
+		"role":         "storage.editor",
+		"member":       "serviceAccount:" + saData.Id(),
+		"subject_type": "serviceAccount",
+		"subject_id":   saData.Id(),
+		"subject_name": "tf-auditor",
+	}, bindings[0])
+	assert.Equal(t, map[string]interface{}{
+		"role":         "storage.viewer",
+		"member":       "system:allAuthenticatedUsers",
+		"subject_type": "system",
+		"subject_id":   "allAuthenticatedUsers",
+		"subject_name": "allAuthenticatedUsers",
+	}, bindings[1])
+	assert.Equal(t, "viewer", bindings[2].(map[string]interface{})["role"])
+}
@@ -0,0 +1,73 @@
+package yandex
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common/client"
+)
+
+const yandexOperationDefaultTimeout = 30 * time.Minute
+
+func dataSourceYandexOperation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Waits for an operation started outside of Terraform to complete and exposes its result.",
+		ReadContext: dataSourceYandexOperationRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(yandexOperationDefaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"operation_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the operation to wait for.",
+				Required:    true,
+			},
+			"done": {
+				Type:        schema.TypeBool,
+				Description: "Whether the operation has completed.",
+				Computed:    true,
+			},
+			"error": {
+				Type:        schema.TypeString,
+				Description: "Error the operation failed with, empty if it succeeded.",
+				Computed:    true,
+			},
+			"metadata_json": {
+				Type:        schema.TypeString,
+				Description: "Metadata of the operation in JSON format.",
+				Computed:    true,
+			},
+			"response_json": {
+				Type:        schema.TypeString,
+				Description: "Response of the operation in JSON format, empty if the operation failed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceYandexOperationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	operationID := d.Get("operation_id").(string)
+	result, err := client.WaitOperation(ctx, config.sdk, operationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("done", result.Done)
+	d.Set("error", result.Error)
+	d.Set("metadata_json", result.MetadataJSON)
+	d.Set("response_json", result.ResponseJSON)
+	d.SetId(operationID)
+
+	return nil
+}
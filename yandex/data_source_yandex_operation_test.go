@@ -0,0 +1,39 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
+)
+
+func TestDataSourceYandexOperation_offline(t *testing.T) {
+	config, _ := newFakeCloudConfig(t)
+
+	op, err := config.sdk.VPC().Network().Create(config.Context(), &vpc.CreateNetworkRequest{
+		FolderId: fakeFolderID,
+		Name:     "tf-network",
+	})
+	require.NoError(t, err)
+
+	ds := dataSourceYandexOperation()
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"operation_id": op.GetId(),
+	})
+	require.False(t, ds.ReadContext(config.Context(), d, config).HasError())
+
+	assert.Equal(t, op.GetId(), d.Id())
+	assert.True(t, d.Get("done").(bool))
+	assert.Empty(t, d.Get("error"))
+	assert.Contains(t, d.Get("metadata_json"), `"networkId"`)
+	assert.Contains(t, d.Get("response_json"), `"name":"tf-network"`)
+
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"operation_id": "unknown-operation-id",
+	})
+	diags := ds.ReadContext(config.Context(), d, config)
+	require.True(t, diags.HasError(), "an unknown operation must not be waited for")
+	assert.Contains(t, diags[0].Summary, `"unknown-operation-id"`)
+}
@@ -23,9 +23,9 @@ func TestAccDataSourceYandexServerlessContainer_byID(t *testing.T) {
 	memory := (1 + acctest.RandIntRange(1, 3)) * 128
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexServerlessContainerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexServerlessContainerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexServerlessContainerByID(containerName, containerDesc, memory, serverlessContainerTestImage1),
@@ -53,9 +53,9 @@ func TestAccDataSourceYandexServerlessContainer_byName(t *testing.T) {
 	memory := (1 + acctest.RandIntRange(1, 3)) * 128
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexServerlessContainerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexServerlessContainerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexServerlessContainerByName(containerName, containerDesc, memory, serverlessContainerTestImage1),
@@ -114,9 +114,9 @@ func TestAccDataSourceYandexServerlessContainer_full(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexServerlessContainerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexServerlessContainerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexServerlessContainerDataSource(params),
@@ -0,0 +1,227 @@
+package yandex
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+const allLabelsPropName = "all_labels"
+
+type crudContextFunc = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics
+
+// withDefaultLabels makes a resource with a "labels" field aware of the provider default_labels.
+// The defaults are merged into the labels sent to the API, while the "labels" attribute keeps
+// only the labels set on the resource itself, so that diffs are not polluted by the defaults.
+// The merged set is exposed through the computed "all_labels" attribute, which also tells the
+// labels derived from the defaults when they change. Labels that can't be updated get the
+// defaults on create only, changes of the defaults do not replace such resources.
+func withDefaultLabels(r *schema.Resource) *schema.Resource {
+	labels, ok := r.Schema["labels"]
+	if !ok || labels.Type != schema.TypeMap || !labels.Optional {
+		return r
+	}
+	if _, ok := r.Schema[allLabelsPropName]; ok {
+		return r
+	}
+	updatable := !labels.ForceNew && (r.Update != nil || r.UpdateContext != nil || r.UpdateWithoutTimeout != nil)
+
+	r.Schema[allLabelsPropName] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "All labels of the resource, including the provider default labels.",
+	}
+
+	if r.Create != nil {
+		r.Create = wrapDefaultLabelsApply(r.Create)
+	}
+	if r.CreateContext != nil {
+		r.CreateContext = wrapDefaultLabelsApplyContext(r.CreateContext)
+	}
+	if r.CreateWithoutTimeout != nil {
+		r.CreateWithoutTimeout = wrapDefaultLabelsApplyContext(r.CreateWithoutTimeout)
+	}
+	if updatable && r.Update != nil {
+		r.Update = wrapDefaultLabelsApply(r.Update)
+	}
+	if updatable && r.UpdateContext != nil {
+		r.UpdateContext = wrapDefaultLabelsApplyContext(r.UpdateContext)
+	}
+	if updatable && r.UpdateWithoutTimeout != nil {
+		r.UpdateWithoutTimeout = wrapDefaultLabelsApplyContext(r.UpdateWithoutTimeout)
+	}
+	if r.Read != nil {
+		r.Read = wrapDefaultLabelsRead(r.Read)
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = wrapDefaultLabelsReadContext(r.ReadContext)
+	}
+	if r.ReadWithoutTimeout != nil {
+		r.ReadWithoutTimeout = wrapDefaultLabelsReadContext(r.ReadWithoutTimeout)
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, d, meta); err != nil {
+				return err
+			}
+		}
+		if !updatable && d.Id() != "" && !d.HasChange("labels") {
+			// The resource is not replaced because of the defaults only.
+			return nil
+		}
+		return customizeDiffAllLabels(ctx, d, meta)
+	}
+
+	return r
+}
+
+// labelsChanged reports whether the labels sent to the API have to be updated: either the
+// resource own labels or the provider default labels merged into them have changed.
+func labelsChanged(d *schema.ResourceData) bool {
+	return d.HasChanges("labels", allLabelsPropName)
+}
+
+// hasFieldChange is the same as d.HasChange, but also takes the default labels into account.
+func hasFieldChange(d *schema.ResourceData, field string) bool {
+	if field == "labels" {
+		return labelsChanged(d)
+	}
+	return d.HasChange(field)
+}
+
+func providerDefaultLabels(meta interface{}) map[string]string {
+	if config, ok := meta.(*Config); ok && config != nil {
+		return config.DefaultLabels
+	}
+	return nil
+}
+
+func customizeDiffAllLabels(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("labels") {
+		return d.SetNewComputed(allLabelsPropName)
+	}
+
+	own, err := expandLabels(d.Get("labels"))
+	if err != nil {
+		return err
+	}
+	old, err := expandLabels(d.Get(allLabelsPropName))
+	if err != nil {
+		return err
+	}
+
+	merged := common.MergeLabels(providerDefaultLabels(meta), own)
+	if reflect.DeepEqual(old, merged) {
+		return nil
+	}
+	return d.SetNew(allLabelsPropName, merged)
+}
+
+// defaultLabelsApply sends the merged labels to the API on create and update,
+// and strips the defaults back from the "labels" attribute afterwards.
+func defaultLabelsApply(d *schema.ResourceData, meta interface{}, apply func() error) error {
+	defaults := providerDefaultLabels(meta)
+	own, err := expandLabels(d.Get("labels"))
+	if err != nil {
+		return err
+	}
+
+	merged := common.MergeLabels(defaults, own)
+	if err := d.Set("labels", merged); err != nil {
+		return err
+	}
+
+	applyErr := apply()
+	if d.Id() == "" {
+		return applyErr
+	}
+	if err := flattenDefaultLabels(d, own, merged, defaults); err != nil && applyErr == nil {
+		return err
+	}
+	return applyErr
+}
+
+// defaultLabelsRead strips the defaults from the labels read from the API. The labels of the state
+// before the read are set on the resource itself, the rest of its all_labels were derived from the
+// defaults, even if the defaults changed since then.
+func defaultLabelsRead(d *schema.ResourceData, meta interface{}, read func() error) error {
+	own, err := expandLabels(d.Get("labels"))
+	if err != nil {
+		return err
+	}
+	previousAll, err := expandLabels(d.Get(allLabelsPropName))
+	if err != nil {
+		return err
+	}
+
+	readErr := read()
+	if d.Id() == "" {
+		return readErr
+	}
+	if err := flattenDefaultLabels(d, own, previousAll, providerDefaultLabels(meta)); err != nil && readErr == nil {
+		return err
+	}
+	return readErr
+}
+
+func flattenDefaultLabels(d *schema.ResourceData, own, previousAll, defaults map[string]string) error {
+	all, err := expandLabels(d.Get("labels"))
+	if err != nil {
+		return err
+	}
+	if err := d.Set(allLabelsPropName, all); err != nil {
+		return err
+	}
+	return d.Set("labels", common.StripDefaultLabels(all, own, previousAll, defaults))
+}
+
+func wrapDefaultLabelsApply(f crudFunc) crudFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		return defaultLabelsApply(d, meta, func() error {
+			return f(d, meta)
+		})
+	}
+}
+
+func wrapDefaultLabelsApplyContext(f crudContextFunc) crudContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		var diags diag.Diagnostics
+		err := defaultLabelsApply(d, meta, func() error {
+			diags = f(ctx, d, meta)
+			return nil
+		})
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		return diags
+	}
+}
+
+func wrapDefaultLabelsRead(f crudFunc) crudFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		return defaultLabelsRead(d, meta, func() error {
+			return f(d, meta)
+		})
+	}
+}
+
+func wrapDefaultLabelsReadContext(f crudContextFunc) crudContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		var diags diag.Diagnostics
+		err := defaultLabelsRead(d, meta, func() error {
+			diags = f(ctx, d, meta)
+			return nil
+		})
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		return diags
+	}
+}
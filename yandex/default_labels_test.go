@@ -0,0 +1,115 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWithDefaultLabels_schema(t *testing.T) {
+	r := withDefaultLabels(resourceYandexVPCNetwork())
+	require.Contains(t, r.Schema, allLabelsPropName)
+	assert.True(t, r.Schema[allLabelsPropName].Computed)
+	assert.False(t, r.Schema[allLabelsPropName].Optional)
+	assert.NotNil(t, r.CustomizeDiff)
+
+}
+
+func TestWithDefaultLabels_forceNew(t *testing.T) {
+	var created map[string]interface{}
+	r := withDefaultLabels(&schema.Resource{
+		Create: func(d *schema.ResourceData, _ interface{}) error {
+			created = d.Get("labels").(map[string]interface{})
+			d.SetId("force-new")
+			return nil
+		},
+		Read: func(d *schema.ResourceData, _ interface{}) error {
+			return d.Set("labels", created)
+		},
+		Delete: func(*schema.ResourceData, interface{}) error { return nil },
+		Schema: map[string]*schema.Schema{
+			"labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	})
+	require.Contains(t, r.Schema, allLabelsPropName, "labels that can't be updated must get the defaults on create")
+	require.NoError(t, r.InternalValidate(nil, true))
+
+	config := &Config{DefaultLabels: map[string]string{"owner": "tf-team"}}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"labels": map[string]interface{}{"app": "web"},
+	})
+	require.NoError(t, r.Create(d, config))
+	assert.Equal(t, map[string]interface{}{"owner": "tf-team", "app": "web"}, created)
+	assert.Equal(t, map[string]interface{}{"app": "web"}, d.Get("labels"))
+
+	config.DefaultLabels = map[string]string{"owner": "other-team"}
+	require.NoError(t, r.Read(d, config))
+	assert.Equal(t, map[string]interface{}{"app": "web"}, d.Get("labels"))
+	assert.Equal(t, map[string]interface{}{"owner": "tf-team", "app": "web"}, d.Get(allLabelsPropName))
+}
+
+func TestWithDefaultLabels_offline(t *testing.T) {
+	config, server := newFakeCloudConfig(t)
+	config.DefaultLabels = map[string]string{
+		"owner": "tf-team",
+		"env":   "dev",
+	}
+	r := withDefaultLabels(resourceYandexVPCNetwork())
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "tf-network",
+		"labels": map[string]interface{}{
+			"env":      "prod",
+			"tf-label": "tf-label-value",
+		},
+	})
+	require.NoError(t, r.Create(d, config))
+	id := d.Id()
+
+	network := server.Get(id).(*vpc.Network)
+	assert.Equal(t, map[string]string{
+		"owner":    "tf-team",
+		"env":      "prod",
+		"tf-label": "tf-label-value",
+	}, network.Labels, "resource labels must win over the default ones")
+	assert.Equal(t, map[string]interface{}{
+		"env":      "prod",
+		"tf-label": "tf-label-value",
+	}, d.Get("labels"))
+	assert.Equal(t, map[string]interface{}{
+		"owner":    "tf-team",
+		"env":      "prod",
+		"tf-label": "tf-label-value",
+	}, d.Get(allLabelsPropName))
+
+	imported := r.TestResourceData()
+	imported.SetId(id)
+	require.NoError(t, r.Read(imported, config))
+	assert.Equal(t, map[string]interface{}{
+		"env":      "prod",
+		"tf-label": "tf-label-value",
+	}, imported.Get("labels"), "default labels must not show up in labels on import")
+
+	config.DefaultLabels["owner"] = "other-team"
+	require.NoError(t, r.Read(d, config))
+	assert.Equal(t, map[string]interface{}{
+		"env":      "prod",
+		"tf-label": "tf-label-value",
+	}, d.Get("labels"), "labels derived from the changed defaults must not show up in labels")
+	assert.Equal(t, "tf-team", d.Get(allLabelsPropName+".owner"))
+
+	require.True(t, server.Mutate(id, func(obj proto.Message) {
+		obj.(*vpc.Network).Labels["added"] = "out-of-band"
+	}))
+	require.NoError(t, r.Read(d, config))
+	assert.Equal(t, "out-of-band", d.Get("labels.added"), "labels added out of band must be kept")
+}
@@ -0,0 +1,342 @@
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// GenerateOptions are the options of the generate subcommand of the provider.
+type GenerateOptions struct {
+	// FolderID is the folder to list the resources of, the provider folder is used if it is empty.
+	FolderID string
+	// ResourceTypes are the resource types to list, with or without the yandex_ prefix.
+	// All the types supported by yandex_folder_inventory are listed if it is empty.
+	ResourceTypes []string
+}
+
+// Generate lists the resources of the requested types in a folder and writes an import block
+// together with a resource block for each of them to w. The provider is configured from the
+// environment, the same way as in Terraform with an empty provider block.
+func Generate(ctx context.Context, w io.Writer, opts GenerateOptions) error {
+	provider := NewSDKProvider()
+	raw := map[string]interface{}{}
+	if opts.FolderID != "" {
+		raw["folder_id"] = opts.FolderID
+	}
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		return fmt.Errorf("error configuring provider: %s", diagnosticsError(diags))
+	}
+	config := provider.Meta().(*Config)
+
+	folderID := opts.FolderID
+	if folderID == "" {
+		folderID = config.FolderID
+	}
+	if folderID == "" {
+		return fmt.Errorf("cannot determine folder_id: please set it in the options or at provider level")
+	}
+
+	return generateConfig(ctx, w, config, provider.ResourcesMap, folderID, opts.ResourceTypes)
+}
+
+func generateConfig(ctx context.Context, w io.Writer, config *Config, resources map[string]*schema.Resource, folderID string, types []string) error {
+	resourceTypes, err := expandGenerateResourceTypes(types)
+	if err != nil {
+		return err
+	}
+
+	for _, resourceType := range resourceTypes {
+		objects, err := folderInventorySources[resourceType](ctx, config, folderID)
+		if err != nil {
+			return fmt.Errorf("error while listing %s in folder %q: %s", resourceType, folderID, err)
+		}
+		sortInventoryObjects(objects)
+
+		r := resources[resourceType]
+		names := map[string]bool{}
+		for _, obj := range objects {
+			state, diags := r.RefreshWithoutUpgrade(ctx, &terraform.InstanceState{
+				ID:         obj.GetId(),
+				Attributes: map[string]string{"id": obj.GetId()},
+			}, config)
+			if diags.HasError() {
+				return fmt.Errorf("error while reading %s %q: %s", resourceType, obj.GetId(), diagnosticsError(diags))
+			}
+			if state == nil || state.ID == "" {
+				// The resource was deleted after it had been listed.
+				continue
+			}
+
+			name := generateResourceName(obj, names)
+			if _, err := fmt.Fprintf(w, "import {\n  to = %s.%s\n  id = %s\n}\n\n", resourceType, name, hclString(obj.GetId())); err != nil {
+				return err
+			}
+
+			var b strings.Builder
+			fmt.Fprintf(&b, "resource %s %s {\n", hclString(resourceType), hclString(name))
+			writeHCLBody(&b, 1, r.Schema, generateValues(r.Schema, r.Data(state)))
+			b.WriteString("}\n\n")
+			if _, err := io.WriteString(w, b.String()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func expandGenerateResourceTypes(types []string) ([]string, error) {
+	if len(types) == 0 {
+		return folderInventoryResourceTypes(), nil
+	}
+
+	var resourceTypes []string
+	seen := map[string]bool{}
+	for _, t := range types {
+		t = strings.TrimSpace(t)
+		if !strings.HasPrefix(t, "yandex_") {
+			t = "yandex_" + t
+		}
+		if _, ok := folderInventorySources[t]; !ok {
+			return nil, fmt.Errorf("unsupported resource type %q, supported types are: %s", t, strings.Join(folderInventoryResourceTypes(), ", "))
+		}
+		if !seen[t] {
+			seen[t] = true
+			resourceTypes = append(resourceTypes, t)
+		}
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes, nil
+}
+
+var invalidResourceNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// generateResourceName makes a unique Terraform resource name from the name of the object,
+// or from its ID if the object has no name.
+func generateResourceName(obj inventoryObject, used map[string]bool) string {
+	name := obj.GetName()
+	if name == "" {
+		name = obj.GetId()
+	}
+	name = invalidResourceNameChars.ReplaceAllString(name, "_")
+	if c := name[0]; c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+		name = "r_" + name
+	}
+
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+func generateValues(s map[string]*schema.Schema, d *schema.ResourceData) map[string]interface{} {
+	values := make(map[string]interface{}, len(s))
+	for k := range s {
+		values[k] = d.Get(k)
+	}
+	return values
+}
+
+// writeHCLBody writes the arguments of a resource that are set in values. Computed only,
+// deprecated and sensitive attributes are skipped, as well as the ones conflicting with
+// an attribute already written.
+func writeHCLBody(b *strings.Builder, indent int, s map[string]*schema.Schema, values map[string]interface{}) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	type attribute struct {
+		key, value string
+	}
+	var attributes []attribute
+	type block struct {
+		key    string
+		elem   *schema.Resource
+		values []interface{}
+	}
+	var blocks []block
+
+	written := map[string]bool{}
+	for _, k := range keys {
+		sch := s[k]
+		if k == "id" || (!sch.Optional && !sch.Required) || sch.Deprecated != "" || sch.Sensitive {
+			continue
+		}
+		v := values[k]
+		if set, ok := v.(*schema.Set); ok {
+			v = set.List()
+		}
+		if v == nil || (!sch.Required && isZeroHCLValue(v)) {
+			continue
+		}
+		if conflictsWithWritten(sch, written) {
+			continue
+		}
+		written[k] = true
+
+		if elem, ok := sch.Elem.(*schema.Resource); ok && (sch.Type == schema.TypeList || sch.Type == schema.TypeSet) {
+			blocks = append(blocks, block{key: k, elem: elem, values: v.([]interface{})})
+			continue
+		}
+		attributes = append(attributes, attribute{key: k, value: hclValue(v, indent)})
+	}
+
+	prefix := strings.Repeat("  ", indent)
+	// Consecutive single line attributes are aligned the same way as terraform fmt does.
+	for i := 0; i < len(attributes); {
+		j, width := i, 0
+		for ; j < len(attributes) && !strings.Contains(attributes[j].value, "\n"); j++ {
+			if len(attributes[j].key) > width {
+				width = len(attributes[j].key)
+			}
+		}
+		if j == i {
+			fmt.Fprintf(b, "%s%s = %s\n", prefix, attributes[i].key, attributes[i].value)
+			i++
+			continue
+		}
+		for ; i < j; i++ {
+			fmt.Fprintf(b, "%s%-*s = %s\n", prefix, width, attributes[i].key, attributes[i].value)
+		}
+	}
+
+	separate := len(attributes) > 0
+	for _, bl := range blocks {
+		for _, v := range bl.values {
+			if separate {
+				b.WriteString("\n")
+			}
+			separate = true
+			m, _ := v.(map[string]interface{})
+			fmt.Fprintf(b, "%s%s {\n", prefix, bl.key)
+			writeHCLBody(b, indent+1, bl.elem.Schema, m)
+			fmt.Fprintf(b, "%s}\n", prefix)
+		}
+	}
+}
+
+// conflictsWithWritten reports whether the attribute conflicts with one written in the same body,
+// the conflicting attributes of nested blocks are matched by the last element of their path.
+func conflictsWithWritten(sch *schema.Schema, written map[string]bool) bool {
+	for _, keys := range [][]string{sch.ConflictsWith, sch.ExactlyOneOf} {
+		for _, k := range keys {
+			if written[k[strings.LastIndex(k, ".")+1:]] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isZeroHCLValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func hclValue(v interface{}, indent int) string {
+	switch v := v.(type) {
+	case string:
+		return hclString(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case *schema.Set:
+		return hclValue(v.List(), indent)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = hclValue(item, indent)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "{}"
+		}
+		keys := make([]string, 0, len(v))
+		width := 0
+		for k := range v {
+			key := hclMapKey(k)
+			keys = append(keys, k)
+			if len(key) > width {
+				width = len(key)
+			}
+		}
+		sort.Strings(keys)
+
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s%-*s = %s\n", strings.Repeat("  ", indent+1), width, hclMapKey(k), hclValue(v[k], indent+1))
+		}
+		b.WriteString(strings.Repeat("  ", indent) + "}")
+		return b.String()
+	}
+	return hclString(fmt.Sprint(v))
+}
+
+var hclIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+func hclMapKey(k string) string {
+	if hclIdentifier.MatchString(k) {
+		return k
+	}
+	return hclString(k)
+}
+
+// hclString quotes s as an HCL string literal, escaping the template sequences.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case r < 0x20:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package yandex
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateConfig_offline(t *testing.T) {
+	config, _ := newFakeCloudConfig(t)
+
+	network := resourceYandexVPCNetwork()
+	var ids []string
+	for _, raw := range []map[string]interface{}{
+		{"name": "tf-network-a", "description": "uses ${var.name}", "labels": map[string]interface{}{"env": "prod"}},
+		{"name": "1st network"},
+	} {
+		d := schema.TestResourceDataRaw(t, network.Schema, raw)
+		require.NoError(t, network.Create(d, config))
+		ids = append(ids, d.Id())
+	}
+
+	var b strings.Builder
+	err := generateConfig(config.Context(), &b, config, NewSDKProvider().ResourcesMap, fakeFolderID, []string{"vpc_network"})
+	require.NoError(t, err)
+
+	expected := `import {
+  to = yandex_vpc_network.r_1st_network
+  id = "` + ids[1] + `"
+}
+
+resource "yandex_vpc_network" "r_1st_network" {
+  folder_id = "fake-folder-id"
+  name      = "1st network"
+}
+
+import {
+  to = yandex_vpc_network.tf-network-a
+  id = "` + ids[0] + `"
+}
+
+resource "yandex_vpc_network" "tf-network-a" {
+  description = "uses $${var.name}"
+  folder_id   = "fake-folder-id"
+  labels = {
+    env = "prod"
+  }
+  name = "tf-network-a"
+}
+
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestExpandGenerateResourceTypes(t *testing.T) {
+	types, err := expandGenerateResourceTypes([]string{"vpc_subnet", " compute_instance", "yandex_vpc_subnet"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"yandex_compute_instance", "yandex_vpc_subnet"}, types)
+
+	types, err = expandGenerateResourceTypes(nil)
+	require.NoError(t, err)
+	assert.Equal(t, folderInventoryResourceTypes(), types)
+
+	_, err = expandGenerateResourceTypes([]string{"storage_bucket"})
+	assert.ErrorContains(t, err, `unsupported resource type "yandex_storage_bucket"`)
+}
+
+func TestWriteHCLBody(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"name":     {Type: schema.TypeString, Required: true},
+		"size":     {Type: schema.TypeInt, Optional: true},
+		"enabled":  {Type: schema.TypeBool, Optional: true},
+		"status":   {Type: schema.TypeString, Computed: true},
+		"password": {Type: schema.TypeString, Optional: true, Sensitive: true},
+		"old_name": {Type: schema.TypeString, Optional: true, Deprecated: "use name"},
+		"zones":    {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"spec": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cores":  {Type: schema.TypeInt, Optional: true},
+					"memory": {Type: schema.TypeFloat, Optional: true, ConflictsWith: []string{"spec.0.cores"}},
+					"ratio":  {Type: schema.TypeFloat, Optional: true},
+				},
+			},
+		},
+	}
+	values := map[string]interface{}{
+		"name":     "multi\nline \"name\"",
+		"size":     0,
+		"enabled":  true,
+		"status":   "RUNNING",
+		"password": "secret",
+		"old_name": "old",
+		"zones":    []interface{}{"ru-central1-a", "ru-central1-b"},
+		"spec": []interface{}{
+			map[string]interface{}{"cores": 2, "memory": 4.0, "ratio": 0.5},
+		},
+	}
+
+	var b strings.Builder
+	writeHCLBody(&b, 0, s, values)
+	assert.Equal(t, `enabled = true
+name    = "multi\nline \"name\""
+zones   = ["ru-central1-a", "ru-central1-b"]
+
+spec {
+  cores = 2
+  ratio = 0.5
+}
+`, b.String())
+}
@@ -45,7 +45,9 @@ type resourceIDParserFunc func(d *schema.ResourceData, config *Config) error
 
 func iamPolicyReadModifySet(ctx context.Context, updater ResourceIamUpdater, modify iamPolicyModifyFunc) error {
 	mutexKey := updater.GetMutexKey()
-	mutexKV.Lock(mutexKey)
+	if err := mutexKV.LockContext(ctx, mutexKey); err != nil {
+		return err
+	}
 	defer mutexKV.Unlock(mutexKey)
 
 	log.Printf("[DEBUG]: Retrieving access bindings for %s\n", updater.DescribeResource())
@@ -73,29 +75,3 @@ func iamPolicyReadModifySet(ctx context.Context, updater ResourceIamUpdater, mod
 
 	return nil
 }
-
-func iamPolicyReadModifyUpdate(ctx context.Context, updater ResourceIamUpdater, policyDelta *PolicyDelta) error {
-	mutexKey := updater.GetMutexKey()
-	mutexKV.Lock(mutexKey)
-	defer mutexKV.Unlock(mutexKey)
-
-	log.Printf("[DEBUG]: Retrieving access bindings for %s\n", updater.DescribeResource())
-
-	p, err := updater.GetResourceIamPolicy(ctx)
-	if err != nil {
-		return err
-	}
-
-	log.Printf("[DEBUG]: Retrieved access bindings for %s: %+v\n", updater.DescribeResource(), p)
-
-	log.Printf("[DEBUG]: Updating access bindings of %s with %+v\n", updater.DescribeResource(), policyDelta)
-
-	err = updater.UpdateResourceIamPolicy(ctx, policyDelta)
-	if err != nil {
-		return fmt.Errorf("Error updating access bindings of %s: %w", updater.DescribeResource(), err)
-	}
-
-	log.Printf("[DEBUG]: Updated access bindings for %s", updater.DescribeResource())
-
-	return nil
-}
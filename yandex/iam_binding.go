@@ -12,6 +12,8 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
 )
 
 var accessBindingSchema = map[string]*schema.Schema{
@@ -28,6 +30,7 @@ var accessBindingSchema = map[string]*schema.Schema{
 			ValidateFunc: validateIamMember,
 		},
 	},
+	"compare_before_set": iamCompareBeforeSetSchema,
 	// for test purposes, to compensate IAM operations delay
 	"sleep_after": {
 		Type:     schema.TypeInt,
@@ -155,8 +158,16 @@ func resourceAccessBindingUpdate(newUpdaterFunc newResourceIamUpdaterFunc) schem
 
 		bindings := getResourceIamBindings(d)
 		role := d.Get("role").(string)
+		compare := iamCompareBeforeSet(d, config)
+		oldMembers, _ := d.GetChange("members")
 
 		err = iamPolicyReadModifySet(ctx, updater, func(p *Policy) error {
+			if compare {
+				expected := membersToAccessBindings(role, oldMembers.(*schema.Set))
+				if err := common.CheckIAMBindingsUnchanged(updater.DescribeResource(), expected, common.IAMBindingsOfRole(role, p.Bindings)); err != nil {
+					return err
+				}
+			}
 			p.Bindings = removeRoleFromBindings(role, p.Bindings)
 			p.Bindings = append(p.Bindings, bindings...)
 			return nil
@@ -183,8 +194,14 @@ func resourceAccessBindingDelete(newUpdaterFunc newResourceIamUpdaterFunc) schem
 			return nil
 		}
 		role := binding[0].RoleId
+		compare := iamCompareBeforeSet(d, config)
 
 		err = iamPolicyReadModifySet(ctx, updater, func(p *Policy) error {
+			if compare {
+				if err := common.CheckIAMBindingsUnchanged(updater.DescribeResource(), binding, common.IAMBindingsOfRole(role, p.Bindings)); err != nil {
+					return err
+				}
+			}
 			p.Bindings = removeRoleFromBindings(role, p.Bindings)
 			return nil
 		})
@@ -232,9 +249,10 @@ func iamBindingImport(resourceIDParser resourceIDParserFunc) schema.StateContext
 
 // all bindings use same Role
 func getResourceIamBindings(d *schema.ResourceData) []*access.AccessBinding {
-	members := d.Get("members").(*schema.Set)
-	role := d.Get("role").(string)
+	return membersToAccessBindings(d.Get("role").(string), d.Get("members").(*schema.Set))
+}
 
+func membersToAccessBindings(role string, members *schema.Set) []*access.AccessBinding {
 	result := make([]*access.AccessBinding, members.Len())
 
 	for i, member := range convertStringSet(members) {
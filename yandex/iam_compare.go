@@ -0,0 +1,28 @@
+package yandex
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// iamCompareBeforeSetSchema is the attribute of the authoritative IAM resources that overrides
+// the provider iam_compare_before_set.
+var iamCompareBeforeSetSchema = &schema.Schema{
+	Type:     schema.TypeBool,
+	Optional: true,
+}
+
+// iamCompareBeforeSet reports whether the access bindings of the resource are compared before
+// they are replaced. The attribute of the resource takes precedence over the provider default.
+// The configuration is not available while the resource is deleted, the state is used then.
+func iamCompareBeforeSet(d *schema.ResourceData, config *Config) bool {
+	for _, raw := range []cty.Value{d.GetRawConfig(), d.GetRawState()} {
+		if raw.IsNull() || !raw.IsKnown() || !raw.Type().HasAttribute("compare_before_set") {
+			continue
+		}
+		if v := raw.GetAttr("compare_before_set"); !v.IsNull() && v.IsKnown() {
+			return v.True()
+		}
+	}
+	return config.IAMCompareBeforeSet
+}
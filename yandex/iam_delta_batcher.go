@@ -0,0 +1,175 @@
+package yandex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+)
+
+// iamDeltaBatchWindow is how long the access binding deltas of the same resource are collected
+// before they are sent in one UpdateAccessBindings request.
+const iamDeltaBatchWindow = 200 * time.Millisecond
+
+// iamDeltaBatchSize is the maximum number of deltas in one UpdateAccessBindings request.
+const iamDeltaBatchSize = 1000
+
+// iamDeltas coalesces the access binding deltas of the _iam_member resources applied concurrently.
+var iamDeltas = newIamDeltaBatcher(iamDeltaBatchWindow, iamDeltaBatchSize)
+
+// errIamDeltaRetry is sent to the requests of a failed batch, which are then retried one by one,
+// so that the error is reported by the resource that caused it.
+var errIamDeltaRetry = errors.New("batch of access binding deltas failed")
+
+type iamDeltaBatcher struct {
+	window    time.Duration
+	batchSize int
+
+	mu      sync.Mutex
+	pending map[string]*iamDeltaBatch
+}
+
+type iamDeltaBatch struct {
+	updater  ResourceIamUpdater
+	requests []*iamDeltaRequest
+}
+
+type iamDeltaRequest struct {
+	ctx    context.Context
+	deltas []*access.AccessBindingDelta
+	done   chan error
+}
+
+func newIamDeltaBatcher(window time.Duration, batchSize int) *iamDeltaBatcher {
+	return &iamDeltaBatcher{
+		window:    window,
+		batchSize: batchSize,
+		pending:   make(map[string]*iamDeltaBatch),
+	}
+}
+
+// update applies the policy delta to the access bindings of the resource of the updater. The deltas of
+// the calls with the same mutex key made within the batch window are merged into as few requests as the
+// batch size allows. The batch is sent with the base context, e.g. config.Context(), limited by the
+// latest deadline of its calls, so that a call giving up doesn't fail the others. A call whose context
+// is done before the batch is sent gives up without sending its deltas.
+func (b *iamDeltaBatcher) update(ctx context.Context, base context.Context, updater ResourceIamUpdater, policyDelta *PolicyDelta) error {
+	mutexKey := updater.GetMutexKey()
+	req := &iamDeltaRequest{ctx: ctx, deltas: policyDelta.Deltas, done: make(chan error, 1)}
+
+	b.mu.Lock()
+	batch, ok := b.pending[mutexKey]
+	if !ok {
+		batch = &iamDeltaBatch{updater: updater}
+		b.pending[mutexKey] = batch
+		time.AfterFunc(b.window, func() {
+			b.mu.Lock()
+			delete(b.pending, mutexKey)
+			b.mu.Unlock()
+
+			b.flush(base, mutexKey, batch)
+		})
+	}
+	batch.requests = append(batch.requests, req)
+	b.mu.Unlock()
+
+	var err error
+	select {
+	case err = <-req.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if err == errIamDeltaRetry {
+		if err := mutexKV.LockContext(ctx, mutexKey); err != nil {
+			return err
+		}
+		defer mutexKV.Unlock(mutexKey)
+		return b.send(ctx, updater, req.deltas)
+	}
+	return err
+}
+
+// flush sends the requests of the batch in chunks of at most batchSize deltas, so that every chunk is
+// applied atomically by a single UpdateAccessBindings request.
+func (b *iamDeltaBatcher) flush(base context.Context, mutexKey string, batch *iamDeltaBatch) {
+	var requests []*iamDeltaRequest
+	for _, req := range batch.requests {
+		if err := req.ctx.Err(); err != nil {
+			req.done <- err
+			continue
+		}
+		requests = append(requests, req)
+	}
+	if len(requests) == 0 {
+		return
+	}
+
+	ctx, cancel := batchContext(base, requests)
+	defer cancel()
+
+	if err := mutexKV.LockContext(ctx, mutexKey); err != nil {
+		for _, req := range requests {
+			req.done <- err
+		}
+		return
+	}
+	defer mutexKV.Unlock(mutexKey)
+
+	var chunk []*iamDeltaRequest
+	var deltas []*access.AccessBindingDelta
+	for i, req := range requests {
+		if len(chunk) > 0 && countBatches(len(deltas)+len(req.deltas), b.batchSize) > 1 {
+			b.sendChunk(ctx, batch.updater, chunk, deltas)
+			chunk, deltas = nil, nil
+		}
+		chunk = append(chunk, req)
+		deltas = append(deltas, req.deltas...)
+
+		if i == len(requests)-1 {
+			b.sendChunk(ctx, batch.updater, chunk, deltas)
+		}
+	}
+}
+
+// batchContext limits the base context by the latest deadline of the requests,
+// not at all if any of them has no deadline.
+func batchContext(base context.Context, requests []*iamDeltaRequest) (context.Context, context.CancelFunc) {
+	var latest time.Time
+	for _, req := range requests {
+		deadline, ok := req.ctx.Deadline()
+		if !ok {
+			return context.WithCancel(base)
+		}
+		if deadline.After(latest) {
+			latest = deadline
+		}
+	}
+	return context.WithDeadline(base, latest)
+}
+
+func (b *iamDeltaBatcher) sendChunk(ctx context.Context, updater ResourceIamUpdater, chunk []*iamDeltaRequest, deltas []*access.AccessBindingDelta) {
+	err := b.send(ctx, updater, deltas)
+	if err != nil && len(chunk) > 1 {
+		log.Printf("[DEBUG]: Retrying %d access binding updates of %s one by one: %v", len(chunk), updater.DescribeResource(), err)
+		err = errIamDeltaRetry
+	}
+	for _, req := range chunk {
+		req.done <- err
+	}
+}
+
+func (b *iamDeltaBatcher) send(ctx context.Context, updater ResourceIamUpdater, deltas []*access.AccessBindingDelta) error {
+	log.Printf("[DEBUG]: Updating access bindings of %s with %d deltas\n", updater.DescribeResource(), len(deltas))
+
+	err := updater.UpdateResourceIamPolicy(ctx, &PolicyDelta{Deltas: deltas})
+	if err != nil {
+		return fmt.Errorf("Error updating access bindings of %s: %w", updater.DescribeResource(), err)
+	}
+
+	log.Printf("[DEBUG]: Updated access bindings for %s", updater.DescribeResource())
+	return nil
+}
@@ -0,0 +1,175 @@
+package yandex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+)
+
+// fakeDeltaIamUpdater records the UpdateResourceIamPolicy calls and fails the ones
+// with a delta for the failing member.
+type fakeDeltaIamUpdater struct {
+	failingMember string
+
+	mu    sync.Mutex
+	calls [][]*access.AccessBindingDelta
+}
+
+func (u *fakeDeltaIamUpdater) GetResourceIamPolicy(context.Context) (*Policy, error) {
+	return &Policy{}, nil
+}
+
+func (u *fakeDeltaIamUpdater) SetResourceIamPolicy(context.Context, *Policy) error {
+	return nil
+}
+
+func (u *fakeDeltaIamUpdater) UpdateResourceIamPolicy(_ context.Context, policy *PolicyDelta) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.calls = append(u.calls, policy.Deltas)
+	for _, d := range policy.Deltas {
+		if canonicalMember(d.AccessBinding) == u.failingMember {
+			return errors.New("invalid member")
+		}
+	}
+	return nil
+}
+
+func (u *fakeDeltaIamUpdater) GetMutexKey() string {
+	return "iam-fake-resource"
+}
+
+func (u *fakeDeltaIamUpdater) GetResourceID() string {
+	return "fake-resource"
+}
+
+func (u *fakeDeltaIamUpdater) DescribeResource() string {
+	return "fake resource"
+}
+
+func applyMemberDeltas(b *iamDeltaBatcher, updater ResourceIamUpdater, count int) []error {
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = b.update(context.Background(), context.Background(), updater, &PolicyDelta{
+				Deltas: []*access.AccessBindingDelta{
+					{
+						Action:        access.AccessBindingAction_ADD,
+						AccessBinding: roleMemberToAccessBinding("viewer", fmt.Sprintf("userAccount:user%d", i)),
+					},
+				},
+			})
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestIamDeltaBatcher_coalescesDeltas(t *testing.T) {
+	updater := &fakeDeltaIamUpdater{}
+	errs := applyMemberDeltas(newIamDeltaBatcher(time.Second, 1000), updater, 300)
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Len(t, updater.calls, 1, "deltas of the same resource must be sent in one request")
+	assert.Len(t, updater.calls[0], 300)
+}
+
+func TestIamDeltaBatcher_respectsBatchSize(t *testing.T) {
+	updater := &fakeDeltaIamUpdater{}
+	errs := applyMemberDeltas(newIamDeltaBatcher(time.Second, 100), updater, 250)
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Len(t, updater.calls, 3)
+	assert.Len(t, updater.calls[0], 100)
+	assert.Len(t, updater.calls[1], 100)
+	assert.Len(t, updater.calls[2], 50)
+}
+
+func TestIamDeltaBatcher_attributesErrors(t *testing.T) {
+	updater := &fakeDeltaIamUpdater{failingMember: "userAccount:user7"}
+	errs := applyMemberDeltas(newIamDeltaBatcher(time.Second, 1000), updater, 20)
+
+	for i, err := range errs {
+		if i == 7 {
+			assert.ErrorContains(t, err, "invalid member")
+		} else {
+			assert.NoError(t, err, "the error must be reported by the failing member only")
+		}
+	}
+	assert.Len(t, updater.calls, 21, "a failed batch must be retried one delta at a time")
+}
+
+func TestIamDeltaBatcher_leaderCancelled(t *testing.T) {
+	updater := &fakeDeltaIamUpdater{}
+	b := newIamDeltaBatcher(200*time.Millisecond, 1000)
+	memberDelta := func(member string) *PolicyDelta {
+		return &PolicyDelta{Deltas: []*access.AccessBindingDelta{
+			{
+				Action:        access.AccessBindingAction_ADD,
+				AccessBinding: roleMemberToAccessBinding("viewer", member),
+			},
+		}}
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		leaderErr <- b.update(leaderCtx, context.Background(), updater, memberDelta("userAccount:leader"))
+	}()
+	require.Eventually(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return len(b.pending) == 1
+	}, time.Second, time.Millisecond, "the leader must start the batch")
+
+	followerErrs := make(chan error, 2)
+	for _, member := range []string{"userAccount:follower1", "userAccount:follower2"} {
+		go func(member string) {
+			followerErrs <- b.update(context.Background(), context.Background(), updater, memberDelta(member))
+		}(member)
+	}
+	cancel()
+
+	assert.ErrorIs(t, <-leaderErr, context.Canceled, "the leader must give up with its context")
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, <-followerErrs, "the followers must not fail with the context of the leader")
+	}
+
+	require.Len(t, updater.calls, 1)
+	var members []string
+	for _, d := range updater.calls[0] {
+		members = append(members, canonicalMember(d.AccessBinding))
+	}
+	assert.ElementsMatch(t, []string{"userAccount:follower1", "userAccount:follower2"}, members,
+		"the deltas of the cancelled leader must not be sent")
+}
+
+func TestIamDeltaBatcher_followerCancelled(t *testing.T) {
+	updater := &fakeDeltaIamUpdater{}
+	b := newIamDeltaBatcher(time.Hour, 1000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := b.update(ctx, context.Background(), updater, &PolicyDelta{Deltas: []*access.AccessBindingDelta{
+		{
+			Action:        access.AccessBindingAction_ADD,
+			AccessBinding: roleMemberToAccessBinding("viewer", "userAccount:user"),
+		},
+	}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "a call must not wait for the batch beyond its own context")
+}
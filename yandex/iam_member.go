@@ -13,6 +13,8 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
 )
 
 var IamMemberBaseSchema = map[string]*schema.Schema{
@@ -36,9 +38,8 @@ var IamMemberBaseSchema = map[string]*schema.Schema{
 }
 
 func validateIamMember(i interface{}, k string) (s []string, es []error) {
-	chunks := strings.SplitN(i.(string), ":", 2)
-	if len(chunks) == 1 || chunks[0] == "" || chunks[1] == "" {
-		es = append(es, fmt.Errorf("expect 'member' value should be in TYPE:ID format, got '%v'", i.(string)))
+	if err := common.ValidateIAMMember(i.(string)); err != nil {
+		es = append(es, err)
 	}
 	return
 }
@@ -112,7 +113,7 @@ func resourceIamMemberCreate(newUpdaterFunc newResourceIamUpdaterFunc) schema.Cr
 		}
 
 		member := getResourceIamMember(d)
-		err = iamPolicyReadModifyUpdate(ctx, updater, &PolicyDelta{
+		err = iamDeltas.update(ctx, config.Context(), updater, &PolicyDelta{
 			Deltas: []*access.AccessBindingDelta{
 				{
 					Action:        access.AccessBindingAction_ADD,
@@ -196,7 +197,7 @@ func resourceIamMemberDelete(newUpdaterFunc newResourceIamUpdaterFunc) schema.De
 
 		member := getResourceIamMember(d)
 
-		err = iamPolicyReadModifyUpdate(ctx, updater, &PolicyDelta{
+		err = iamDeltas.update(ctx, config.Context(), updater, &PolicyDelta{
 			Deltas: []*access.AccessBindingDelta{
 				{
 					Action:        access.AccessBindingAction_REMOVE,
@@ -10,6 +10,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"google.golang.org/grpc/codes"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
 )
 
 var IamPolicyBaseSchema = map[string]*schema.Schema{
@@ -19,6 +21,7 @@ var IamPolicyBaseSchema = map[string]*schema.Schema{
 		DiffSuppressFunc: shouldSuppressDiffForPolicies,
 		ValidateFunc:     validateIamPolicy,
 	},
+	"compare_before_set": iamCompareBeforeSetSchema,
 }
 
 func iamPolicyImport(resourceIDParser resourceIDParserFunc) schema.StateContextFunc {
@@ -60,7 +63,8 @@ func resourceIamPolicyCreate(newUpdaterFunc newResourceIamUpdaterFunc) schema.Cr
 			return diag.FromErr(err)
 		}
 
-		if err := setIamPolicyData(ctx, d, updater); err != nil {
+		// There are no access bindings read to plan the creation, so there is nothing to compare with.
+		if err := setIamPolicyData(ctx, d, updater, false); err != nil {
 			return diag.FromErr(err)
 		}
 
@@ -102,7 +106,7 @@ func resourceIamPolicyUpdate(newUpdaterFunc newResourceIamUpdaterFunc) schema.Up
 		}
 
 		if d.HasChange("policy_data") {
-			if err := setIamPolicyData(ctx, d, updater); err != nil {
+			if err := setIamPolicyData(ctx, d, updater, iamCompareBeforeSet(d, config)); err != nil {
 				return diag.FromErr(err)
 			}
 		}
@@ -119,20 +123,52 @@ func resourceIamPolicyDelete(newUpdaterFunc newResourceIamUpdaterFunc) schema.De
 			return diag.FromErr(err)
 		}
 
+		if iamCompareBeforeSet(d, config) {
+			expected, err := unmarshalIamPolicy(d.Get("policy_data").(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			err = iamPolicyReadModifySet(ctx, updater, func(p *Policy) error {
+				if err := common.CheckIAMBindingsUnchanged(updater.DescribeResource(), expected.Bindings, p.Bindings); err != nil {
+					return err
+				}
+				p.Bindings = nil
+				return nil
+			})
+			return diag.FromErr(err)
+		}
+
 		// Set an empty policy to delete the attached policy.
 		err = updater.SetResourceIamPolicy(ctx, &Policy{})
 		return diag.FromErr(err)
 	}
 }
 
-func setIamPolicyData(ctx context.Context, d *schema.ResourceData, updater ResourceIamUpdater) error {
-	policy, err := unmarshalIamPolicy(d.Get("policy_data").(string))
+// setIamPolicyData replaces the access bindings of the resource with policy_data. If compare is set,
+// the current access bindings are compared with the previous policy_data, i.e. the ones read to plan
+// the change, right before they are replaced.
+func setIamPolicyData(ctx context.Context, d *schema.ResourceData, updater ResourceIamUpdater, compare bool) error {
+	old, new := d.GetChange("policy_data")
+	policy, err := unmarshalIamPolicy(new.(string))
 	if err != nil {
 		return fmt.Errorf("'policy_data' is not valid for %s: %w", updater.DescribeResource(), err)
 	}
 
-	err = updater.SetResourceIamPolicy(ctx, policy)
-	return err
+	if !compare {
+		return updater.SetResourceIamPolicy(ctx, policy)
+	}
+
+	expected, err := unmarshalIamPolicy(old.(string))
+	if err != nil {
+		return err
+	}
+	return iamPolicyReadModifySet(ctx, updater, func(p *Policy) error {
+		if err := common.CheckIAMBindingsUnchanged(updater.DescribeResource(), expected.Bindings, p.Bindings); err != nil {
+			return err
+		}
+		p.Bindings = policy.Bindings
+		return nil
+	})
 }
 
 func marshalIamPolicy(policy *Policy) string {
@@ -0,0 +1,114 @@
+package yandex
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// iamResource describes a ResourceIamUpdater, from which the IAM resources of the resource type are built.
+type iamResource struct {
+	schema     map[string]*schema.Schema
+	newUpdater newResourceIamUpdaterFunc
+	parseID    resourceIDParserFunc
+	timeout    time.Duration
+}
+
+// iamResources are the IAM updaters by the prefix of the resource type of their IAM resources.
+var iamResources = map[string]iamResource{
+	"yandex_container_registry": {
+		IamContainerRegistrySchema, newContainerRegistryIamUpdater, containerRegistryIDParseFunc, yandexIAMContainerRegistryDefaultTimeout,
+	},
+	"yandex_container_repository": {
+		IamContainerRepositorySchema, newContainerRepositoryIamUpdater, containerRepositoryIDParseFunc, yandexIAMContainerRepositoryDefaultTimeout,
+	},
+	"yandex_function": {
+		IamFunctionSchema, newFunctionIamUpdater, functionIDParseFunc, yandexIAMFunctionDefaultTimeout,
+	},
+	"yandex_iam_service_account": {
+		IamServiceAccountSchema, newServiceAccountIamUpdater, serviceAccountIDParseFunc, yandexIAMServiceAccountDefaultTimeout,
+	},
+	"yandex_kms_asymmetric_encryption_key": {
+		IamKMSAsymmetricEncryptionKeySchema, newKMSAsymmetricEncryptionKeyIamUpdater, kmsAsymmetricEncryptionKeyIDParseFunc, yandexIAMKMSDefaultTimeout,
+	},
+	"yandex_kms_asymmetric_signature_key": {
+		IamKMSAsymmetricSignatureKeySchema, newKMSAsymmetricSignatureKeyIamUpdater, kmsAsymmetricSignatureKeyIDParseFunc, yandexIAMKMSDefaultTimeout,
+	},
+	"yandex_kms_symmetric_key": {
+		IamKMSSymmetricKeySchema, newKMSSymmetricKeyIamUpdater, kmsSymmetricKeyIDParseFunc, yandexIAMKMSDefaultTimeout,
+	},
+	"yandex_lockbox_secret": {
+		IamLockboxSecretSchema, newLockboxSecretIamUpdater, LockboxSecretIDParseFunc, yandexIAMLockboxDefaultTimeout,
+	},
+	"yandex_organizationmanager_group": {
+		IamGroupSchema, newGroupIamUpdater, groupIDParseFunc, yandexOrganizationManagerGroupDefaultTimeout,
+	},
+	"yandex_organizationmanager_organization": {
+		IamOrganizationSchema, newOrganizationIamUpdater, organizationIDParseFunc, yandexOrganizationManagerOrganizationDefaultTimeout,
+	},
+	"yandex_resourcemanager_cloud": {
+		IamCloudSchema, newCloudIamUpdater, cloudIDParseFunc, yandexResourceManagerCloudDefaultTimeout,
+	},
+	"yandex_resourcemanager_folder": {
+		IamFolderSchema, newFolderIamUpdater, folderIDParseFunc, yandexResourceManagerFolderDefaultTimeout,
+	},
+	"yandex_serverless_container": {
+		IamServerlessContainerSchema, newServerlessContainerIamUpdater, serverlessContainerIDParseFunc, yandexIAMServerlessContainerDefaultTimeout,
+	},
+	"yandex_ydb_database": {
+		IamYDBDatabaseSchema, newYDBDatabaseIamUpdater, ydbDatabaseIDParseFunc, yandexIAMYDBDefaultTimeout,
+	},
+}
+
+func (r iamResource) member() *schema.Resource {
+	return resourceIamMember(
+		r.schema,
+		r.newUpdater,
+		WithTimeout(
+			&schema.ResourceTimeout{
+				Default: schema.DefaultTimeout(r.timeout),
+			}),
+		WithImporter(
+			&schema.ResourceImporter{
+				StateContext: iamMemberImport(r.parseID),
+			}),
+	)
+}
+
+func (r iamResource) policy() *schema.Resource {
+	return resourceIamPolicy(
+		r.schema,
+		r.newUpdater,
+		WithTimeout(
+			&schema.ResourceTimeout{
+				Default: schema.DefaultTimeout(r.timeout),
+			}),
+		WithImporter(
+			&schema.ResourceImporter{
+				StateContext: iamPolicyImport(r.parseID),
+			}),
+	)
+}
+
+// updaterByID returns the IAM updater of the resource with the ID, as its import would.
+func (r iamResource) updaterByID(id string, config *Config) (ResourceIamUpdater, error) {
+	d := (&schema.Resource{Schema: r.schema}).Data(nil)
+	d.SetId(id)
+	if err := r.parseID(d, config); err != nil {
+		return nil, err
+	}
+	return r.newUpdater(d, config)
+}
+
+// addIamResources adds the _iam_member and _iam_policy resources of every IAM updater,
+// unless the resource map already has them.
+func addIamResources(resources map[string]*schema.Resource) {
+	for prefix, r := range iamResources {
+		if _, ok := resources[prefix+"_iam_member"]; !ok {
+			resources[prefix+"_iam_member"] = r.member()
+		}
+		if _, ok := resources[prefix+"_iam_policy"]; !ok {
+			resources[prefix+"_iam_policy"] = r.policy()
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIamResources_registered(t *testing.T) {
+	for prefix := range iamResources {
+		for _, suffix := range []string{"_iam_binding", "_iam_member", "_iam_policy"} {
+			if prefix == "yandex_organizationmanager_group" && suffix == "_iam_binding" {
+				continue
+			}
+			r, ok := testAccProvider.ResourcesMap[prefix+suffix]
+			require.True(t, ok, "%s must be registered", prefix+suffix)
+			assert.NotNil(t, r.Importer, "%s must be importable", prefix+suffix)
+		}
+	}
+
+	member := testAccProvider.ResourcesMap["yandex_kms_symmetric_key_iam_member"]
+	assert.Contains(t, member.Schema, "symmetric_key_id")
+	assert.Contains(t, member.Schema, "role")
+	assert.Contains(t, member.Schema, "member")
+
+	policy := testAccProvider.ResourcesMap["yandex_lockbox_secret_iam_policy"]
+	assert.Contains(t, policy.Schema, "secret_id")
+	assert.Contains(t, policy.Schema, "policy_data")
+}
@@ -0,0 +1,59 @@
+package yandex
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/common/mutexkv"
+)
+
+func lockBackendSchema() *schema.Schema {
+	attributes := map[string]*schema.Schema{
+		"type": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: common.Descriptions["lock_backend.type"],
+		},
+	}
+	for _, name := range []string{"path", "bucket", "prefix", "endpoint", "table", "timeout", "lease_duration", "holder"} {
+		attributes[name] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: common.Descriptions["lock_backend."+name],
+		}
+	}
+
+	// MaxItems is not set to keep the schema identical to the framework provider one,
+	// the limit is checked in expandLockBackend.
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Resource{Schema: attributes},
+		Description: common.Descriptions["lock_backend"],
+	}
+}
+
+// expandLockBackend returns nil if there is no lock_backend block, so that the locks are held in memory.
+func expandLockBackend(d *schema.ResourceData) (*mutexkv.Config, error) {
+	v := d.Get("lock_backend").([]interface{})
+	if len(v) == 0 || v[0] == nil {
+		return nil, nil
+	}
+	if len(v) > 1 {
+		return nil, fmt.Errorf("at most one lock_backend block is allowed, got %d", len(v))
+	}
+	lb := v[0].(map[string]interface{})
+	return &mutexkv.Config{
+		Type:          lb["type"].(string),
+		Path:          lb["path"].(string),
+		Bucket:        lb["bucket"].(string),
+		Prefix:        lb["prefix"].(string),
+		Endpoint:      lb["endpoint"].(string),
+		Table:         lb["table"].(string),
+		Timeout:       lb["timeout"].(string),
+		LeaseDuration: lb["lease_duration"].(string),
+		Holder:        lb["holder"].(string),
+	}, nil
+}
@@ -74,6 +74,21 @@ func expandMySQLUsers(users []*mysql.User, d *schema.ResourceData) ([]*mysql.Use
 	return result, nil
 }
 
+// expandMySQLUsersWriteOnlyPasswords sets the write-only passwords of the users expanded by expandMySQLUsers.
+// They are only set for the API requests, so that they never get into the state.
+func expandMySQLUsersWriteOnlyPasswords(d *schema.ResourceData, users []*mysql.UserSpec) error {
+	for i, user := range users {
+		password, err := getWriteOnlyString(d, fmt.Sprintf("user.%d.password_wo", i))
+		if err != nil {
+			return err
+		}
+		if password != "" {
+			user.Password = password
+		}
+	}
+	return nil
+}
+
 func expandMysqlUser(u map[string]interface{}, existsUser *mysql.User) (user *mysql.UserSpec, isDiff bool, err error) {
 	user = &mysql.UserSpec{}
 
@@ -980,6 +995,17 @@ func listMysqlUsers(ctx context.Context, config *Config, id string) ([]*mysql.Us
 	return users, nil
 }
 
+func flattenMysqlUserConnectionManager(cm *mysql.ConnectionManager) []interface{} {
+	if cm == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"connection_id": cm.ConnectionId,
+		},
+	}
+}
+
 func flattenMysqlUsers(us []*mysql.User, passwords map[string]string) ([]interface{}, error) {
 	out := make([]interface{}, 0)
 
@@ -63,6 +63,8 @@ func TestFlattenMySQLSettings_5_7(t *testing.T) {
 		"sql_mode":                               "NO_BACKSLASH_ESCAPES,STRICT_ALL_TABLES",
 		"innodb_print_all_deadlocks":             "true",
 		"log_slow_rate_type":                     "0",
+		"audit_log_policy":                       "0",
+		"innodb_change_buffering":                "0",
 	}
 
 	if !reflect.DeepEqual(ethalon, m) {
@@ -106,6 +108,8 @@ func TestFlattenMySQLSettings_8_0(t *testing.T) {
 		"sql_mode":                               "NO_BACKSLASH_ESCAPES,STRICT_ALL_TABLES",
 		"innodb_print_all_deadlocks":             "true",
 		"log_slow_rate_type":                     "0",
+		"audit_log_policy":                       "0",
+		"innodb_change_buffering":                "0",
 	}
 
 	if !reflect.DeepEqual(ethalon, m) {
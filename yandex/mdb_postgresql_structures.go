@@ -333,6 +333,17 @@ func pgUsersPasswords(users []*postgresql.UserSpec) map[string]string {
 	return out
 }
 
+func flattenPGUserConnectionManager(cm *postgresql.ConnectionManager) []interface{} {
+	if cm == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"connection_id": cm.ConnectionId,
+		},
+	}
+}
+
 func pgUserPermissionHash(v interface{}) int {
 	m := v.(map[string]interface{})
 
@@ -1055,6 +1066,9 @@ func pgUserForCreate(d *schema.ResourceData, currUsers []*postgresql.User) (user
 			if err != nil {
 				return nil, err
 			}
+			if err := expandPGUserWriteOnlyPassword(d, user, fmt.Sprintf("user.%v.", i)); err != nil {
+				return nil, err
+			}
 			user.Grants = make([]string, 0)
 			user.Permissions = make([]*postgresql.Permission, 0)
 			usersForCreate = append(usersForCreate, user)
@@ -1064,6 +1078,19 @@ func pgUserForCreate(d *schema.ResourceData, currUsers []*postgresql.User) (user
 	return usersForCreate, nil
 }
 
+// expandPGUserWriteOnlyPassword sets the write-only password of the user at the path, e.g. "user.3.".
+// It is only set for the API requests, so that it never gets into the state.
+func expandPGUserWriteOnlyPassword(d *schema.ResourceData, user *postgresql.UserSpec, path string) error {
+	password, err := getWriteOnlyString(d, path+"password_wo")
+	if err != nil {
+		return err
+	}
+	if password != "" {
+		user.Password = password
+	}
+	return nil
+}
+
 // expandPGUserNew expand to new object from schema.ResourceData
 // path like "user.3."
 func expandPGUserNew(d *schema.ResourceData, path string) (*postgresql.UserSpec, error) {
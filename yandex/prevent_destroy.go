@@ -0,0 +1,68 @@
+package yandex
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+// preventDestroyLabelProps are the attributes whose values are matched against the labels
+// listed in the provider prevent_destroy_types, the first one present in the schema is used.
+var preventDestroyLabelProps = []string{allLabelsPropName, "labels", "tags"}
+
+// withPreventDestroy makes the Delete of a resource fail if the resource type or one of its
+// labels is listed in the provider prevent_destroy_types.
+func withPreventDestroy(resourceType string, r *schema.Resource) *schema.Resource {
+	labelsProp := ""
+	for _, prop := range preventDestroyLabelProps {
+		if s, ok := r.Schema[prop]; ok && s.Type == schema.TypeMap {
+			labelsProp = prop
+			break
+		}
+	}
+
+	check := func(d *schema.ResourceData, meta interface{}) error {
+		config, ok := meta.(*Config)
+		if !ok || config == nil || config.PreventDestroy.IsEmpty() {
+			return nil
+		}
+
+		var labels map[string]string
+		if labelsProp != "" {
+			labels, _ = expandLabels(d.Get(labelsProp))
+		}
+		if entry, ok := config.PreventDestroy.Match(resourceType, labels); ok {
+			return common.PreventDestroyError(resourceType, d.Id(), entry)
+		}
+		return nil
+	}
+
+	if r.Delete != nil {
+		del := r.Delete
+		r.Delete = func(d *schema.ResourceData, meta interface{}) error {
+			if err := check(d, meta); err != nil {
+				return err
+			}
+			return del(d, meta)
+		}
+	}
+
+	wrapContext := func(f crudContextFunc) crudContextFunc {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := check(d, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return f(ctx, d, meta)
+		}
+	}
+	r.DeleteContext = wrapContext(r.DeleteContext)
+	r.DeleteWithoutTimeout = wrapContext(r.DeleteWithoutTimeout)
+
+	return r
+}
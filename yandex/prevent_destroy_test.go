@@ -0,0 +1,53 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+)
+
+func TestWithPreventDestroy_offline(t *testing.T) {
+	config, server := newFakeCloudConfig(t)
+	config.DefaultLabels = map[string]string{"protected": "true"}
+	r := withPreventDestroy("yandex_vpc_network", withDefaultLabels(resourceYandexVPCNetwork()))
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "tf-network",
+	})
+	require.NoError(t, r.Create(d, config))
+	id := d.Id()
+
+	rules, err := common.ParsePreventDestroyRules([]string{"protected=true"})
+	require.NoError(t, err)
+	config.PreventDestroy = rules
+	err = r.Delete(d, config)
+	require.Error(t, err, "a network with a default protected label must not be deleted")
+	assert.Contains(t, err.Error(), `"protected=true"`)
+	assert.NotNil(t, server.Get(id))
+
+	rules, err = common.ParsePreventDestroyRules([]string{"yandex_vpc_network"})
+	require.NoError(t, err)
+	config.PreventDestroy = rules
+	err = r.Delete(d, config)
+	require.Error(t, err, "a network must not be deleted when its type is listed")
+	assert.Contains(t, err.Error(), `"yandex_vpc_network"`)
+	assert.NotNil(t, server.Get(id))
+
+	rules, err = common.ParsePreventDestroyRules([]string{"protected=false", "protected=true"})
+	require.NoError(t, err)
+	config.PreventDestroy = rules
+	err = r.Delete(d, config)
+	require.Error(t, err, "a network must not be deleted when its label value is one of the listed ones")
+	assert.Contains(t, err.Error(), `"protected=true"`)
+	assert.NotNil(t, server.Get(id))
+
+	rules, err = common.ParsePreventDestroyRules([]string{"yandex_vpc_subnet", "protected=false"})
+	require.NoError(t, err)
+	config.PreventDestroy = rules
+	require.NoError(t, r.Delete(d, config))
+	assert.Nil(t, server.Get(id))
+}
@@ -6,15 +6,12 @@ import (
 	"fmt"
 	"github.com/yandex-cloud/terraform-provider-yandex/common/mutexkv"
 	"os"
-	"strconv"
-	"strings"
 
 	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/common/client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
-	"github.com/yandex-cloud/terraform-provider-yandex/version"
 )
 
 // Global MutexKV
@@ -131,6 +128,32 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 				Optional:    true,
 				Description: common.Descriptions["profile"],
 			},
+			"impersonate_service_account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: common.Descriptions["impersonate_service_account_id"],
+			},
+			"default_labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: common.Descriptions["default_labels"],
+			},
+			"prevent_destroy_types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: common.Descriptions["prevent_destroy_types"],
+			},
+			"iam_compare_before_set": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: common.Descriptions["iam_compare_before_set"],
+			},
+			"retry_policy":      retryPolicySchema(),
+			"rate_limit":        rateLimitSchema(),
+			"workload_identity": workloadIdentitySchema(),
+			"lock_backend":      lockBackendSchema(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -161,9 +184,11 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 			"yandex_compute_snapshot_schedule":                        dataSourceYandexComputeSnapshotSchedule(),
 			"yandex_dataproc_cluster":                                 dataSourceYandexDataprocCluster(),
 			"yandex_dns_zone":                                         dataSourceYandexDnsZone(),
+			"yandex_folder_inventory":                                 dataSourceYandexFolderInventory(),
 			"yandex_function":                                         dataSourceYandexFunction(),
 			"yandex_function_scaling_policy":                          dataSourceYandexFunctionScalingPolicy(),
 			"yandex_function_trigger":                                 dataSourceYandexFunctionTrigger(),
+			"yandex_iam_access_bindings":                              dataSourceYandexIAMAccessBindings(),
 			"yandex_iam_policy":                                       dataSourceYandexIAMPolicy(),
 			"yandex_iam_role":                                         dataSourceYandexIAMRole(),
 			"yandex_iam_service_account":                              dataSourceYandexIAMServiceAccount(),
@@ -201,6 +226,7 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 			"yandex_mdb_sqlserver_cluster":                            dataSourceYandexMDBSQLServerCluster(),
 			"yandex_monitoring_dashboard":                             dataSourceYandexMonitoringDashboard(),
 			"yandex_message_queue":                                    dataSourceYandexMessageQueue(),
+			"yandex_operation":                                        dataSourceYandexOperation(),
 			"yandex_organizationmanager_group":                        dataSourceYandexOrganizationManagerGroup(),
 			"yandex_organizationmanager_saml_federation":              dataSourceYandexOrganizationManagerSamlFederation(),
 			"yandex_organizationmanager_saml_federation_user_account": dataSourceYandexOrganizationManagerSamlFederationUserAccount(),
@@ -254,6 +280,7 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 			"yandex_function_iam_binding":                             resourceYandexFunctionIAMBinding(),
 			"yandex_function_scaling_policy":                          resourceYandexFunctionScalingPolicy(),
 			"yandex_function_trigger":                                 resourceYandexFunctionTrigger(),
+			"yandex_grpc_resource":                                    resourceYandexGrpcResource(),
 			"yandex_iam_service_account":                              resourceYandexIAMServiceAccount(),
 			"yandex_iam_service_account_api_key":                      resourceYandexIAMServiceAccountAPIKey(),
 			"yandex_iam_service_account_iam_binding":                  resourceYandexIAMServiceAccountIAMBinding(),
@@ -315,8 +342,6 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 			"yandex_resourcemanager_folder_iam_policy":                resourceYandexResourceManagerFolderIAMPolicy(),
 			"yandex_serverless_container":                             resourceYandexServerlessContainer(),
 			"yandex_serverless_container_iam_binding":                 resourceYandexServerlessContainerIAMBinding(),
-			"yandex_storage_bucket":                                   resourceYandexStorageBucket(),
-			"yandex_storage_object":                                   resourceYandexStorageObject(),
 			"yandex_vpc_address":                                      resourceYandexVPCAddress(),
 			"yandex_vpc_default_security_group":                       resourceYandexVPCDefaultSecurityGroup(),
 			"yandex_vpc_gateway":                                      resourceYandexVPCGateway(),
@@ -334,6 +359,14 @@ func sdkProvider(emptyFolder bool) *schema.Provider {
 			"yandex_ydb_table_index":                                  resourceYandexYDBTableIndex(),
 		},
 	}
+	addIamResources(provider.ResourcesMap)
+
+	for name, r := range provider.ResourcesMap {
+		withTracing(name, withPreventDestroy(name, withDefaultLabels(r)))
+	}
+	for name, r := range provider.DataSourcesMap {
+		withTracing(name, r)
+	}
 
 	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		return providerConfigure(ctx, d, provider, emptyFolder, false)
@@ -360,79 +393,84 @@ func withALBVirtualHostID(r *schema.Resource) *schema.Resource {
 
 func wrapParseVirtualHostID(f crudFunc) crudFunc {
 	return func(d *schema.ResourceData, meta interface{}) error {
-		attrs := strings.Split(d.Id(), "/")
-		if len(attrs) < 2 {
-			return fmt.Errorf("error reading virtual_host, wrong id: %q", d.Id())
+		httpRouterID, name, err := common.ParseVirtualHostID(d.Id())
+		if err != nil {
+			return err
 		}
-		if err := d.Set("http_router_id", attrs[0]); err != nil {
+		if err := d.Set("http_router_id", httpRouterID); err != nil {
 			return err
 		}
-		if err := d.Set("name", attrs[1]); err != nil {
+		if err := d.Set("name", name); err != nil {
 			return err
 		}
 		return f(d, meta)
 	}
 }
 
-func setToDefaultIfNeeded(field string, osEnvName string, defaultVal string) string {
-	if len(field) != 0 {
-		return field
-	}
-	field = os.Getenv(osEnvName)
-	if len(field) == 0 {
-		field = defaultVal
+// testConfig is used to avoid using StopContext duo to tests are run in parallel and context is cancelled randomly in tests
+// there is same following issue https://github.com/hashicorp/terraform-plugin-sdk/issues/966
+func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Provider, emptyFolder bool, testConfig bool) (interface{}, diag.Diagnostics) {
+	settings := client.Settings{
+		Endpoint:                       d.Get("endpoint").(string),
+		FolderID:                       d.Get("folder_id").(string),
+		CloudID:                        d.Get("cloud_id").(string),
+		OrganizationID:                 d.Get("organization_id").(string),
+		Region:                         d.Get("region_id").(string),
+		Zone:                           d.Get("zone").(string),
+		Token:                          d.Get("token").(string),
+		ServiceAccountKeyFileOrContent: d.Get("service_account_key_file").(string),
+		StorageEndpoint:                d.Get("storage_endpoint").(string),
+		StorageAccessKey:               d.Get("storage_access_key").(string),
+		StorageSecretKey:               d.Get("storage_secret_key").(string),
+		YMQEndpoint:                    d.Get("ymq_endpoint").(string),
+		YMQAccessKey:                   d.Get("ymq_access_key").(string),
+		YMQSecretKey:                   d.Get("ymq_secret_key").(string),
+		Plaintext:                      d.Get("plaintext").(bool),
+		Insecure:                       d.Get("insecure").(bool),
+		MaxRetries:                     d.Get("max_retries").(int),
+		SharedCredentialsFile:          d.Get("shared_credentials_file").(string),
+		Profile:                        d.Get("profile").(string),
+		ImpersonateServiceAccountID:    d.Get("impersonate_service_account_id").(string),
 	}
-	return field
-}
+	// Settings are taken from the provider attributes first, then from the environment variables,
+	// then from the shared credentials profile.
+	settings.SetDefaults()
 
-func setToDefaultBoolIfNeeded(osEnvName string, defaultVal bool) bool {
-	field := os.Getenv(osEnvName)
-	v, err := strconv.ParseBool(field)
+	workloadIdentity, err := expandWorkloadIdentity(d)
 	if err != nil {
-		return v
-	} else {
-		return defaultVal
+		return nil, diag.FromErr(err)
 	}
-}
-
-// testConfig is used to avoid using StopContext duo to tests are run in parallel and context is cancelled randomly in tests
-// there is same following issue https://github.com/hashicorp/terraform-plugin-sdk/issues/966
-func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Provider, emptyFolder bool, testConfig bool) (interface{}, diag.Diagnostics) {
-	config := Config{
-		Endpoint:                       setToDefaultIfNeeded(d.Get("endpoint").(string), "YC_ENDPOINT", common.DefaultEndpoint),
-		FolderID:                       setToDefaultIfNeeded(d.Get("folder_id").(string), "YC_FOLDER_ID", ""),
-		CloudID:                        setToDefaultIfNeeded(d.Get("cloud_id").(string), "YC_CLOUD_ID", ""),
-		OrganizationID:                 setToDefaultIfNeeded(d.Get("organization_id").(string), "YC_ORGANIZATION_ID", ""),
-		Region:                         setToDefaultIfNeeded(d.Get("region_id").(string), "YC_REGION", common.DefaultRegion),
-		Zone:                           setToDefaultIfNeeded(d.Get("zone").(string), "YC_ZONE", ""),
-		Token:                          setToDefaultIfNeeded(d.Get("token").(string), "YC_TOKEN", ""),
-		ServiceAccountKeyFileOrContent: setToDefaultIfNeeded(d.Get("service_account_key_file").(string), "YC_SERVICE_ACCOUNT_KEY_FILE", ""),
-		StorageEndpoint:                setToDefaultIfNeeded(d.Get("storage_endpoint").(string), "YC_STORAGE_ENDPOINT_URL", common.DefaultStorageEndpoint),
-		StorageAccessKey:               setToDefaultIfNeeded(d.Get("storage_access_key").(string), "YC_STORAGE_ACCESS_KEY", ""),
-		StorageSecretKey:               setToDefaultIfNeeded(d.Get("storage_secret_key").(string), "YC_STORAGE_SECRET_KEY", ""),
-		YMQEndpoint:                    setToDefaultIfNeeded(d.Get("ymq_endpoint").(string), "YC_MESSAGE_QUEUE_ENDPOINT", common.DefaultYMQEndpoint),
-		YMQAccessKey:                   setToDefaultIfNeeded(d.Get("ymq_access_key").(string), "YC_MESSAGE_QUEUE_ACCESS_KEY", ""),
-		YMQSecretKey:                   setToDefaultIfNeeded(d.Get("ymq_secret_key").(string), "YC_MESSAGE_QUEUE_SECRET_KEY", ""),
+	settings.WorkloadIdentity = workloadIdentity
 
-		Plaintext:             setToDefaultBoolIfNeeded("YC_PLAINTEXT", d.Get("plaintext").(bool)),
-		Insecure:              setToDefaultBoolIfNeeded("YC_INSECURE", d.Get("insecure").(bool)),
-		MaxRetries:            d.Get("max_retries").(int),
-		SharedCredentialsFile: d.Get("shared_credentials_file").(string),
-		Profile:               d.Get("profile").(string),
-		userAgent:             p.UserAgent("terraform-provider-yandex", version.ProviderVersion),
+	retryPolicy, err := expandRetryPolicy(d, settings.MaxRetries)
+	if err != nil {
+		return nil, diag.Errorf("invalid retry_policy: %s", err)
 	}
+	settings.RetryPolicy = retryPolicy
+	settings.RateLimits = expandRateLimits(d)
 
-	if len(config.Profile) == 0 {
-		config.Profile = "default"
+	lockBackend, err := expandLockBackend(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
+	settings.LockBackend = lockBackend
 
-	if config.MaxRetries == 0 {
-		config.MaxRetries = common.DefaultMaxRetries
+	config := Config{}
+	config.setSettings(settings)
+
+	defaultLabels, err := expandLabels(d.Get("default_labels"))
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
+	config.DefaultLabels = defaultLabels
 
-	if emptyFolder {
-		config.FolderID = ""
+	preventDestroy, err := common.ParsePreventDestroyRules(expandStringSlice(d.Get("prevent_destroy_types").([]interface{})))
+	if err != nil {
+		return nil, diag.Errorf("invalid prevent_destroy_types: %s", err)
 	}
+	config.PreventDestroy = preventDestroy
+	config.IAMCompareBeforeSet = d.Get("iam_compare_before_set").(bool)
+
 	stopCtx, ok := schema.StopContext(ctx)
 	if !ok {
 		stopCtx = ctx
@@ -441,16 +479,12 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, p *schema.Pr
 		stopCtx = context.Background()
 	}
 
-	terraformVersion := p.TerraformVersion
-	if terraformVersion == "" {
-		// Terraform 0.12 introduced this field to the protocol
-		// We can therefore assume that if it's missing it's 0.10 or 0.11
-		terraformVersion = "0.11+compatible"
-	}
-
-	if err := config.initAndValidate(stopCtx, terraformVersion, false); err != nil {
+	if err := config.initAndValidate(stopCtx, p.TerraformVersion, false); err != nil {
 		return nil, diag.FromErr(err)
 	}
+	if emptyFolder {
+		config.FolderID = ""
+	}
 
 	return &config, nil
 
@@ -10,17 +10,24 @@ import (
 	"testing"
 
 	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/workloadidentity"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-testing/terraform"
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/resourcemanager/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk"
+
+	yandex_framework "github.com/yandex-cloud/terraform-provider-yandex/yandex-framework"
 )
 
 const providerDefaultValueInsecure = false
@@ -30,6 +37,10 @@ const providerDefaultValueEndpoint = "api.cloud.yandex.net:443"
 var testAccProviders map[string]*schema.Provider
 var testAccProviderFactories map[string]func() (*schema.Provider, error)
 
+// testAccMuxProviderFactories serve testAccProvider along with the framework provider, for the tests
+// that use the resources migrated to the framework.
+var testAccMuxProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
+
 // WARNING!!!! do not use testAccProviderEmptyFolder in tests, that use testAccCheck***Destroy functions.
 // testAccCheck***Destroy functions tend to use static testAccProvider
 var testAccProviderEmptyFolder map[string]*schema.Provider
@@ -77,6 +88,10 @@ func init() {
 		},
 	}
 
+	testAccMuxProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"yandex": newMuxProviderServer,
+	}
+
 	testAccProviderEmptyFolder = map[string]*schema.Provider{
 		"yandex": emptyFolderProvider(),
 	}
@@ -88,6 +103,27 @@ func init() {
 	}
 }
 
+func newMuxProviderServer() (tfprotov6.ProviderServer, error) {
+	ctx := context.Background()
+
+	upgradedSdkProvider, err := tf5to6server.UpgradeServer(ctx, testAccProvider.GRPCProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		providerserver.NewProtocol6(yandex_framework.NewFrameworkProvider()),
+		func() tfprotov6.ProviderServer {
+			return upgradedSdkProvider
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer(), nil
+}
+
 func TestProvider(t *testing.T) {
 	if err := NewSDKProvider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -104,7 +140,7 @@ func TestProviderWithRawConfig(t *testing.T) {
 		"zone":     "ru-central1-a",
 	}
 
-	diags := testProvider.Configure(context.Background(), (*terraform2.ResourceConfig)(terraform.NewResourceConfigRaw(raw)))
+	diags := testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
 	if diags != nil && diags.HasError() {
 		for _, d := range diags {
 			if d.Severity == diag.Error {
@@ -130,7 +166,7 @@ func TestProviderDefaultValues(t *testing.T) {
 		"zone":  "ru-central1-a",
 	}
 
-	diags := testProvider.Configure(context.Background(), (*terraform2.ResourceConfig)(terraform.NewResourceConfigRaw(raw)))
+	diags := testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
 	if diags != nil && diags.HasError() {
 		for _, d := range diags {
 			if d.Severity == diag.Error {
@@ -181,7 +217,7 @@ func TestProviderOrganizationId(t *testing.T) {
 		"organization_id": org,
 	}
 
-	diags := testProvider.Configure(context.Background(), (*terraform2.ResourceConfig)(terraform.NewResourceConfigRaw(raw)))
+	diags := testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
 	if diags != nil && diags.HasError() {
 		for _, d := range diags {
 			if d.Severity == diag.Error {
@@ -207,7 +243,7 @@ func TestProviderSharedCredentialsFileAndProfile(t *testing.T) {
 		"profile":                 "prod-profile",
 	}
 
-	diags := testProvider.Configure(context.Background(), (*terraform2.ResourceConfig)(terraform.NewResourceConfigRaw(raw)))
+	diags := testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
 	if diags != nil && diags.HasError() {
 		for _, d := range diags {
 			if d.Severity == diag.Error {
@@ -225,6 +261,84 @@ func TestProviderSharedCredentialsFileAndProfile(t *testing.T) {
 	assert.Equal(t, "prod-profile", conf.Profile)
 }
 
+func TestProviderSharedCredentialsPrecedence(t *testing.T) {
+	filename, err := writeFile("[prod-profile]\n" +
+		"cloud_id=profile-cloud\n" +
+		"folder_id=profile-folder\n" +
+		"zone=profile-zone\n" +
+		"token=profile-token\n")
+	require.NoError(t, err)
+	defer os.Remove(filename)
+
+	t.Setenv("YC_CLOUD_ID", "env-cloud")
+	t.Setenv("YC_FOLDER_ID", "env-folder")
+	t.Setenv("YC_ZONE", "")
+	t.Setenv("YC_ENDPOINT", "")
+
+	testProvider := NewSDKProvider()
+	raw := map[string]interface{}{
+		"token":                   "any_string_like_a_oauth",
+		"cloud_id":                "attribute-cloud",
+		"shared_credentials_file": filename,
+		"profile":                 "prod-profile",
+	}
+
+	diags := testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
+	require.False(t, diags.HasError(), "error configuring provider: %v", diags)
+
+	conf := testProvider.Meta().(*Config)
+	assert.Equal(t, "attribute-cloud", conf.CloudID, "attribute must win over env and profile")
+	assert.Equal(t, "env-folder", conf.FolderID, "env must win over profile")
+	assert.Equal(t, "profile-zone", conf.Zone)
+	assert.Equal(t, "any_string_like_a_oauth", conf.Token, "configured credentials must not be mixed with the profile ones")
+	assert.Equal(t, common.DefaultEndpoint, conf.Endpoint)
+}
+
+func TestProviderWorkloadIdentity(t *testing.T) {
+	filename, err := writeFile("[default]\n" +
+		"folder_id=profile-folder\n" +
+		"token=profile-token\n")
+	require.NoError(t, err)
+	defer os.Remove(filename)
+
+	t.Setenv("YC_TOKEN", "")
+	t.Setenv("YC_SERVICE_ACCOUNT_KEY_FILE", "")
+
+	workloadIdentity := []interface{}{
+		map[string]interface{}{
+			"service_account_id": "sa-id",
+			"token_env":          "CI_JOB_JWT",
+		},
+	}
+
+	testProvider := NewSDKProvider()
+	raw := map[string]interface{}{
+		"shared_credentials_file": filename,
+		"workload_identity":       workloadIdentity,
+	}
+	diags := testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
+	require.False(t, diags.HasError(), "error configuring provider: %v", diags)
+
+	conf := testProvider.Meta().(*Config)
+	assert.Equal(t, "profile-folder", conf.FolderID)
+	assert.Empty(t, conf.Token, "profile token must not be used along with workload identity")
+	require.NotNil(t, conf.WorkloadIdentity)
+	assert.Equal(t, "sa-id", conf.WorkloadIdentity.ServiceAccountID)
+
+	settings := conf.settings()
+	credentials, _, err := settings.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.IsType(t, &workloadidentity.Credentials{}, credentials)
+
+	testProvider = NewSDKProvider()
+	raw = map[string]interface{}{
+		"token":             "any_string_like_a_oauth",
+		"workload_identity": workloadIdentity,
+	}
+	diags = testProvider.Configure(context.Background(), terraform2.NewResourceConfigRaw(raw))
+	assert.True(t, diags.HasError(), "workload_identity must conflict with token")
+}
+
 func testAccPreCheck(t *testing.T) {
 	for _, varName := range testAccEnvVars {
 		if val := os.Getenv(varName); val == "" {
@@ -295,7 +409,8 @@ func setTestIDs() error {
 		Token:                          os.Getenv("YC_TOKEN"),
 		ServiceAccountKeyFileOrContent: os.Getenv("YC_SERVICE_ACCOUNT_KEY_FILE"),
 	}
-	credentials, err := providerConfig.credentials()
+	settings := providerConfig.settings()
+	credentials, _, err := settings.Credentials(context.Background())
 	if err != nil {
 		return err
 	}
@@ -402,3 +517,14 @@ func restoreEnvVars(storage map[string]string) error {
 	}
 	return nil
 }
+
+func writeFile(data string) (string, error) {
+	tmpFile, err := os.CreateTemp("", "shared-credentials-file-test")
+	if err != nil {
+		return "", err
+	}
+	defer tmpFile.Close()
+
+	_, err = tmpFile.WriteString(data)
+	return tmpFile.Name(), err
+}
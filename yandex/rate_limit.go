@@ -0,0 +1,57 @@
+package yandex
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/ratelimit"
+)
+
+func rateLimitSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"service": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: common.Descriptions["rate_limit.service"],
+				},
+				"requests_per_second": {
+					Type:        schema.TypeFloat,
+					Optional:    true,
+					Description: common.Descriptions["rate_limit.requests_per_second"],
+				},
+				"burst": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Description: common.Descriptions["rate_limit.burst"],
+				},
+				"max_in_flight": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Description: common.Descriptions["rate_limit.max_in_flight"],
+				},
+			},
+		},
+		Description: common.Descriptions["rate_limit"],
+	}
+}
+
+func expandRateLimits(d *schema.ResourceData) []ratelimit.Rule {
+	var rules []ratelimit.Rule
+	for _, v := range d.Get("rate_limit").([]interface{}) {
+		if v == nil {
+			continue
+		}
+		limit := v.(map[string]interface{})
+		rules = append(rules, ratelimit.Rule{
+			Service:           limit["service"].(string),
+			RequestsPerSecond: limit["requests_per_second"].(float64),
+			Burst:             limit["burst"].(int),
+			MaxInFlight:       limit["max_in_flight"].(int),
+		})
+	}
+	return rules
+}
@@ -261,7 +261,7 @@ func resourceYandexALBHTTPRouterUpdate(d *schema.ResourceData, meta interface{})
 
 	var updatePath []string
 	for field, path := range resourceALBHTTPRouterUpdateFieldsMap {
-		if d.HasChange(field) {
+		if hasFieldChange(d, field) {
 			updatePath = append(updatePath, path)
 		}
 	}
@@ -82,7 +82,7 @@ func resourceYandexBackupPolicy() *schema.Resource {
 				MaxItems: 1,
 				Required: true,
 				Elem:     resourceYandexBackupPolicyRetryConfigurationResource(),
-				Set:      storageBucketS3SetFunc("enabled", "interval", "max_attempts"),
+				Set:      keysSetFunc("enabled", "interval", "max_attempts"),
 			},
 
 			"vm_snapshot_reattempts": {
@@ -136,7 +136,7 @@ func resourceYandexBackupPolicy() *schema.Resource {
 				Required: true,
 				MaxItems: 1,
 				Elem:     resourceYandexBacupPolicyRetentionSchema(),
-				Set:      storageBucketS3SetFunc("after_backup", "rules"),
+				Set:      keysSetFunc("after_backup", "rules"),
 			},
 
 			"scheduling": {
@@ -253,7 +253,7 @@ func resourceYandexBacupPolicyRetentionSchema() *schema.Resource {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem:     resourceYandexBackupRetentionRuleSchema(),
-				Set:      storageBucketS3SetFunc("max_age", "max_count", "repeat_period"),
+				Set:      keysSetFunc("max_age", "max_count", "repeat_period"),
 			},
 		},
 	}
@@ -271,7 +271,7 @@ func resourceYandexBacupPolicySchedulingResource() *schema.Resource {
 			"execute_by_time": {
 				Type:     schema.TypeSet,
 				Optional: true,
-				Set:      storageBucketS3SetFunc("weekdays", "repeat_at", "repeat_every", "monthdays", "include_last_day_of_month", "months", "type"),
+				Set:      keysSetFunc("weekdays", "repeat_at", "repeat_every", "monthdays", "include_last_day_of_month", "months", "type"),
 				Elem:     resourceYandexackupPolicySchedulingRuleTimeResource(),
 			},
 
@@ -696,7 +696,7 @@ func resourceYandexCMCertificateUpdate(ctx context.Context, d *schema.ResourceDa
 		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "description")
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get("labels"))
 		if err != nil {
 			return diag.Errorf("error while get labels: %s", err)
@@ -849,7 +849,7 @@ func resourceYandexComputeInstanceUpdate(d *schema.ResourceData, meta interface{
 	}
 
 	labelPropName := "labels"
-	if d.HasChange(labelPropName) {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get(labelPropName))
 		if err != nil {
 			return err
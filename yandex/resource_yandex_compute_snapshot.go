@@ -162,7 +162,7 @@ func resourceYandexComputeSnapshotUpdate(d *schema.ResourceData, meta interface{
 	d.Partial(true)
 
 	labelPropName := "labels"
-	if d.HasChange(labelPropName) {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get(labelPropName))
 		if err != nil {
 			return err
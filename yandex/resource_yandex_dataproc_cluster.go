@@ -651,7 +651,7 @@ func getDataprocClusterUpdateRequest(d *schema.ResourceData) (*dataproc.UpdateCl
 	var updatePaths []string
 	fieldNames := []string{"description", "labels", "name", "service_account_id", "bucket", "ui_proxy", "security_group_ids", "deletion_protection"}
 	for _, fieldName := range fieldNames {
-		if d.HasChange(fieldName) {
+		if hasFieldChange(d, fieldName) {
 			updatePaths = append(updatePaths, fieldName)
 		}
 	}
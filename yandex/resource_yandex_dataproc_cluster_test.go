@@ -604,9 +604,9 @@ func TestAccDataprocCluster(t *testing.T) {
 	resourceName := "yandex_dataproc_cluster.tf-dataproc-cluster"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckDataprocClusterDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckDataprocClusterDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataprocClusterConfig(t, templateParams),
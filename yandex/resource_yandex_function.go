@@ -410,7 +410,7 @@ func resourceYandexFunctionUpdate(d *schema.ResourceData, meta interface{}) erro
 		updatePaths = append(updatePaths, "description")
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		updatePaths = append(updatePaths, "labels")
 	}
 
@@ -677,7 +677,7 @@ func flattenYandexFunction(d *schema.ResourceData, function *functions.Function,
 
 	d.Set("version", version.Id)
 	d.Set("image_size", version.ImageSize)
-	d.Set("loggroup_id", version.LogGroupId)
+	d.Set("loggroup_id", version.GetLogOptions().GetLogGroupId())
 	d.Set("runtime", version.Runtime)
 	d.Set("entrypoint", version.Entrypoint)
 	d.Set("service_account_id", version.ServiceAccountId)
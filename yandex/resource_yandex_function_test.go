@@ -71,9 +71,9 @@ func TestAccYandexFunction_basic(t *testing.T) {
 	zipFilename := "test-fixtures/serverless/main.zip"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionDestroy,
 		Steps: []resource.TestStep{
 			basicYandexFunctionTestStep(functionName, functionDesc, labelKey, labelValue, zipFilename, &function),
 			functionImportTestStep(),
@@ -98,9 +98,9 @@ func TestAccYandexFunction_update(t *testing.T) {
 	zipFilename := "test-fixtures/serverless/main.zip"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionDestroy,
 		Steps: []resource.TestStep{
 			basicYandexFunctionTestStep(functionName, functionDesc, labelKey, labelValue, zipFilename, &function),
 			functionImportTestStep(),
@@ -214,9 +214,9 @@ func TestAccYandexFunction_full(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionDestroy,
 		Steps: []resource.TestStep{
 			testConfigFunc(params),
 			functionImportTestStep(),
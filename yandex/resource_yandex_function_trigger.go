@@ -1055,7 +1055,7 @@ func resourceYandexFunctionTriggerUpdate(d *schema.ResourceData, meta interface{
 		updatePaths = append(updatePaths, "description")
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		updatePaths = append(updatePaths, "labels")
 	}
 
@@ -68,9 +68,9 @@ func TestAccYandexFunctionTrigger_basic(t *testing.T) {
 	labelValue := acctest.RandomWithPrefix("tf-trigger-label-value")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionTriggerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionTriggerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionTriggerBasic(triggerName, triggerDesc, labelKey, labelValue),
@@ -101,9 +101,9 @@ func TestAccYandexFunctionTrigger_invokeContainerBasic(t *testing.T) {
 	labelValue := acctest.RandomWithPrefix("tf-trigger-label-value")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionTriggerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionTriggerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionTriggerInvokeContainer(triggerName, triggerDesc, labelKey, labelValue),
@@ -138,9 +138,9 @@ func TestAccYandexFunctionTrigger_update(t *testing.T) {
 	labelValueUpdated := acctest.RandomWithPrefix("tf-trigger-label-value")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionTriggerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionTriggerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionTriggerBasic(triggerName, triggerDesc, labelKey, labelValue),
@@ -182,9 +182,9 @@ func TestAccYandexFunctionTrigger_iot(t *testing.T) {
 	var device iot.Device
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionTriggerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionTriggerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionTriggerIoT(registryName, deviceName, triggerName),
@@ -218,9 +218,9 @@ func TestAccYandexFunctionTrigger_message(t *testing.T) {
 	serviceAccount := acctest.RandomWithPrefix("tf-service-account")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionTriggerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionTriggerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionTriggerMessageQueue(triggerName, queueID, serviceAccount),
@@ -249,9 +249,9 @@ func TestAccYandexFunctionTrigger_object(t *testing.T) {
 	bucket := acctest.RandomWithPrefix("tf-bucket")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionTriggerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionTriggerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionTriggerObjectStorage(triggerName, bucket),
@@ -282,9 +282,9 @@ func TestAccYandexFunctionTrigger_logging(t *testing.T) {
 	triggerName := acctest.RandomWithPrefix("tf-trigger")
 	logSrcFn := &functions.Function{}
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionTriggerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionTriggerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionTriggerLogging(triggerName, 5, 100),
@@ -310,9 +310,9 @@ func TestAccYandexFunctionTrigger_CR(t *testing.T) {
 	trigger := &triggers.Trigger{}
 	triggerName := acctest.RandomWithPrefix("tf-trigger")
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionTriggerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionTriggerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionTriggerContainerRegistry(triggerName, 3, 10),
@@ -338,9 +338,9 @@ func TestAccYandexFunctionTrigger_YDS(t *testing.T) {
 	trigger := &triggers.Trigger{}
 	triggerName := acctest.RandomWithPrefix("tf-trigger")
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionTriggerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionTriggerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionTriggerYDS(triggerName, 5, 1000),
@@ -365,9 +365,9 @@ func TestAccYandexFunctionTrigger_Mail(t *testing.T) {
 	trigger := &triggers.Trigger{}
 	triggerName := acctest.RandomWithPrefix("tf-trigger")
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexFunctionTriggerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexFunctionTriggerDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testYandexFunctionTriggerMail(triggerName, 3, 10),
@@ -0,0 +1,379 @@
+package yandex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/grpcjson"
+)
+
+const yandexGrpcResourceDefaultTimeout = 30 * time.Minute
+
+// grpcResourceIDPlaceholder is replaced by the resource ID in the read, update and delete requests.
+const grpcResourceIDPlaceholder = "{{id}}"
+
+func resourceYandexGrpcResource() *schema.Resource {
+	jsonAttribute := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeString,
+			Description:  description,
+			ValidateFunc: validation.StringIsJSON,
+			StateFunc: func(v interface{}) string {
+				json, _ := structure.NormalizeJsonString(v)
+				return json
+			},
+		}
+	}
+
+	createRequest := jsonAttribute("Request of the create method in protobuf JSON format.")
+	createRequest.Required = true
+	createRequest.ForceNew = true
+
+	readRequest := jsonAttribute("Request of the get method in protobuf JSON format, `" + grpcResourceIDPlaceholder + "` is replaced by the resource ID.")
+	readRequest.Required = true
+
+	updateRequest := jsonAttribute("Request of the update method in protobuf JSON format, `" + grpcResourceIDPlaceholder + "` is replaced by the resource ID.")
+	updateRequest.Optional = true
+	updateRequest.RequiredWith = []string{"update_method"}
+
+	deleteRequest := jsonAttribute("Request of the delete method in protobuf JSON format, `" + grpcResourceIDPlaceholder + "` is replaced by the resource ID.")
+	deleteRequest.Optional = true
+	deleteRequest.RequiredWith = []string{"delete_method"}
+
+	return &schema.Resource{
+		Description: "Manages an API object through fully qualified gRPC methods with requests in protobuf JSON format, " +
+			"for the API the provider has no resource for yet.",
+
+		CreateContext: resourceYandexGrpcResourceCreate,
+		ReadContext:   resourceYandexGrpcResourceRead,
+		UpdateContext: resourceYandexGrpcResourceUpdate,
+		DeleteContext: resourceYandexGrpcResourceDelete,
+		CustomizeDiff: resourceYandexGrpcResourceCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(yandexGrpcResourceDefaultTimeout),
+			Read:   schema.DefaultTimeout(yandexGrpcResourceDefaultTimeout),
+			Update: schema.DefaultTimeout(yandexGrpcResourceDefaultTimeout),
+			Delete: schema.DefaultTimeout(yandexGrpcResourceDefaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"endpoint": {
+				Type:        schema.TypeString,
+				Description: "ID of the API endpoint serving the methods as listed by `yc endpoint list`, e.g. `vpc`, or its address in host:port format.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"create_method": {
+				Type:        schema.TypeString,
+				Description: "Fully qualified create method, e.g. `yandex.cloud.vpc.v1.NetworkService/Create`.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"create_request_json": createRequest,
+			"id_path": {
+				Type:        schema.TypeString,
+				Description: "Dot separated path of the resource ID in the response of the create method, or in the response of the operation it returns. A path prefixed by `metadata.` is looked up in the metadata of the operation before it is waited for.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "id",
+			},
+			"read_method": {
+				Type:        schema.TypeString,
+				Description: "Fully qualified get method, e.g. `yandex.cloud.vpc.v1.NetworkService/Get`.",
+				Required:    true,
+			},
+			"read_request_json": readRequest,
+			"update_method": {
+				Type:         schema.TypeString,
+				Description:  "Fully qualified update method. If it is not set, the resource is recreated on drift.",
+				Optional:     true,
+				RequiredWith: []string{"update_request_json"},
+			},
+			"update_request_json": updateRequest,
+			"delete_method": {
+				Type:         schema.TypeString,
+				Description:  "Fully qualified delete method. If it is not set, the resource is only removed from the state on destroy.",
+				Optional:     true,
+				RequiredWith: []string{"delete_request_json"},
+			},
+			"delete_request_json": deleteRequest,
+			"drift_paths": {
+				Type:        schema.TypeSet,
+				Description: "Dot separated paths in the response of the get method whose changes are detected as drift.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"response_json": {
+				Type:        schema.TypeString,
+				Description: "Response of the get method in protobuf JSON format.",
+				Computed:    true,
+			},
+			"drift_values": {
+				Type:        schema.TypeMap,
+				Description: "Values at `drift_paths` in JSON format, as of the last create or update.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceYandexGrpcResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	idPath := d.Get("id_path").(string)
+	// The ID in the operation metadata is set before the operation is waited for, so that a resource
+	// whose operation fails is kept in the state and is deleted or replaced by the next apply.
+	onOperation := func(op *operation.Operation) error {
+		metadataPath, ok := strings.CutPrefix(idPath, "metadata.")
+		if !ok {
+			return nil
+		}
+		metadata, err := grpcResourceOperationMetadata(op)
+		if err != nil {
+			return err
+		}
+		id, err := grpcResourceID(metadata, metadataPath)
+		if err != nil {
+			return fmt.Errorf("%w in the metadata of operation %q: %s", err, op.GetId(), metadata)
+		}
+		d.SetId(id)
+		return nil
+	}
+
+	resp, err := callGrpcResourceMethod(ctx, config, d, "create_method", d.Get("create_request_json").(string), onOperation)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Id() == "" {
+		id, err := grpcResourceID(resp, idPath)
+		if err != nil {
+			return diag.Errorf("%s in the create response: %s", err, resp)
+		}
+		d.SetId(id)
+	}
+
+	return resourceYandexGrpcResourceRefresh(ctx, d, config, true)
+}
+
+func resourceYandexGrpcResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	// The values at drift paths are kept as of the last apply, so the drift is shown in the plan.
+	return resourceYandexGrpcResourceRefresh(ctx, d, config, len(d.Get("drift_values").(map[string]interface{})) == 0)
+}
+
+func resourceYandexGrpcResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	if d.Get("update_method").(string) != "" && d.HasChanges("update_method", "update_request_json", "drift_values") {
+		if _, err := callGrpcResourceMethod(ctx, config, d, "update_method", grpcResourceRequest(d, "update_request_json"), nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceYandexGrpcResourceRefresh(ctx, d, config, true)
+}
+
+func resourceYandexGrpcResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	if d.Get("delete_method").(string) == "" {
+		log.Printf("[WARN] Removing grpc resource %q from the state only, as it has no delete_method", d.Id())
+		return nil
+	}
+
+	_, err := callGrpcResourceMethod(ctx, config, d, "delete_method", grpcResourceRequest(d, "delete_request_json"), nil)
+	if err != nil && !isStatusWithCode(err, codes.NotFound) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// resourceYandexGrpcResourceCustomizeDiff plans an update, or a replacement if there is no update method,
+// when the values at drift_paths differ from the ones of the last apply.
+func resourceYandexGrpcResourceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	current, err := grpcResourceDriftValues(d.Get("response_json").(string), convertStringSet(d.Get("drift_paths").(*schema.Set)))
+	if err != nil {
+		return err
+	}
+	if driftValuesEqual(current, d.Get("drift_values").(map[string]interface{})) {
+		return nil
+	}
+
+	if d.HasChange("drift_paths") {
+		// Other values are tracked, the resource itself has not drifted.
+		return d.SetNew("drift_values", current)
+	}
+	log.Printf("[DEBUG] grpc resource %q has drifted at drift_paths", d.Id())
+	if err := d.SetNewComputed("drift_values"); err != nil {
+		return err
+	}
+	if d.Get("update_method").(string) == "" {
+		return d.ForceNew("drift_values")
+	}
+	return nil
+}
+
+func resourceYandexGrpcResourceRefresh(ctx context.Context, d *schema.ResourceData, config *Config, setDriftValues bool) diag.Diagnostics {
+	resp, err := callGrpcResourceMethod(ctx, config, d, "read_method", grpcResourceRequest(d, "read_request_json"), nil)
+	if err != nil {
+		return diag.FromErr(handleNotFoundError(err, d, fmt.Sprintf("grpc resource %q", d.Id())))
+	}
+
+	if setDriftValues {
+		values, err := grpcResourceDriftValues(resp, convertStringSet(d.Get("drift_paths").(*schema.Set)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("drift_values", values); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("response_json", resp); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// grpcResourceRequest returns the request in the attribute with the placeholder replaced by the resource ID.
+func grpcResourceRequest(d *schema.ResourceData, attribute string) string {
+	id, _ := json.Marshal(d.Id())
+	return strings.ReplaceAll(d.Get(attribute).(string), grpcResourceIDPlaceholder, strings.Trim(string(id), `"`))
+}
+
+// callGrpcResourceMethod calls the method in the attribute and returns the response in JSON format. If the method
+// returns an operation, onOperation is called with it unless it is nil, then the operation is waited for and its
+// response is returned.
+func callGrpcResourceMethod(ctx context.Context, config *Config, d *schema.ResourceData, methodAttribute string, request string,
+	onOperation func(op *operation.Operation) error) (string, error) {
+	methodName := d.Get(methodAttribute).(string)
+	method, err := grpcjson.FindMethod(methodName)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", methodAttribute, err)
+	}
+
+	conn, err := config.ycClient.Conn(ctx, d.Get("endpoint").(string))
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("[DEBUG] Calling %s of grpc resource %q", methodName, d.Id())
+	resp, err := grpcjson.Invoke(ctx, conn, method, request)
+	if err != nil {
+		return "", fmt.Errorf("error while calling %s: %w", methodName, err)
+	}
+
+	if op, ok := resp.(*operation.Operation); ok {
+		if onOperation != nil {
+			if err := onOperation(op); err != nil {
+				return "", err
+			}
+		}
+		wrapped, err := config.sdk.WrapOperation(op, nil)
+		if err != nil {
+			return "", err
+		}
+		if err := wrapped.Wait(ctx); err != nil {
+			return "", fmt.Errorf("error while waiting for operation %q of %s: %w", op.GetId(), methodName, err)
+		}
+		resp = &emptypb.Empty{}
+		if a := wrapped.Proto().GetResponse(); a != nil {
+			if resp, err = a.UnmarshalNew(); err != nil {
+				return "", fmt.Errorf("error while decoding response of operation %q: %w", op.GetId(), err)
+			}
+		}
+	}
+
+	return grpcjson.Marshal(resp)
+}
+
+// grpcResourceOperationMetadata returns the metadata of the operation in JSON format.
+func grpcResourceOperationMetadata(op *operation.Operation) (string, error) {
+	if op.GetMetadata() == nil {
+		return "{}", nil
+	}
+	metadata, err := op.GetMetadata().UnmarshalNew()
+	if err != nil {
+		return "", fmt.Errorf("error while decoding metadata of operation %q: %w", op.GetId(), err)
+	}
+	return grpcjson.Marshal(metadata)
+}
+
+// grpcResourceID returns the non-empty string at the path of the JSON document.
+func grpcResourceID(document string, path string) (string, error) {
+	v, ok, err := grpcjson.Lookup(document, path)
+	if err != nil {
+		return "", err
+	}
+	if id, _ := v.(string); ok && id != "" {
+		return id, nil
+	}
+	return "", fmt.Errorf("cannot find resource ID at %q", path)
+}
+
+// grpcResourceDriftValues returns the values at the paths of the JSON document in JSON format,
+// "null" for the paths that are not in the document.
+func grpcResourceDriftValues(document string, paths []string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(paths))
+	if document == "" {
+		return values, nil
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		v, _, err := grpcjson.Lookup(document, path)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		values[path] = string(b)
+	}
+	return values, nil
+}
+
+func driftValuesEqual(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
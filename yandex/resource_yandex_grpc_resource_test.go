@@ -0,0 +1,96 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGrpcResource_offline(t *testing.T) {
+	config, server := newFakeCloudConfig(t)
+	ctx := config.Context()
+	r := resourceYandexGrpcResource()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"endpoint":            "vpc",
+		"create_method":       "yandex.cloud.vpc.v1.NetworkService/Create",
+		"create_request_json": `{"folderId":"fake-folder-id","name":"tf-network","description":"created"}`,
+		"read_method":         "yandex.cloud.vpc.v1.NetworkService/Get",
+		"read_request_json":   `{"networkId":"{{id}}"}`,
+		"delete_method":       "yandex.cloud.vpc.v1.NetworkService/Delete",
+		"delete_request_json": `{"networkId":"{{id}}"}`,
+		"drift_paths":         []interface{}{"description", "labels"},
+	})
+	diags := r.CreateContext(ctx, d, config)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	id := d.Id()
+	network, ok := server.Get(id).(*vpc.Network)
+	require.True(t, ok, "the network must be created with the create method")
+	assert.Equal(t, "tf-network", network.GetName())
+	assert.Contains(t, d.Get("response_json"), `"name":"tf-network"`)
+	assert.Equal(t, map[string]interface{}{"description": `"created"`, "labels": "null"}, d.Get("drift_values"))
+
+	server.Mutate(id, func(m proto.Message) {
+		m.(*vpc.Network).Description = "changed"
+	})
+	require.False(t, r.ReadContext(ctx, d, config).HasError())
+	assert.Contains(t, d.Get("response_json"), `"description":"changed"`)
+	assert.Equal(t, `"created"`, d.Get("drift_values.description"), "drift values must be kept as of the last apply")
+
+	current, err := grpcResourceDriftValues(d.Get("response_json").(string), []string{"description", "labels"})
+	require.NoError(t, err)
+	assert.False(t, driftValuesEqual(current, d.Get("drift_values").(map[string]interface{})))
+
+	require.False(t, r.DeleteContext(ctx, d, config).HasError())
+	assert.Nil(t, server.Get(id))
+
+	require.False(t, r.ReadContext(ctx, d, config).HasError())
+	assert.Empty(t, d.Id(), "a deleted resource must be removed from the state")
+}
+
+func TestGrpcResource_invalidMethod_offline(t *testing.T) {
+	config, _ := newFakeCloudConfig(t)
+	r := resourceYandexGrpcResource()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"endpoint":            "vpc",
+		"create_method":       "yandex.cloud.vpc.v1.NetworkService/Unknown",
+		"create_request_json": `{}`,
+		"read_method":         "yandex.cloud.vpc.v1.NetworkService/Get",
+		"read_request_json":   `{"networkId":"{{id}}"}`,
+	})
+	diags := r.CreateContext(config.Context(), d, config)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "invalid create_method")
+	assert.Empty(t, d.Id())
+}
+
+func TestGrpcResource_metadataIDPath_offline(t *testing.T) {
+	config, server := newFakeCloudConfig(t)
+	r := resourceYandexGrpcResource()
+
+	raw := map[string]interface{}{
+		"endpoint":            "vpc",
+		"create_method":       "yandex.cloud.vpc.v1.NetworkService/Create",
+		"create_request_json": `{"folderId":"fake-folder-id","name":"tf-network"}`,
+		"id_path":             "metadata.networkId",
+		"read_method":         "yandex.cloud.vpc.v1.NetworkService/Get",
+		"read_request_json":   `{"networkId":"{{id}}"}`,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	diags := r.CreateContext(config.Context(), d, config)
+	require.False(t, diags.HasError(), "%v", diags)
+	_, ok := server.Get(d.Id()).(*vpc.Network)
+	assert.True(t, ok, "the ID must be taken from the operation metadata")
+
+	raw["id_path"] = "metadata.unknownId"
+	d = schema.TestResourceDataRaw(t, r.Schema, raw)
+	diags = r.CreateContext(config.Context(), d, config)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `cannot find resource ID at "unknownId" in the metadata of operation`)
+}
@@ -231,7 +231,7 @@ func resourceYandexIoTCoreBrokerUpdate(d *schema.ResourceData, meta interface{})
 		updatePaths = append(updatePaths, "description")
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		updatePaths = append(updatePaths, "labels")
 	}
 
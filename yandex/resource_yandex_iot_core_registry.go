@@ -250,7 +250,7 @@ func resourceYandexIoTCoreRegistryUpdate(d *schema.ResourceData, meta interface{
 		updatePaths = append(updatePaths, "description")
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		updatePaths = append(updatePaths, "labels")
 	}
 
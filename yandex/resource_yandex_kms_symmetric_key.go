@@ -204,7 +204,7 @@ func resourceYandexKMSSymmetricKeyUpdate(d *schema.ResourceData, meta interface{
 	d.Partial(true)
 
 	labelPropName := "labels"
-	if d.HasChange(labelPropName) {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get(labelPropName))
 		if err != nil {
 			return err
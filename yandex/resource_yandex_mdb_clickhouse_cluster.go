@@ -1460,7 +1460,7 @@ func updateClickHouseClusterParams(d *schema.ResourceData, meta interface{}) err
 	onDone := []func(){}
 	updatePath := []string{}
 	for field, path := range mdbClickHouseUpdateFieldsMap {
-		if d.HasChange(field) {
+		if hasFieldChange(d, field) {
 			updatePath = append(updatePath, path)
 			onDone = append(onDone, func() {
 
@@ -617,7 +617,7 @@ func updateElasticsearchClusterParams(d *schema.ResourceData, meta interface{})
 		changed = append(changed, "name")
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get("labels"))
 		if err != nil {
 			return err
@@ -299,6 +299,8 @@ func resourceYandexMDBKafkaClusterTopicBlock() *schema.Resource {
 	}
 }
 
+// resourceYandexMDBKafkaClusterUserBlock has no write-only password_wo unlike yandex_mdb_kafka_user:
+// the user blocks are a set and the SDK rejects write-only attributes nested into sets.
 func resourceYandexMDBKafkaClusterUserBlock() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -1011,7 +1013,7 @@ func kafkaClusterUpdateRequestWithMask(d *schema.ResourceData) (*kafka.UpdateClu
 
 	updatePath := []string{}
 	for field, path := range mdbKafkaUpdateFieldsMap {
-		if d.HasChange(field) {
+		if hasFieldChange(d, field) {
 			updatePath = append(updatePath, strings.Replace(path, "{version}", getSuffixVersion(d), -1))
 		}
 	}
@@ -47,9 +47,21 @@ func resourceYandexMDBKafkaUser() *schema.Resource {
 				ForceNew: true,
 			},
 			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
 				Type:      schema.TypeString,
-				Required:  true,
+				Optional:  true,
 				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
 			},
 			"permission": {
 				Type:     schema.TypeSet,
@@ -92,10 +104,22 @@ func buildKafkaUserPermissions(d *schema.ResourceData) ([]*kafka.Permission, boo
 	return nil, false, nil
 }
 
+func buildKafkaUserPassword(d *schema.ResourceData) (string, error) {
+	password, err := getWriteOnlyString(d, "password_wo")
+	if err != nil || password != "" {
+		return password, err
+	}
+	return d.Get("password").(string), nil
+}
+
 func buildKafkaUserSpec(d *schema.ResourceData) (*kafka.UserSpec, error) {
+	password, err := buildKafkaUserPassword(d)
+	if err != nil {
+		return nil, err
+	}
 	userSpec := &kafka.UserSpec{
 		Name:     d.Get("name").(string),
-		Password: d.Get("password").(string),
+		Password: password,
 	}
 	permissions, ok, err := buildKafkaUserPermissions(d)
 	if err != nil {
@@ -137,10 +161,14 @@ func resourceYandexMDBKafkaUserUpdate(d *schema.ResourceData, meta interface{})
 	ctx, cancel := config.ContextWithTimeout(d.Timeout(schema.TimeoutUpdate))
 	defer cancel()
 
+	password, err := buildKafkaUserPassword(d)
+	if err != nil {
+		return err
+	}
 	request := &kafka.UpdateUserRequest{
 		ClusterId: d.Get("cluster_id").(string),
 		UserName:  d.Get("name").(string),
-		Password:  d.Get("password").(string),
+		Password:  password,
 	}
 
 	permissions, ok, err := buildKafkaUserPermissions(d)
@@ -157,6 +185,10 @@ func resourceYandexMDBKafkaUserUpdate(d *schema.ResourceData, meta interface{})
 			updatePaths = append(updatePaths, maskField)
 		}
 	}
+	// Write-only password is never stored, so it is sent again only when its version changes.
+	if d.HasChange("password_wo_version") && !d.HasChange("password") {
+		updatePaths = append(updatePaths, "password")
+	}
 	if len(updatePaths) == 0 {
 		return nil
 	}
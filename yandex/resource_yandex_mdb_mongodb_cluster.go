@@ -1253,7 +1253,7 @@ func updateMongodbClusterParams(ctx context.Context, d *schema.ResourceData, met
 
 	var updatePath []string
 	for field, path := range mdbMongodbUpdateFieldsMap {
-		if d.HasChange(field) {
+		if hasFieldChange(d, field) {
 			updatePath = append(updatePath, path)
 		}
 	}
@@ -105,9 +105,19 @@ func resourceYandexMDBMySQLCluster() *schema.Resource {
 						},
 						"password": {
 							Type:      schema.TypeString,
-							Required:  true,
+							Optional:  true,
 							Sensitive: true,
 						},
+						"password_wo": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
+						},
+						"password_wo_version": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
 						"permission": {
 							Type:     schema.TypeSet,
 							Optional: true,
@@ -564,6 +574,9 @@ func prepareCreateMySQLRequest(d *schema.ResourceData, meta *Config) (*mysql.Cre
 	if err != nil {
 		return nil, fmt.Errorf("error while expanding user specs on MySQL Cluster create: %s", err)
 	}
+	if err := expandMySQLUsersWriteOnlyPasswords(d, users); err != nil {
+		return nil, fmt.Errorf("error while expanding user specs on MySQL Cluster create: %s", err)
+	}
 
 	configSpec, err := expandMySQLConfigSpec(d)
 	if err != nil {
@@ -656,6 +669,11 @@ func resourceYandexMDBMySQLClusterRead(d *schema.ResourceData, meta interface{})
 		if err != nil {
 			return err
 		}
+		versions := passwordWriteOnlyVersions(stateUser)
+		for _, u := range fUsers {
+			m := u.(map[string]interface{})
+			m["password_wo_version"] = versions[m["name"].(string)]
+		}
 
 		sortInterfaceListByResourceData(fUsers, d, "user", "name")
 
@@ -889,7 +907,7 @@ func prepareMySQLClusterUpdateRequest(d *schema.ResourceData) (*mysql.UpdateClus
 
 	updatePaths := []string{}
 	for field, path := range mdbMysqlUpdateFieldsMap {
-		if d.HasChange(field) {
+		if hasFieldChange(d, field) {
 			updatePaths = append(updatePaths, path)
 		}
 	}
@@ -1021,6 +1039,9 @@ func updateMysqlClusterUsers(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return err
 	}
+	if err := expandMySQLUsersWriteOnlyPasswords(d, targetUsers); err != nil {
+		return err
+	}
 
 	toDelete, toAdd := mysqlUsersDiff(currUsers, targetUsers)
 
@@ -1127,10 +1148,12 @@ func mysqlChangedUsers(users []*mysql.User, d *schema.ResourceData) ([]*mysql.Us
 	oldSpecs, newSpecs := d.GetChange("user")
 	result := []*mysql.UserSpec{}
 	oldPwd := make(map[string]string)
+	oldPwdVersion := make(map[string]int)
 
 	for _, spec := range oldSpecs.([]interface{}) {
 		m := spec.(map[string]interface{})
 		oldPwd[m["name"].(string)] = m["password"].(string)
+		oldPwdVersion[m["name"].(string)] = m["password_wo_version"].(int)
 	}
 
 	usersMap := make(map[string]*mysql.User)
@@ -1139,13 +1162,26 @@ func mysqlChangedUsers(users []*mysql.User, d *schema.ResourceData) ([]*mysql.Us
 		usersMap[u.Name] = u
 	}
 
-	for _, u := range newSpecs.([]interface{}) {
+	for i, u := range newSpecs.([]interface{}) {
 		m := u.(map[string]interface{})
 		user, isDiff, err := expandMysqlUser(m, usersMap[(m["name"]).(string)])
 		if err != nil {
 			return nil, err
 		}
-		if isDiff || oldPwd[user.Name] != user.Password {
+
+		// Write-only password is never stored, so it is sent again only when its version changes.
+		password, err := getWriteOnlyString(d, fmt.Sprintf("user.%d.password_wo", i))
+		if err != nil {
+			return nil, err
+		}
+		if password != "" {
+			user.Password = password
+			isDiff = isDiff || oldPwdVersion[user.Name] != m["password_wo_version"].(int)
+		} else {
+			isDiff = isDiff || oldPwd[user.Name] != user.Password
+		}
+
+		if isDiff {
 			result = append(result, user)
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/mdb/mysql/v1"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
@@ -48,9 +49,37 @@ func resourceYandexMDBMySQLUser() *schema.Resource {
 				Required: true,
 			},
 			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo", "generate_password"},
+			},
+			"password_wo": {
 				Type:      schema.TypeString,
-				Required:  true,
+				Optional:  true,
 				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+			},
+			"generate_password": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"connection_manager": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connection_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 			"permission": {
 				Type:     schema.TypeSet,
@@ -177,6 +206,18 @@ func expandMySQLUserSpec(d *schema.ResourceData) (*mysql.UserSpec, error) {
 		user.Password = v.(string)
 	}
 
+	password, err := getWriteOnlyString(d, "password_wo")
+	if err != nil {
+		return nil, err
+	}
+	if password != "" {
+		user.Password = password
+	}
+
+	if v, ok := d.GetOk("generate_password"); ok && v.(bool) {
+		user.GeneratePassword = &wrappers.BoolValue{Value: true}
+	}
+
 	if v, ok := d.GetOk("permission"); ok {
 		permissions, err := expandMysqlUserPermissions(v.(*schema.Set))
 		if err != nil {
@@ -244,6 +285,7 @@ func resourceYandexMDBMySQLUserRead(d *schema.ResourceData, meta interface{}) er
 	if user.AuthenticationPlugin != 0 {
 		d.Set("authentication_plugin", mysql.AuthPlugin_name[int32(user.AuthenticationPlugin)])
 	}
+	d.Set("connection_manager", flattenMysqlUserConnectionManager(user.ConnectionManager))
 	return nil
 }
 
@@ -258,16 +300,26 @@ func resourceYandexMDBMySQLUserUpdate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	updatePaths := []string{"authentication_plugin", "permissions", "connection_limits", "global_permissions"}
+	// Write-only password is never stored, so it is sent again only when its version changes.
+	if d.HasChange("password") || d.HasChange("password_wo_version") {
+		updatePaths = append(updatePaths, "password")
+	}
+	if d.HasChange("generate_password") && user.GeneratePassword.GetValue() {
+		updatePaths = append(updatePaths, "generate_password")
+	}
+
 	clusterID := d.Get("cluster_id").(string)
 	request := &mysql.UpdateUserRequest{
 		ClusterId:            clusterID,
 		UserName:             user.Name,
 		Password:             user.Password,
+		GeneratePassword:     user.GeneratePassword,
 		Permissions:          user.Permissions,
 		AuthenticationPlugin: user.AuthenticationPlugin,
 		ConnectionLimits:     user.ConnectionLimits,
 		GlobalPermissions:    user.GlobalPermissions,
-		UpdateMask:           &field_mask.FieldMask{Paths: []string{"authentication_plugin", "password", "permissions", "connection_limits", "global_permissions"}},
+		UpdateMask:           &field_mask.FieldMask{Paths: updatePaths},
 	}
 	op, err := retryConflictingOperation(ctx, config, func() (*operation.Operation, error) {
 		log.Printf("[DEBUG] Sending MySQL user update request: %+v", request)
@@ -772,7 +772,7 @@ func updateOpenSearchClusterParams(d *schema.ResourceData, meta interface{}) err
 		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "name")
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get("labels"))
 		if err != nil {
 			return err
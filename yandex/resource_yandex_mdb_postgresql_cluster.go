@@ -369,9 +369,19 @@ func resourceYandexMDBPostgreSQLClusterUserBlock() *schema.Resource {
 			},
 			"password": {
 				Type:      schema.TypeString,
-				Required:  true,
+				Optional:  true,
 				Sensitive: true,
 			},
+			"password_wo": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_wo_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
 			"login": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -587,6 +597,10 @@ func resourceYandexMDBPostgreSQLClusterRead(d *schema.ResourceData, meta interfa
 		if err != nil {
 			return err
 		}
+		versions := passwordWriteOnlyVersions(stateUsers)
+		for _, u := range fUsers {
+			u["password_wo_version"] = versions[u["name"].(string)]
+		}
 		if err := d.Set("user", fUsers); err != nil {
 			return err
 		}
@@ -832,6 +846,11 @@ func prepareCreatePostgreSQLRequest(d *schema.ResourceData, meta *Config) (*post
 	if err != nil {
 		return nil, fmt.Errorf("Error while expanding user specs on PostgreSQL Cluster create: %s", err)
 	}
+	for i, user := range userSpecs {
+		if err := expandPGUserWriteOnlyPassword(d, user, fmt.Sprintf("user.%v.", i)); err != nil {
+			return nil, fmt.Errorf("Error while expanding user specs on PostgreSQL Cluster create: %s", err)
+		}
+	}
 
 	databaseSpecs, err := expandPGDatabaseSpecs(d)
 	if err != nil {
@@ -1342,6 +1361,9 @@ func updatePGUser(ctx context.Context, config *Config, d *schema.ResourceData, u
 	if err != nil {
 		return err
 	}
+	if err := expandPGUserWriteOnlyPassword(d, us, path); err != nil {
+		return err
+	}
 
 	changeMask := map[string]string{
 		"password":   "password",
@@ -1364,6 +1386,11 @@ func updatePGUser(ctx context.Context, config *Config, d *schema.ResourceData, u
 		}
 	}
 
+	// Write-only password is never stored, so it is sent again only when its version changes.
+	if d.HasChange(path+"password_wo_version") && !d.HasChange(path+"password") {
+		updatePath = append(updatePath, "password")
+	}
+
 	if len(updatePath) == 0 {
 		return nil
 	}
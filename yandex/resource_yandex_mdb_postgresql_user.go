@@ -53,9 +53,37 @@ func resourceYandexMDBPostgreSQLUser() *schema.Resource {
 				Required: true,
 			},
 			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo", "generate_password"},
+			},
+			"password_wo": {
 				Type:      schema.TypeString,
-				Required:  true,
+				Optional:  true,
 				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+			},
+			"generate_password": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"connection_manager": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connection_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 			"login": {
 				Type:     schema.TypeBool,
@@ -159,6 +187,18 @@ func expandPgUserSpec(d *schema.ResourceData) (*postgresql.UserSpec, error) {
 		user.Password = v.(string)
 	}
 
+	password, err := getWriteOnlyString(d, "password_wo")
+	if err != nil {
+		return nil, err
+	}
+	if password != "" {
+		user.Password = password
+	}
+
+	if v, ok := d.GetOk("generate_password"); ok && v.(bool) {
+		user.GeneratePassword = &wrappers.BoolValue{Value: true}
+	}
+
 	if v, ok := d.GetOkExists("login"); ok {
 		user.Login = &wrappers.BoolValue{Value: v.(bool)}
 	}
@@ -241,6 +281,7 @@ func resourceYandexMDBPostgreSQLUserRead(d *schema.ResourceData, meta interface{
 
 	d.Set("settings", settings)
 	d.Set("deletion_protection", mdbPGResolveTristateBoolean(user.DeletionProtection))
+	d.Set("connection_manager", flattenPGUserConnectionManager(user.ConnectionManager))
 
 	return nil
 }
@@ -272,6 +313,15 @@ func resourceYandexMDBPostgreSQLUserUpdate(d *schema.ResourceData, meta interfac
 		}
 	}
 
+	// Write-only password is never stored, so it is sent again only when its version changes.
+	if d.HasChange("password_wo_version") && !d.HasChange("password") {
+		updatePath = append(updatePath, "password")
+	}
+
+	if d.HasChange("generate_password") && user.GeneratePassword.GetValue() {
+		updatePath = append(updatePath, "generate_password")
+	}
+
 	if user.DeletionProtection != nil {
 		updatePath = append(updatePath, "deletion_protection")
 	}
@@ -294,6 +344,7 @@ func resourceYandexMDBPostgreSQLUserUpdate(d *schema.ResourceData, meta interfac
 		ClusterId:          clusterID,
 		UserName:           user.Name,
 		Password:           user.Password,
+		GeneratePassword:   user.GeneratePassword,
 		Permissions:        userPermissions,
 		ConnLimit:          user.ConnLimit.GetValue(),
 		Login:              user.Login,
@@ -552,7 +552,7 @@ func updateRedisClusterParams(d *schema.ResourceData, meta interface{}) error {
 		})
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get("labels"))
 		if err != nil {
 			return err
@@ -599,7 +599,7 @@ func sqlserverClusterUpdate(ctx context.Context, config *Config, d *schema.Resou
 
 	updatePath := []string{}
 	for field, path := range mdbSQLServerUpdateFieldsMap {
-		if d.HasChange(field) {
+		if hasFieldChange(d, field) {
 			updatePath = append(updatePath, path)
 		}
 	}
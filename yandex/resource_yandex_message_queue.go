@@ -11,13 +11,14 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common/client"
 )
 
 const defaultYMQRegion = "ru-central1"
@@ -561,11 +562,7 @@ func getKeysForYMQClient(d *schema.ResourceData, meta interface{}) (accessKey, s
 }
 
 func newYMQClientConfigFromKeys(accessKey, secretKey string, providerConfig *Config) *aws.Config {
-	return &aws.Config{
-		Credentials: credentials.NewStaticCredentials(accessKey, secretKey, ""),
-		Endpoint:    aws.String(providerConfig.YMQEndpoint),
-		Region:      aws.String(providerConfig.Region),
-	}
+	return client.NewYMQConfig(providerConfig.YMQEndpoint, providerConfig.Region, accessKey, secretKey)
 }
 
 func newYMQClientConfig(d *schema.ResourceData, meta interface{}) (config *aws.Config, err error) {
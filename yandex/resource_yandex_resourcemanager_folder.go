@@ -145,7 +145,7 @@ func resourceYandexResourceManagerFolderUpdate(d *schema.ResourceData, meta inte
 		UpdateMask: &field_mask.FieldMask{},
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get("labels"))
 		if err != nil {
 			return err
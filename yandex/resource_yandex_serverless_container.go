@@ -325,7 +325,7 @@ func resourceYandexServerlessContainerUpdate(d *schema.ResourceData, meta interf
 	if d.HasChange("description") {
 		updatePaths = append(updatePaths, "description")
 	}
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		updatePaths = append(updatePaths, "labels")
 	}
 
@@ -74,9 +74,9 @@ func TestAccYandexServerlessContainer_basic(t *testing.T) {
 	memory := (1 + acctest.RandIntRange(1, 4)) * 128
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexServerlessContainerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexServerlessContainerDestroy,
 		Steps: []resource.TestStep{
 			basicYandexServerlessContainerTestStep(containerName, containerDesc, memory, serverlessContainerTestImage1, &container, &revision, true),
 			serverlessContainerImportTestStep(),
@@ -98,9 +98,9 @@ func TestAccYandexServerlessContainer_update(t *testing.T) {
 	memoryUpdated := (4 + acctest.RandIntRange(4, 6)) * 128
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexServerlessContainerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexServerlessContainerDestroy,
 		Steps: []resource.TestStep{
 			// create container
 			basicYandexServerlessContainerTestStep(containerName, containerDesc, memory, serverlessContainerTestImage1, &container, &revision, true),
@@ -261,9 +261,9 @@ func TestAccYandexServerlessContainer_full(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testYandexServerlessContainerDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testYandexServerlessContainerDestroy,
 		Steps: []resource.TestStep{
 			testConfigFunc(params),
 			serverlessContainerImportTestStep(),
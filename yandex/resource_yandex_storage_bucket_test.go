@@ -23,6 +23,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/kms/v1"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-storage"
 )
 
 func init() {
@@ -104,10 +107,10 @@ func TestAccStorageBucket_basic(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfig(rInt),
@@ -127,10 +130,10 @@ func TestAccStorageBucket_namePrefix(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithNamePrefix(rInt),
@@ -148,10 +151,10 @@ func TestAccStorageBucket_generatedName(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithGeneratedName(rInt),
@@ -168,11 +171,11 @@ func TestAccStorageBucket_Policy(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithPolicy(rInt),
@@ -190,11 +193,11 @@ func TestAccStorageBucket_PolicyNone(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketBasic(rInt),
@@ -212,11 +215,11 @@ func TestAccStorageBucket_PolicyEmpty(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithEmptyPolicy(rInt),
@@ -234,11 +237,11 @@ func TestAccStorageBucket_updateAcl(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketAclPreConfig(rInt),
@@ -275,20 +278,20 @@ func TestAccStorageBucket_Website_Simple(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketWebsiteConfig(rInt),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckStorageBucketExists(resourceName),
 					wrapWithRetries(testAccCheckStorageBucketWebsite(resourceName, "index.html", "", "", "")),
-					resource.TestCheckResourceAttr(resourceName, "website.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "website.0.index_document", "index.html"),
-					resource.TestCheckResourceAttr(resourceName, "website.0.error_document", ""),
+					resource.TestCheckResourceAttrSet(resourceName, "website.%"),
+					resource.TestCheckResourceAttr(resourceName, "website.index_document", "index.html"),
+					resource.TestCheckNoResourceAttr(resourceName, "website.error_document"),
 					resource.TestCheckResourceAttr(resourceName, "website_endpoint", testAccWebsiteEndpoint(rInt)),
 				),
 			},
@@ -297,8 +300,8 @@ func TestAccStorageBucket_Website_Simple(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckStorageBucketExists(resourceName),
 					wrapWithRetries(testAccCheckStorageBucketWebsite(resourceName, "index.html", "error.html", "", "")),
-					resource.TestCheckResourceAttr(resourceName, "website.0.index_document", "index.html"),
-					resource.TestCheckResourceAttr(resourceName, "website.0.error_document", "error.html"),
+					resource.TestCheckResourceAttr(resourceName, "website.index_document", "index.html"),
+					resource.TestCheckResourceAttr(resourceName, "website.error_document", "error.html"),
 					resource.TestCheckResourceAttr(resourceName, "website_endpoint", testAccWebsiteEndpoint(rInt)),
 				),
 			},
@@ -307,8 +310,8 @@ func TestAccStorageBucket_Website_Simple(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckStorageBucketExists(resourceName),
 					wrapWithRetries(testAccCheckStorageBucketWebsite(resourceName, "", "", "", "")),
-					resource.TestCheckResourceAttr(resourceName, "website.#", "0"),
-					resource.TestCheckResourceAttr(resourceName, "website_endpoint", ""),
+					resource.TestCheckNoResourceAttr(resourceName, "website.%"),
+					resource.TestCheckNoResourceAttr(resourceName, "website_endpoint"),
 				),
 			},
 		},
@@ -320,11 +323,11 @@ func TestAccStorageBucket_WebsiteRedirect(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketWebsiteConfigWithRedirect(rInt),
@@ -343,11 +346,11 @@ func TestAccStorageBucket_WebsiteHttpsRedirect(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketWebsiteConfigWithHttpsRedirect(rInt),
@@ -366,11 +369,11 @@ func TestAccStorageBucket_WebsiteRoutingRules(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketWebsiteConfigWithRoutingRules(rInt),
@@ -409,10 +412,10 @@ func TestAccStorageBucket_shouldFailNotFound(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: "",
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            "",
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketDestroyedConfig(rInt),
@@ -432,9 +435,9 @@ func TestAccStorageBucket_VersioningNone(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketBasic(rInt),
@@ -452,10 +455,10 @@ func TestAccStorageBucket_VersioningEnabled(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: "",
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            "",
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithVersioning(rInt),
@@ -480,10 +483,10 @@ func TestAccStorageBucket_cors_update(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithCORS(rInt),
@@ -551,10 +554,10 @@ func TestAccStorageBucket_MaxSize(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketMaxSize(rInt, maxSize),
@@ -583,24 +586,24 @@ func TestAccStorageBucket_HTTPSConfig(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketHTTPSConfig(bucketName, externalCertificateID),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckStorageBucketExists(resourceName),
-					resource.TestCheckResourceAttr(resourceName, "https.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "https.0.certificate_id", externalCertificateID),
+					resource.TestCheckResourceAttrSet(resourceName, "https.%"),
+					resource.TestCheckResourceAttr(resourceName, "https.certificate_id", externalCertificateID),
 				),
 			}, {
 				Config: testAccStorageBucketWithCustomName(bucketName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckStorageBucketExists(resourceName),
-					resource.TestCheckResourceAttr(resourceName, "https.#", "0"),
+					resource.TestCheckNoResourceAttr(resourceName, "https.%"),
 				),
 			},
 		},
@@ -612,19 +615,19 @@ func TestAccStorageBucket_AnonymousAccessFlags(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketAnonymousAccessFlags(rInt),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckStorageBucketExists(resourceName),
-					resource.TestCheckResourceAttr(resourceName, "anonymous_access_flags.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "anonymous_access_flags.0.read", "true"),
-					resource.TestCheckResourceAttr(resourceName, "anonymous_access_flags.0.list", "true"),
-					resource.TestCheckResourceAttr(resourceName, "anonymous_access_flags.0.config_read", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "anonymous_access_flags.%"),
+					resource.TestCheckResourceAttr(resourceName, "anonymous_access_flags.read", "true"),
+					resource.TestCheckResourceAttr(resourceName, "anonymous_access_flags.list", "true"),
+					resource.TestCheckResourceAttr(resourceName, "anonymous_access_flags.config_read", "true"),
 				),
 			},
 		},
@@ -637,10 +640,10 @@ func TestAccStorageBucket_StorageClass(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketDefaultStorageClassCold(rInt),
@@ -660,10 +663,10 @@ func TestAccStorageBucket_FolderID(t *testing.T) {
 	folderID := testFolderID
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketWithFolderID(rInt, folderID),
@@ -681,10 +684,10 @@ func TestAccStorageBucket_cors_delete(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithCORS(rInt),
@@ -730,10 +733,10 @@ func TestAccStorageBucket_cors_emptyOrigin(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithCORSEmptyOrigin(rInt),
@@ -773,10 +776,10 @@ func TestAccStorageBucket_SSE(t *testing.T) {
 	var symmetricKey kms.SymmetricKey
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketSSEDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketSSEDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketSSEDefault(keyName, rInt),
@@ -808,10 +811,10 @@ func TestAccStorageBucket_ObjectLockNone(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketBasic(rInt),
@@ -830,10 +833,10 @@ func TestAccStorageBucket_ObjectLockEnabled(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithObjectLock(rInt, "", 0, 0),
@@ -857,10 +860,10 @@ func TestAccStorageBucket_ObjectLockWithDefaultRetentionDays(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithObjectLock(rInt, s3.ObjectLockModeGovernance, 10, 0),
@@ -890,10 +893,10 @@ func TestAccStorageBucket_ObjectLockWithDefaultRetentionYears(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithObjectLock(rInt, s3.ObjectLockModeGovernance, 0, 10),
@@ -926,12 +929,12 @@ func TestAccStorageBucket_Tagging(t *testing.T) {
 		Value: aws.String("value"),
 	}}
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
-		ErrorCheck:      checkErrorSkipNotImplemented(t),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
+		ErrorCheck:               checkErrorSkipNotImplemented(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketWithTags(rInt),
@@ -953,36 +956,6 @@ func TestAccStorageBucket_Tagging(t *testing.T) {
 	})
 }
 
-func TestStorageBucketName(t *testing.T) {
-	validNames := []string{
-		"foobar",
-		"127.0.0.1",
-		"foo..bar",
-		"foo.bar.baz",
-		"Foo.Bar",
-		strings.Repeat("x", 63),
-	}
-
-	for _, v := range validNames {
-		if err := validateS3BucketName(v); err != nil {
-			t.Fatalf("%q should be a valid storage bucket name", v)
-		}
-	}
-
-	invalidNames := []string{
-		"foo_bar",
-		"foo_bar_baz",
-		"foo;bar",
-		strings.Repeat("x", 64),
-	}
-
-	for _, v := range invalidNames {
-		if err := validateS3BucketName(v); err == nil {
-			t.Fatalf("%q should not be a valid storage bucket name", v)
-		}
-	}
-}
-
 func testAccCheckStorageBucketDestroy(s *terraform.State) error {
 	return testAccCheckStorageBucketDestroyWithProvider(s, testAccProvider)
 }
@@ -1433,12 +1406,12 @@ func testAccBucketName(randInt int) string {
 }
 
 func testAccBucketDomainName(randInt int) string {
-	name, _ := bucketDomainName(fmt.Sprintf("tf-test-bucket-%d", randInt), getExampleStorageEndpoint())
+	name, _ := yandex_storage.BucketDomainName(fmt.Sprintf("tf-test-bucket-%d", randInt), getExampleStorageEndpoint())
 	return name
 }
 
 func testAccWebsiteEndpoint(randInt int) string {
-	return fmt.Sprintf("tf-test-bucket-%d.%s", randInt, WebsiteDomainURL())
+	return common.StorageWebsiteEndpoint(fmt.Sprintf("tf-test-bucket-%d", randInt))
 }
 
 func newBucketConfigBuilder(randInt int) testAccStorageBucketConfigBuilder {
@@ -1532,7 +1505,7 @@ example of how it might look after calling this method:
 
 		default_storage_class = "STANDARD"
 
-		anonymous_access_flags {
+		anonymous_access_flags = {
 			list = false
 			read = false
 		}
@@ -1555,7 +1528,7 @@ func (b testAccStorageBucketConfigBuilder) render() string {
 		extendedTemplate = `
 	default_storage_class = %s
 
-	anonymous_access_flags {
+	anonymous_access_flags = {
 		list = %t
 		read = %t
 		config_read = %t
@@ -1641,7 +1614,7 @@ func testAccStorageBucketAclEmptyConfig(randInt int) string {
 }
 
 func testAccStorageBucketWebsiteConfig(randInt int) string {
-	const website = `website {
+	const website = `website = {
 		index_document = "index.html"
 	}`
 
@@ -1652,7 +1625,7 @@ func testAccStorageBucketWebsiteConfig(randInt int) string {
 }
 
 func testAccStorageBucketWebsiteConfigWithError(randInt int) string {
-	const website = `website {
+	const website = `website = {
 		index_document = "index.html"
 		error_document = "error.html"
 	}`
@@ -1675,7 +1648,7 @@ func testAccStorageBucketWithTags(randInt int) string {
 }
 
 func testAccStorageBucketWebsiteConfigWithRedirect(randInt int) string {
-	const website = `website {
+	const website = `website = {
 		redirect_all_requests_to = "http://hashicorp.com?my=query"
 	}`
 
@@ -1686,7 +1659,7 @@ func testAccStorageBucketWebsiteConfigWithRedirect(randInt int) string {
 }
 
 func testAccStorageBucketWebsiteConfigWithHttpsRedirect(randInt int) string {
-	const website = `website {
+	const website = `website = {
 		redirect_all_requests_to = "https://hashicorp.com?my=query"
 	}`
 
@@ -1697,7 +1670,7 @@ func testAccStorageBucketWebsiteConfigWithHttpsRedirect(randInt int) string {
 }
 
 func testAccStorageBucketWebsiteConfigWithRoutingRules(randInt int) string {
-	const website = `website {
+	const website = `website = {
 		index_document = "index.html"
 		error_document = "error.html"
 
@@ -1730,7 +1703,7 @@ func testAccStorageBucketDestroyedConfig(randInt int) string {
 }
 
 func testAccStorageBucketConfigWithVersioning(randInt int) string {
-	const versioning = `versioning {
+	const versioning = `versioning = {
 		enabled = true
 	}`
 	return newBucketConfigBuilder(randInt).
@@ -1740,7 +1713,7 @@ func testAccStorageBucketConfigWithVersioning(randInt int) string {
 }
 
 func testAccStorageBucketConfigWithDisableVersioning(randInt int) string {
-	const versioning = `versioning {
+	const versioning = `versioning = {
 		enabled = false
 	}`
 
@@ -1764,8 +1737,8 @@ func testAccStorageBucketConfigWithObjectLock(randInt int, mode string, days int
 
 	var rule string
 	if len(modeConfig)+len(daysConfig)+len(yearsConfig) > 0 {
-		rule = fmt.Sprintf(`rule{
-				default_retention {
+		rule = fmt.Sprintf(`rule = {
+				default_retention = {
 					%[1]s
 					%[2]s
 					%[3]s
@@ -1773,11 +1746,11 @@ func testAccStorageBucketConfigWithObjectLock(randInt int, mode string, days int
 			}`, modeConfig, daysConfig, yearsConfig)
 	}
 
-	versioningConfig := `versioning {
+	versioningConfig := `versioning = {
 		enabled = true
 	}`
 
-	objectLockConfig := fmt.Sprintf(`object_lock_configuration {
+	objectLockConfig := fmt.Sprintf(`object_lock_configuration = {
 		object_lock_enabled = "Enabled"
 		%s
 	}`, rule)
@@ -1790,13 +1763,13 @@ func testAccStorageBucketConfigWithObjectLock(randInt int, mode string, days int
 }
 
 func testAccStorageBucketConfigWithCORS(randInt int) string {
-	const cors = `cors_rule {
+	const cors = `cors_rule = [{
 		allowed_headers = ["*"]
 		allowed_methods = ["PUT","POST"]
 		allowed_origins = ["https://www.example.com"]
 		expose_headers  = ["x-amz-server-side-encryption","ETag"]
 		max_age_seconds = 3000
-	}`
+	}]`
 
 	return newBucketConfigBuilder(randInt).
 		addStatement(cors).
@@ -1805,13 +1778,13 @@ func testAccStorageBucketConfigWithCORS(randInt int) string {
 }
 
 func testAccStorageBucketConfigWithCORSUpdated(randInt int) string {
-	const cors = `cors_rule {
+	const cors = `cors_rule = [{
 		allowed_headers = ["*"]
 		allowed_methods = ["GET"]
 		allowed_origins = ["https://www.example.ru"]
 		expose_headers  = ["x-amz-server-side-encryption","ETag"]
 		max_age_seconds = 2000
-	}`
+	}]`
 
 	return newBucketConfigBuilder(randInt).
 		addStatement(cors).
@@ -1820,13 +1793,13 @@ func testAccStorageBucketConfigWithCORSUpdated(randInt int) string {
 }
 
 func testAccStorageBucketConfigWithCORSEmptyOrigin(randInt int) string {
-	const cors = `cors_rule {
+	const cors = `cors_rule = [{
 		allowed_headers = ["*"]
 		allowed_methods = ["PUT","POST"]
 		allowed_origins = [""]
 		expose_headers = ["x-amz-server-side-encryption","ETag"]
 		max_age_seconds = 3000
-	}`
+	}]`
 
 	return newBucketConfigBuilder(randInt).
 		addStatement(cors).
@@ -1844,7 +1817,7 @@ func testAccStorageBucketConfigWithNamePrefix(randInt int) string {
 
 	default_storage_class = "STANDARD"
 
-	anonymous_access_flags {
+	anonymous_access_flags = {
 		list = false
 		read = false
 	}
@@ -1860,7 +1833,7 @@ func testAccStorageBucketConfigWithGeneratedName(randInt int) string {
 
 	default_storage_class = "STANDARD"
 
-	anonymous_access_flags {
+	anonymous_access_flags = {
 		list = false
 		read = false
 	}
@@ -1869,7 +1842,7 @@ func testAccStorageBucketConfigWithGeneratedName(randInt int) string {
 }
 
 func testAccStorageBucketConfigWithLogging(randInt int) string {
-	const stmt = `logging {
+	const stmt = `logging = {
     		target_bucket = yandex_storage_bucket.log_bucket.id
 		target_prefix = "log/"
   	}`
@@ -1882,7 +1855,7 @@ func testAccStorageBucketConfigWithLogging(randInt int) string {
 
 	default_storage_class = "STANDARD"
 
-	anonymous_access_flags {
+	anonymous_access_flags = {
 		list = false
 		read = false
 	}
@@ -1897,15 +1870,15 @@ func testAccStorageBucketConfigWithLogging(randInt int) string {
 
 func testAccStorageBucketConfigWithLifecycle(randInt int) string {
 	const acl = `acl = "private"`
-	const stmt = `lifecycle_rule {
+	const stmt = `lifecycle_rule = [{
 		id      = "id1"
 		prefix  = "path1/"
 		enabled = true
 
-		expiration {
+		expiration = {
 			days = 365
 		}
-	}`
+	}]`
 
 	return newBucketConfigBuilder(randInt).
 		addStatement(acl).
@@ -1916,25 +1889,23 @@ func testAccStorageBucketConfigWithLifecycle(randInt int) string {
 
 func testAccStorageBucketConfigWithVersioningLifecycle(randInt int) string {
 	const acl = `acl = "private"`
-	const lifecycle = `lifecycle_rule {
+	const lifecycle = `lifecycle_rule = [{
 		id      = "id1"
 		prefix  = "path1/"
 		enabled = true
 
-		noncurrent_version_expiration {
+		noncurrent_version_expiration = {
 			days = 365
 		}
-	}
-
-	lifecycle_rule {
+	}, {
 		id      = "id2"
 		prefix  = "path2/"
 		enabled = false
 
-		noncurrent_version_expiration {
+		noncurrent_version_expiration = {
 			days = 365
 		}
-	}`
+	}]`
 
 	return newBucketConfigBuilder(randInt).
 		addStatement(acl).
@@ -1944,12 +1915,12 @@ func testAccStorageBucketConfigWithVersioningLifecycle(randInt int) string {
 }
 
 func testAccStorageBucketConfigLifecycleRuleExpirationEmptyConfigurationBlock(randInt int) string {
-	const stmt = `lifecycle_rule {
+	const stmt = `lifecycle_rule = [{
 	enabled = true
 	id      = "id1"
 
-	expiration {}
-}`
+	expiration = {}
+}]`
 
 	return newBucketConfigBuilder(randInt).
 		addStatement(stmt).
@@ -1958,11 +1929,11 @@ func testAccStorageBucketConfigLifecycleRuleExpirationEmptyConfigurationBlock(ra
 }
 
 func testAccStorageBucketConfigLifecycleRuleAbortIncompleteMultipartUploadDays(randInt int) string {
-	const stmt = `lifecycle_rule {
+	const stmt = `lifecycle_rule = [{
 	abort_incomplete_multipart_upload_days = 7
 	enabled                                = true
 	id                                     = "id1"
-}`
+}]`
 
 	return newBucketConfigBuilder(randInt).
 		addStatement(stmt).
@@ -1971,15 +1942,15 @@ func testAccStorageBucketConfigLifecycleRuleAbortIncompleteMultipartUploadDays(r
 }
 
 func testAccStorageBucketConfigWithTransitionToIceStorage(randInt int) string {
-	const lifecycle = `lifecycle_rule {
+	const lifecycle = `lifecycle_rule = [{
 	enabled = true
 	id      = "id1"
 
-	transition {
+	transition = [{
       days          = 30
       storage_class = "ICE"
-    }
-}`
+    }]
+}]`
 	return newBucketConfigBuilder(randInt).
 		addStatement(lifecycle).
 		asAdmin().
@@ -1987,15 +1958,15 @@ func testAccStorageBucketConfigWithTransitionToIceStorage(randInt int) string {
 }
 
 func testAccStorageBucketConfigWithNonCurrentVersionTransitionToIceStorage(randInt int) string {
-	const lifecycle = `lifecycle_rule {
+	const lifecycle = `lifecycle_rule = [{
 	enabled = true
 	id      = "id1"
 
-	noncurrent_version_transition {
+	noncurrent_version_transition = [{
       days          = 30
       storage_class = "ICE"
-    }
-}`
+    }]
+}]`
 	return newBucketConfigBuilder(randInt).
 		addStatement(lifecycle).
 		asAdmin().
@@ -2003,9 +1974,9 @@ func testAccStorageBucketConfigWithNonCurrentVersionTransitionToIceStorage(randI
 }
 
 func testAccStorageBucketSSEDefault(keyName string, randInt int) string {
-	const sse = `server_side_encryption_configuration {
-		rule {
-			apply_server_side_encryption_by_default {
+	const sse = `server_side_encryption_configuration = {
+		rule = {
+			apply_server_side_encryption_by_default = {
 				kms_master_key_id = yandex_kms_symmetric_key.key-a.id
 				sse_algorithm     = "aws:kms"
 			}
@@ -2102,7 +2073,7 @@ func testAccStorageBucketWithCustomName(name string) string {
 }
 
 func testAccStorageBucketHTTPSConfig(bucketName, certID string) string {
-	httpsStmt := fmt.Sprintf(`https {
+	httpsStmt := fmt.Sprintf(`https = {
 		certificate_id = "%s"
 	}`, certID)
 
@@ -2141,10 +2112,10 @@ func TestAccStorageBucket_Logging(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithLogging(rInt),
@@ -2162,10 +2133,10 @@ func TestAccStorageBucket_LifecycleBasic(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithLifecycle(rInt),
@@ -2173,9 +2144,9 @@ func TestAccStorageBucket_LifecycleBasic(t *testing.T) {
 					testAccCheckStorageBucketExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.id", "id1"),
 					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.prefix", "path1/"),
-					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.expiration.0.days", "365"),
-					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.expiration.0.date", ""),
-					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.expiration.0.expired_object_delete_marker", "false"),
+					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.expiration.days", "365"),
+					resource.TestCheckNoResourceAttr(resourceName, "lifecycle_rule.0.expiration.date"),
+					resource.TestCheckNoResourceAttr(resourceName, "lifecycle_rule.0.expiration.expired_object_delete_marker"),
 				),
 			},
 		},
@@ -2187,10 +2158,10 @@ func TestAccStorageBucket_LifecycleVersioning(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithVersioningLifecycle(rInt),
@@ -2199,11 +2170,11 @@ func TestAccStorageBucket_LifecycleVersioning(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.id", "id1"),
 					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.prefix", "path1/"),
 					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.enabled", "true"),
-					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.noncurrent_version_expiration.0.days", "365"),
+					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.0.noncurrent_version_expiration.days", "365"),
 					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.1.id", "id2"),
 					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.1.prefix", "path2/"),
 					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.1.enabled", "false"),
-					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.1.noncurrent_version_expiration.0.days", "365"),
+					resource.TestCheckResourceAttr(resourceName, "lifecycle_rule.1.noncurrent_version_expiration.days", "365"),
 				),
 			},
 		},
@@ -2216,10 +2187,10 @@ func TestAccStorageBucket_LifecycleRule_Expiration_EmptyConfigurationBlock(t *te
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigLifecycleRuleExpirationEmptyConfigurationBlock(rInt),
@@ -2237,10 +2208,10 @@ func TestAccStorageBucket_LifecycleRule_AbortIncompleteMultipartUploadDays_NoExp
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:      func() { testAccPreCheck(t) },
-		IDRefreshName: resourceName,
-		Providers:     testAccProviders,
-		CheckDestroy:  testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigLifecycleRuleAbortIncompleteMultipartUploadDays(rInt),
@@ -2257,10 +2228,10 @@ func TestAccStorageBucket_LifecycleRule_TransitionToIceStorage(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		IDRefreshName:     resourceName,
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithTransitionToIceStorage(rInt),
@@ -2278,10 +2249,10 @@ func TestAccStorageBucket_LifecycleRule_NonCurrentVersionTransitionToIceStorage(
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		IDRefreshName:     resourceName,
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithNonCurrentVersionTransitionToIceStorage(rInt),
@@ -2300,11 +2271,11 @@ func TestAccStorageBucket_ImportBasic(t *testing.T) {
 	resourceName := "yandex_storage_bucket.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageBucketDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageBucketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageBucketConfigWithPolicy(rInt),
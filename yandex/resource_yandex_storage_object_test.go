@@ -20,6 +20,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex-framework/yandex-storage"
 )
 
 func init() {
@@ -114,11 +116,11 @@ func TestAccStorageObject_source(t *testing.T) {
 	defer os.Remove(source)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectConfigSource(rInt, source),
@@ -140,11 +142,11 @@ func TestAccStorageObject_sourceHash(t *testing.T) {
 	defer os.Remove(source)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectConfigSourceHash(rInt, source),
@@ -176,11 +178,11 @@ func TestAccStorageObject_content(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectConfigContent(rInt, "some_bucket_content"),
@@ -199,11 +201,11 @@ func TestAccStorageObject_contentBase64(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectConfigContentBase64(rInt, base64.StdEncoding.EncodeToString([]byte("some_bucket_content"))),
@@ -222,11 +224,11 @@ func TestAccStorageObject_contentTypeEmpty(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectConfigContentType(rInt, ""),
@@ -245,11 +247,11 @@ func TestAccStorageObject_contentTypeText(t *testing.T) {
 	rInt := acctest.RandInt()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectConfigContentType(rInt, "text/plain"),
@@ -268,11 +270,11 @@ func TestAccStorageObject_updateAcl(t *testing.T) {
 	resourceName := "yandex_storage_object.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectAclPreConfig(rInt),
@@ -298,11 +300,11 @@ func TestAccStorageObject_ObjectLockNone(t *testing.T) {
 	resourceName := "yandex_storage_object.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectConfigContent(rInt, "some_bucket_content"),
@@ -322,11 +324,11 @@ func TestAccStorageObject_LegalHoldOn(t *testing.T) {
 	resourceName := "yandex_storage_object.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectConfigLegalHoldStatus(rInt, s3.ObjectLockLegalHoldStatusOn),
@@ -354,11 +356,11 @@ func TestAccStorageObject_LegalHoldOff(t *testing.T) {
 	resourceName := "yandex_storage_object.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectConfigLegalHoldStatus(rInt, s3.ObjectLockLegalHoldStatusOff),
@@ -389,12 +391,12 @@ func TestAccStorageObject_Tagging(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:        func() { testAccPreCheck(t) },
-		IDRefreshName:   resourceName,
-		IDRefreshIgnore: []string{"access_key", "secret_key"},
-		Providers:       testAccProviders,
-		CheckDestroy:    testAccCheckStorageObjectDestroy,
-		ErrorCheck:      checkErrorSkipNotImplemented(t),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		IDRefreshName:            resourceName,
+		IDRefreshIgnore:          []string{"access_key", "secret_key"},
+		ProtoV6ProviderFactories: testAccMuxProviderFactories,
+		CheckDestroy:             testAccCheckStorageObjectDestroy,
+		ErrorCheck:               checkErrorSkipNotImplemented(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStorageObjectTagsPreConfig(rInt, tags),
@@ -571,8 +573,8 @@ func testAccCheckStorageObjectTagging(name string, obj *s3.GetObjectOutput, tags
 		}
 
 		got := out.TagSet
-		tagsMap := storageBucketTaggingNormalize(tags)
-		gotMap := storageBucketTaggingNormalize(got)
+		tagsMap := yandex_storage.TagsToMap(tags)
+		gotMap := yandex_storage.TagsToMap(got)
 
 		for k, v := range tagsMap {
 			gotV, ok := gotMap[k]
@@ -763,7 +765,7 @@ resource "yandex_storage_object" "test" {
 func testAccStorageObjectTagsPreConfig(randInt int, tags []*s3.Tag) string {
 	bucketConfig := newBucketConfigBuilder(randInt).asEditor().render()
 
-	normalizedTags := storageBucketTaggingNormalize(tags)
+	normalizedTags := yandex_storage.TagsToMap(tags)
 	var sb strings.Builder
 	for k, v := range normalizedTags {
 		// to keep indentation
@@ -235,7 +235,7 @@ func resourceYandexVPCAddressUpdate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	const addrLabelsPropName = "labels"
-	if d.HasChange(addrLabelsPropName) {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get(addrLabelsPropName))
 		if err != nil {
 			return err
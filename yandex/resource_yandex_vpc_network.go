@@ -186,7 +186,7 @@ func resourceYandexVPCNetworkUpdate(d *schema.ResourceData, meta interface{}) er
 		UpdateMask: &field_mask.FieldMask{},
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get("labels"))
 		if err != nil {
 			return err
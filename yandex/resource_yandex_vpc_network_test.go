@@ -6,9 +6,13 @@ import (
 	"testing"
 
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/vpc/v1"
 )
@@ -230,6 +234,71 @@ func TestAccVPCNetwork_addSubnets(t *testing.T) {
 	})
 }
 
+func TestVPCNetworkOffline_crud(t *testing.T) {
+	config, server := newFakeCloudConfig(t)
+
+	d := schema.TestResourceDataRaw(t, resourceYandexVPCNetwork().Schema, map[string]interface{}{
+		"name":        "tf-network",
+		"description": "Network description for test",
+		"labels": map[string]interface{}{
+			"tf-label": "tf-label-value",
+		},
+	})
+	require.NoError(t, resourceYandexVPCNetworkCreate(d, config))
+	require.NotEmpty(t, d.Id())
+
+	assert.Equal(t, "tf-network", d.Get("name"))
+	assert.Equal(t, fakeFolderID, d.Get("folder_id"))
+	assert.Equal(t, map[string]interface{}{"tf-label": "tf-label-value"}, d.Get("labels"))
+	assert.NotEmpty(t, d.Get("default_security_group_id"))
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceYandexVPCNetwork().Schema, map[string]interface{}{
+		"name":        "tf-network-updated",
+		"description": "Network description for test",
+		"labels": map[string]interface{}{
+			"tf-label": "tf-label-value-updated",
+		},
+	})
+	d.SetId(id)
+	require.NoError(t, resourceYandexVPCNetworkUpdate(d, config))
+
+	network := server.Get(id).(*vpc.Network)
+	assert.Equal(t, "tf-network-updated", network.Name)
+	assert.Equal(t, map[string]string{"tf-label": "tf-label-value-updated"}, network.Labels)
+
+	require.NoError(t, resourceYandexVPCNetworkDelete(d, config))
+	assert.Nil(t, server.Get(id))
+}
+
+func TestVPCNetworkOffline_importAndDrift(t *testing.T) {
+	config, server := newFakeCloudConfig(t)
+
+	d := schema.TestResourceDataRaw(t, resourceYandexVPCNetwork().Schema, map[string]interface{}{
+		"name":        "tf-network",
+		"description": "Network description for test",
+	})
+	require.NoError(t, resourceYandexVPCNetworkCreate(d, config))
+	id := d.Id()
+
+	imported := resourceYandexVPCNetwork().TestResourceData()
+	imported.SetId(id)
+	require.NoError(t, resourceYandexVPCNetworkRead(imported, config))
+	assert.Equal(t, "tf-network", imported.Get("name"))
+	assert.Equal(t, "Network description for test", imported.Get("description"))
+	assert.Equal(t, fakeFolderID, imported.Get("folder_id"))
+
+	server.Mutate(id, func(m proto.Message) {
+		m.(*vpc.Network).Description = "changed out-of-band"
+	})
+	require.NoError(t, resourceYandexVPCNetworkRead(d, config))
+	assert.Equal(t, "changed out-of-band", d.Get("description"))
+
+	server.Delete(id)
+	require.NoError(t, resourceYandexVPCNetworkRead(d, config))
+	assert.Empty(t, d.Id(), "resource deleted out-of-band should be removed from state")
+}
+
 func testAccCheckVPCNetworkDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 
@@ -192,7 +192,7 @@ func resourceYandexVPCRouteTableUpdate(d *schema.ResourceData, meta interface{})
 		UpdateMask:   &field_mask.FieldMask{},
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get("labels"))
 		if err != nil {
 			return err
@@ -283,7 +283,7 @@ func resourceYandexVPCSecurityGroupUpdate(d *schema.ResourceData, meta interface
 		UpdateMask:      &field_mask.FieldMask{},
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get("labels"))
 		if err != nil {
 			return err
@@ -248,7 +248,7 @@ func resourceYandexVPCSubnetUpdate(d *schema.ResourceData, meta interface{}) err
 		UpdateMask: &field_mask.FieldMask{},
 	}
 
-	if d.HasChange("labels") {
+	if labelsChanged(d) {
 		labelsProp, err := expandLabels(d.Get("labels"))
 		if err != nil {
 			return err
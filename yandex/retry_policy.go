@@ -0,0 +1,129 @@
+package yandex
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/retrypolicy"
+)
+
+func retryPolicyRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"codes": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: common.Descriptions["retry_policy.codes"],
+		},
+		"base_backoff": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: common.Descriptions["retry_policy.base_backoff"],
+		},
+		"max_backoff": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: common.Descriptions["retry_policy.max_backoff"],
+		},
+		"max_elapsed_time": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: common.Descriptions["retry_policy.max_elapsed_time"],
+		},
+		"retry_conflicting_operations": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: common.Descriptions["retry_policy.retry_conflicting_operations"],
+		},
+	}
+}
+
+func retryPolicySchema() *schema.Schema {
+	policy := retryPolicyRuleSchema()
+
+	override := retryPolicyRuleSchema()
+	override["method"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: common.Descriptions["retry_policy.method_override.method"],
+	}
+	override["max_retries"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Description: common.Descriptions["retry_policy.method_override.max_retries"],
+	}
+
+	policy["method_override"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Resource{Schema: override},
+		Description: common.Descriptions["retry_policy.method_override"],
+	}
+
+	// MaxItems is not set to keep the schema identical to the framework provider one,
+	// where blocks are limited by validators. The limit is checked in expandRetryPolicy.
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Resource{Schema: policy},
+		Description: common.Descriptions["retry_policy"],
+	}
+}
+
+// expandRetryPolicy returns nil if there is no retry_policy block, so that the default retries are used.
+func expandRetryPolicy(d *schema.ResourceData, maxRetries int) (*retrypolicy.Policy, error) {
+	v, ok := d.GetOk("retry_policy")
+	if !ok {
+		return nil, nil
+	}
+	policies := v.([]interface{})
+	if len(policies) == 0 || policies[0] == nil {
+		return nil, nil
+	}
+	if len(policies) > 1 {
+		return nil, fmt.Errorf("at most one retry_policy block is allowed, got %d", len(policies))
+	}
+	policy := policies[0].(map[string]interface{})
+	policyPath := cty.GetAttrPath("retry_policy").IndexInt(0)
+
+	var overrides []retrypolicy.RuleConfig
+	for i, o := range policy["method_override"].([]interface{}) {
+		override := o.(map[string]interface{})
+		overridePath := policyPath.GetAttr("method_override").IndexInt(i)
+		rule := expandRetryPolicyRule(d, overridePath, override)
+		rule.Method = override["method"].(string)
+		if maxRetries := override["max_retries"].(int); isRawConfigSet(d, overridePath.GetAttr("max_retries"), maxRetries != 0) {
+			rule.MaxRetries = &maxRetries
+		}
+		overrides = append(overrides, rule)
+	}
+
+	return retrypolicy.NewPolicy(maxRetries, expandRetryPolicyRule(d, policyPath, policy), overrides)
+}
+
+func expandRetryPolicyRule(d *schema.ResourceData, path cty.Path, rule map[string]interface{}) retrypolicy.RuleConfig {
+	config := retrypolicy.RuleConfig{
+		Codes:          expandStringSlice(rule["codes"].([]interface{})),
+		BaseBackoff:    rule["base_backoff"].(string),
+		MaxBackoff:     rule["max_backoff"].(string),
+		MaxElapsedTime: rule["max_elapsed_time"].(string),
+	}
+	if retry := rule["retry_conflicting_operations"].(bool); isRawConfigSet(d, path.GetAttr("retry_conflicting_operations"), retry) {
+		config.RetryConflictingOperations = &retry
+	}
+	return config
+}
+
+// isRawConfigSet tells whether the attribute at the path is set in the configuration, so that
+// an explicit zero value can be told from an unset one. If the raw configuration is not
+// available, the attribute is set if its value is not zero, as nonZero tells.
+func isRawConfigSet(d *schema.ResourceData, path cty.Path, nonZero bool) bool {
+	v, diags := d.GetRawConfigAt(path)
+	if diags.HasError() || !v.IsKnown() {
+		return nonZero
+	}
+	return !v.IsNull()
+}
@@ -0,0 +1,119 @@
+package yandex
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tracing"
+)
+
+// withTracing wraps the resource or data source actions into spans tagged with its type.
+// The span is passed down to the API calls and operation waits both in the context of the
+// actions receiving one and in the Config.Context. The waits of operations not polled to
+// completion end with the action.
+func withTracing(resourceType string, r *schema.Resource) *schema.Resource {
+	_, hasFolderID := r.Schema["folder_id"]
+
+	wrap := func(f crudFunc, action string) crudFunc {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			ctx, span := startResourceSpan(context.Background(), resourceType, action, d, meta, hasFolderID)
+			meta, cancel := metaWithSpan(ctx, meta)
+			defer cancel()
+
+			err := f(d, meta)
+			endResourceSpan(span, d, err)
+			return err
+		}
+	}
+	wrapContext := func(f crudContextFunc, action string) crudContextFunc {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			ctx, span := startResourceSpan(ctx, resourceType, action, d, meta, hasFolderID)
+			ctx, cancelCtx := context.WithCancel(ctx)
+			defer cancelCtx()
+			meta, cancel := metaWithSpan(ctx, meta)
+			defer cancel()
+
+			diags := f(ctx, d, meta)
+			endResourceSpan(span, d, diagnosticsError(diags))
+			return diags
+		}
+	}
+
+	r.Create = wrap(r.Create, "create")
+	r.Read = wrap(r.Read, "read")
+	r.Update = wrap(r.Update, "update")
+	r.Delete = wrap(r.Delete, "delete")
+	r.CreateContext = wrapContext(r.CreateContext, "create")
+	r.ReadContext = wrapContext(r.ReadContext, "read")
+	r.UpdateContext = wrapContext(r.UpdateContext, "update")
+	r.DeleteContext = wrapContext(r.DeleteContext, "delete")
+	r.CreateWithoutTimeout = wrapContext(r.CreateWithoutTimeout, "create")
+	r.ReadWithoutTimeout = wrapContext(r.ReadWithoutTimeout, "read")
+	r.UpdateWithoutTimeout = wrapContext(r.UpdateWithoutTimeout, "update")
+	r.DeleteWithoutTimeout = wrapContext(r.DeleteWithoutTimeout, "delete")
+	return r
+}
+
+// metaWithSpan returns a copy of the provider Config whose Context carries the span and the
+// resource type of spanCtx, so that the actions using Config.Context call the API in the span
+// of the action. The context is canceled by the returned function.
+func metaWithSpan(spanCtx context.Context, meta interface{}) (interface{}, context.CancelFunc) {
+	config, ok := meta.(*Config)
+	if !ok || config == nil || config.Context() == nil {
+		return meta, func() {}
+	}
+	spanConfig := *config
+	var cancel context.CancelFunc
+	ctx := trace.ContextWithSpan(config.Context(), trace.SpanFromContext(spanCtx))
+	ctx = tracing.ContextWithResourceType(ctx, tracing.ResourceTypeFromContext(spanCtx))
+	spanConfig.contextWithClientTraceID, cancel = context.WithCancel(ctx)
+	return &spanConfig, cancel
+}
+
+func startResourceSpan(ctx context.Context, resourceType, action string, d *schema.ResourceData, meta interface{}, hasFolderID bool) (context.Context, trace.Span) {
+	ctx, span := tracing.StartResourceSpan(ctx, resourceType, action)
+	if !span.IsRecording() {
+		return ctx, span
+	}
+
+	folderID := ""
+	if hasFolderID {
+		folderID, _ = d.Get("folder_id").(string)
+	}
+	if config, ok := meta.(*Config); ok && config != nil && folderID == "" {
+		folderID = config.FolderID
+	}
+	if folderID != "" {
+		span.SetAttributes(tracing.AttrFolderID.String(folderID))
+	}
+	if d.Id() != "" {
+		span.SetAttributes(tracing.AttrResourceID.String(d.Id()))
+	}
+	return ctx, span
+}
+
+func endResourceSpan(span trace.Span, d *schema.ResourceData, err error) {
+	if span.IsRecording() && d.Id() != "" {
+		span.SetAttributes(tracing.AttrResourceID.String(d.Id()))
+	}
+	tracing.EndSpan(span, err)
+}
+
+func diagnosticsError(diags diag.Diagnostics) error {
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			return errors.New(d.Summary)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package yandex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tracing"
+)
+
+func TestWithTracingPassesSpanToConfigContext(t *testing.T) {
+	config, _ := newFakeCloudConfig(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	r := withTracing("yandex_vpc_network", resourceYandexVPCNetwork())
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "traced"})
+	require.NoError(t, r.Create(d, config))
+
+	var resourceSpan, createSpan tracetest.SpanStub
+	for _, s := range exporter.GetSpans() {
+		switch s.Name {
+		case "yandex_vpc_network create":
+			resourceSpan = s
+		case "yandex.cloud.vpc.v1.NetworkService/Create":
+			createSpan = s
+		}
+	}
+	require.True(t, resourceSpan.SpanContext.IsValid())
+	require.True(t, createSpan.SpanContext.IsValid())
+	assert.Equal(t, resourceSpan.SpanContext.SpanID(), createSpan.Parent.SpanID(),
+		"the API calls of the action must be children of its span")
+
+	resourceType := ""
+	for _, kv := range createSpan.Attributes {
+		if kv.Key == tracing.AttrResourceType {
+			resourceType = kv.Value.AsString()
+		}
+	}
+	assert.Equal(t, "yandex_vpc_network", resourceType)
+	assert.Equal(t, d.Id(), resourceSpanID(resourceSpan))
+}
+
+func resourceSpanID(span tracetest.SpanStub) string {
+	for _, kv := range span.Attributes {
+		if kv.Key == tracing.AttrResourceID {
+			return kv.Value.AsString()
+		}
+	}
+	return ""
+}
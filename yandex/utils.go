@@ -16,7 +16,6 @@ import (
 
 	"github.com/c2h5oh/datasize"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
@@ -26,13 +25,15 @@ import (
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/access"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/compute/v1"
-	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1"
-	"github.com/yandex-cloud/go-genproto/yandex/cloud/iam/v1/awscompatibility"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/operation"
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/resourcemanager/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk"
 	sdkoperation "github.com/yandex-cloud/go-sdk/operation"
 	"github.com/yandex-cloud/go-sdk/sdkresolvers"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common/client"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/tracing"
+	"github.com/yandex-cloud/terraform-provider-yandex/yandex/internal/hashcode"
 )
 
 type instanceAction int
@@ -111,120 +112,26 @@ func lockCloudByFolderID(config *Config, folderID string) (func(), error) {
 	}
 	c := CloudIamUpdater{cloudID: cloudID}
 	mutexKey := c.GetMutexKey()
-	mutexKV.Lock(mutexKey)
+	if err := mutexKV.LockContext(config.Context(), mutexKey); err != nil {
+		return nil, err
+	}
 	return func() {
 		mutexKV.Unlock(mutexKey)
 	}, nil
 }
 
 func createTemporaryStaticAccessKey(roleID string, config *Config) (accessKey, secretKey string, cleanup func(), err error) {
-	op, err := config.sdk.WrapOperation(config.sdk.IAM().ServiceAccount().Create(context.Background(), &iam.CreateServiceAccountRequest{
-		FolderId: config.FolderID,
-		Name:     acctest.RandomWithPrefix("tmp-sa-"),
-	}))
-	if err != nil {
-		return
-	}
-
-	protoMetadata, err := op.Metadata()
-	if err != nil {
-		return
-	}
-
-	md, ok := protoMetadata.(*iam.CreateServiceAccountMetadata)
-	if !ok {
-		err = fmt.Errorf("could not get temporary service account ID from create operation metadata")
-		return
-	}
-
-	saID := md.ServiceAccountId
-
-	err = op.Wait(context.Background())
+	key, err := client.CreateTemporaryStaticAccessKey(context.Background(), config.sdk, config.FolderID, roleID, mutexKV)
 	if err != nil {
 		return
 	}
 
-	deleteSa := func() {
-		// Use a folder updater in a similar way iam resources do to prevent modifying folder access binding while
-		// service account is being deleted.
-		updater, err := newFolderIamUpdaterFromFolderID(config.FolderID, config)
-		if err != nil {
-			log.Printf("[WARN] error deleting temporary service account: %s", err)
-			return
-		}
-
-		mutexKey := updater.GetMutexKey()
-		mutexKV.Lock(mutexKey)
-		defer mutexKV.Unlock(mutexKey)
-
-		op, err := config.sdk.WrapOperation(config.sdk.IAM().ServiceAccount().Delete(context.Background(), &iam.DeleteServiceAccountRequest{
-			ServiceAccountId: saID,
-		}))
-		if err != nil {
-			log.Printf("[WARN] error deleting temporary service account: %s", err)
-			return
-		}
-
-		err = op.Wait(context.Background())
-		if err != nil {
-			log.Printf("[WARN] error deleting temporary service account: %s", err)
-		}
-	}
-
-	createKey := func() (*awscompatibility.CreateAccessKeyResponse, error) {
-		op, err = config.sdk.WrapOperation(config.sdk.ResourceManager().Folder().UpdateAccessBindings(context.Background(), &access.UpdateAccessBindingsRequest{
-			ResourceId: config.FolderID,
-			AccessBindingDeltas: []*access.AccessBindingDelta{
-				{
-					Action: access.AccessBindingAction_ADD,
-					AccessBinding: &access.AccessBinding{
-						RoleId: roleID,
-						Subject: &access.Subject{
-							Id:   saID,
-							Type: "serviceAccount",
-						},
-					},
-				},
-			},
-		}))
-		if err != nil {
-			return nil, err
-		}
-
-		err = op.Wait(context.Background())
-		if err != nil {
-			return nil, err
-		}
-
-		sak, err := config.sdk.IAM().AWSCompatibility().AccessKey().Create(context.Background(), &awscompatibility.CreateAccessKeyRequest{
-			ServiceAccountId: saID,
-		})
-		if err != nil {
-			return nil, err
-		}
-
-		return sak, err
-	}
-
-	sak, err := createKey()
-	if err != nil {
-		deleteSa()
-		return
-	}
-
-	accessKey = sak.AccessKey.KeyId
-	secretKey = sak.Secret
 	cleanup = func() {
-		_, err := config.sdk.IAM().AWSCompatibility().AccessKey().Delete(context.Background(), &awscompatibility.DeleteAccessKeyRequest{
-			AccessKeyId: sak.AccessKey.Id,
-		})
-		if err != nil {
-			log.Printf("[WARN] error deleting temporary access key: %s", err)
+		if err := key.Delete(context.Background(), config.sdk, mutexKV); err != nil {
+			log.Printf("[WARN] %s", err)
 		}
-
-		deleteSa()
 	}
-	return
+	return key.AccessKey, key.SecretKey, cleanup, nil
 }
 
 func retryConflictingOperation(ctx context.Context, config *Config, action func() (*operation.Operation, error)) (*sdkoperation.Operation, error) {
@@ -247,13 +154,15 @@ func retryConflictingOperation(ctx context.Context, config *Config, action func(
 		}
 
 		log.Printf("[DEBUG] Waiting for conflicting operation %q to complete", operationID)
+		waitCtx, span := tracing.StartSpan(ctx, "conflicting operation wait", tracing.AttrOperationID.String(operationID))
 		req := &operation.GetOperationRequest{OperationId: operationID}
-		op, err = config.sdk.WrapOperation(config.sdk.Operation().Get(ctx, req))
+		op, err = config.sdk.WrapOperation(config.sdk.Operation().Get(waitCtx, req))
 		if err != nil {
+			tracing.EndSpan(span, err)
 			return nil, err
 		}
 
-		_ = op.Wait(ctx)
+		tracing.EndSpan(span, op.Wait(waitCtx))
 		log.Printf("[DEBUG] Conflicting operation %q has completed. Going to retry initial action.", operationID)
 	}
 }
@@ -852,7 +761,7 @@ func generateFieldMasks(d *schema.ResourceData, fieldsMap map[string]string) []s
 	changedPaths := make(map[string]bool)
 
 	for longField, longPath := range fieldsMap {
-		if !d.HasChange(longField) {
+		if !hasFieldChange(d, longField) {
 			continue
 		}
 
@@ -873,7 +782,7 @@ func generateFieldMasks(d *schema.ResourceData, fieldsMap map[string]string) []s
 				break
 			}
 
-			if !d.HasChange(field) {
+			if !hasFieldChange(d, field) {
 				continue
 			}
 
@@ -1000,3 +909,24 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// keysSetFunc returns the set hash function of the maps hashing the values of the keys.
+func keysSetFunc(keys ...string) schema.SchemaSetFunc {
+	return func(v interface{}) int {
+		var buf bytes.Buffer
+		m, ok := v.(map[string]interface{})
+
+		if !ok {
+			return 0
+		}
+
+		for _, key := range keys {
+			if v, ok := m[key]; ok {
+				value := fmt.Sprintf("%v", v)
+				buf.WriteString(value + "-")
+			}
+		}
+
+		return hashcode.String(buf.String())
+	}
+}
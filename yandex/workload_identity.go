@@ -0,0 +1,67 @@
+package yandex
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/yandex-cloud/terraform-provider-yandex/common"
+	"github.com/yandex-cloud/terraform-provider-yandex/pkg/workloadidentity"
+)
+
+func workloadIdentitySchema() *schema.Schema {
+	// MaxItems is not set to keep the schema identical to the framework provider one,
+	// the limit is checked in expandWorkloadIdentity.
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"service_account_id": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: common.Descriptions["workload_identity.service_account_id"],
+				},
+				"audience": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: common.Descriptions["workload_identity.audience"],
+				},
+				"token_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: common.Descriptions["workload_identity.token_file"],
+				},
+				"token_env": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: common.Descriptions["workload_identity.token_env"],
+				},
+				"endpoint": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: common.Descriptions["workload_identity.endpoint"],
+				},
+			},
+		},
+		Description: common.Descriptions["workload_identity"],
+	}
+}
+
+func expandWorkloadIdentity(d *schema.ResourceData) (*workloadidentity.Config, error) {
+	v := d.Get("workload_identity").([]interface{})
+	if len(v) == 0 || v[0] == nil {
+		return nil, nil
+	}
+	if len(v) > 1 {
+		return nil, fmt.Errorf("at most one workload_identity block is allowed, got %d", len(v))
+	}
+	wi := v[0].(map[string]interface{})
+	return &workloadidentity.Config{
+		ServiceAccountID: wi["service_account_id"].(string),
+		Audience:         wi["audience"].(string),
+		TokenFile:        wi["token_file"].(string),
+		TokenEnv:         wi["token_env"].(string),
+		Endpoint:         wi["endpoint"].(string),
+	}, nil
+}
@@ -0,0 +1,54 @@
+package yandex
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// getWriteOnlyString returns the value of a write-only string attribute from the resource configuration.
+// Write-only attributes are never persisted to the plan or the state, so the value is only available
+// while the resource is created or updated, and is empty otherwise.
+func getWriteOnlyString(d *schema.ResourceData, key string) (string, error) {
+	if d.GetRawConfig().IsNull() {
+		return "", nil
+	}
+
+	v, diags := d.GetRawConfigAt(attributePath(key))
+	if diags.HasError() {
+		return "", fmt.Errorf("error reading write-only attribute %q: %v", key, diags)
+	}
+	if v.IsNull() || !v.IsKnown() || !v.Type().Equals(cty.String) {
+		return "", nil
+	}
+	return v.AsString(), nil
+}
+
+// attributePath converts a key of the resource data, e.g. "user.0.password_wo", to the path of the attribute.
+func attributePath(key string) cty.Path {
+	var path cty.Path
+	for _, step := range strings.Split(key, ".") {
+		if i, err := strconv.Atoi(step); err == nil {
+			path = path.IndexInt(i)
+		} else {
+			path = path.GetAttr(step)
+		}
+	}
+	return path
+}
+
+// passwordWriteOnlyVersions returns password_wo_version of the users in the state by user name,
+// the version is never returned by the API.
+func passwordWriteOnlyVersions(stateUsers []interface{}) map[string]int {
+	versions := make(map[string]int)
+	for _, u := range stateUsers {
+		m := u.(map[string]interface{})
+		if v, ok := m["password_wo_version"].(int); ok {
+			versions[m["name"].(string)] = v
+		}
+	}
+	return versions
+}
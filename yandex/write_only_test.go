@@ -0,0 +1,50 @@
+package yandex
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributePath(t *testing.T) {
+	tests := []struct {
+		key  string
+		want cty.Path
+	}{
+		{
+			key:  "password_wo",
+			want: cty.GetAttrPath("password_wo"),
+		},
+		{
+			key:  "user.0.password_wo",
+			want: cty.GetAttrPath("user").IndexInt(0).GetAttr("password_wo"),
+		},
+		{
+			key:  "user.12.settings.name",
+			want: cty.GetAttrPath("user").IndexInt(12).GetAttr("settings").GetAttr("name"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.True(t, tt.want.Equals(attributePath(tt.key)), "unexpected path of %q", tt.key)
+		})
+	}
+}
+
+func TestGetWriteOnlyStringWithoutConfig(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"password_wo": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			WriteOnly: true,
+		},
+	}, map[string]interface{}{})
+
+	password, err := getWriteOnlyString(d, "password_wo")
+	require.NoError(t, err)
+	assert.Empty(t, password)
+}